@@ -17,6 +17,7 @@ package awsemfexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,17 @@ func TestLoadConfig(t *testing.T) {
 			RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
 			DimensionRollupOption: "ZeroAndSingleDimensionRollup",
 			MetricDeclarations:    []*MetricDeclaration{},
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:      true,
+				NumConsumers: 4,
+				QueueSize:    100,
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     30 * time.Second,
+				MaxElapsedTime:  10 * time.Minute,
+			},
 		})
 
 	r2 := cfg.Exporters["awsemf/resource_attr_to_label"].(*Config)
@@ -77,5 +89,7 @@ func TestLoadConfig(t *testing.T) {
 			DimensionRollupOption:       "ZeroAndSingleDimensionRollup",
 			ResourceToTelemetrySettings: exporterhelper.ResourceToTelemetrySettings{Enabled: true},
 			MetricDeclarations:          []*MetricDeclaration{},
+			QueueSettings:               exporterhelper.DefaultQueueSettings(),
+			RetrySettings:               exporterhelper.DefaultRetrySettings(),
 		})
 }
@@ -109,9 +109,9 @@ func (client *cloudWatchLogClient) PutLogEvents(input *cloudwatchlogs.PutLogEven
 				continue
 			default:
 				// ThrottlingException is handled here because the type cloudwatch.ThrottlingException is not yet available in public SDK
-				// Drop request if ThrottlingException happens
+				// Return the error if ThrottlingException happens, the exporter retries the request with a backoff
 				if awsErr.Code() == ErrCodeThrottlingException {
-					client.logger.Warn("cwlog_client: Error occurs in PutLogEvents, will not retry the request", zap.Error(awsErr), zap.String("LogGroupName", *input.LogGroupName), zap.String("LogStreamName", *input.LogStreamName))
+					client.logger.Warn("cwlog_client: Error occurs in PutLogEvents, will retry the request after a backoff", zap.Error(awsErr), zap.String("LogGroupName", *input.LogGroupName), zap.String("LogStreamName", *input.LogStreamName))
 					return token, err
 				}
 				client.logger.Error("cwlog_client: Error occurs in PutLogEvents", zap.Error(awsErr))
@@ -120,18 +120,20 @@ func (client *cloudWatchLogClient) PutLogEvents(input *cloudwatchlogs.PutLogEven
 
 		}
 
-		//TODO: Should have metrics to provide visibility of these failures
 		if response != nil {
 			if response.RejectedLogEventsInfo != nil {
 				rejectedLogEventsInfo := response.RejectedLogEventsInfo
 				if rejectedLogEventsInfo.TooOldLogEventEndIndex != nil {
 					client.logger.Warn(fmt.Sprintf("%d log events for log group name are too old", *rejectedLogEventsInfo.TooOldLogEventEndIndex), zap.String("LogGroupName", *input.LogGroupName))
+					recordDroppedLogEvents(reasonTooOld, *rejectedLogEventsInfo.TooOldLogEventEndIndex)
 				}
 				if rejectedLogEventsInfo.TooNewLogEventStartIndex != nil {
 					client.logger.Warn(fmt.Sprintf("%d log events for log group name are too new", *rejectedLogEventsInfo.TooNewLogEventStartIndex), zap.String("LogGroupName", *input.LogGroupName))
+					recordDroppedLogEvents(reasonTooNew, int64(len(input.LogEvents))-*rejectedLogEventsInfo.TooNewLogEventStartIndex)
 				}
 				if rejectedLogEventsInfo.ExpiredLogEventEndIndex != nil {
 					client.logger.Warn(fmt.Sprintf("%d log events for log group name are expired", *rejectedLogEventsInfo.ExpiredLogEventEndIndex), zap.String("LogGroupName", *input.LogGroupName))
+					recordDroppedLogEvents(reasonExpired, *rejectedLogEventsInfo.ExpiredLogEventEndIndex)
 				}
 			}
 
@@ -142,7 +144,7 @@ func (client *cloudWatchLogClient) PutLogEvents(input *cloudwatchlogs.PutLogEven
 		}
 	}
 	if err != nil {
-		client.logger.Error("All retries failed for PutLogEvents. Drop this request.", zap.Error(err))
+		client.logger.Error("All retries failed for PutLogEvents.", zap.Error(err))
 	}
 	return token, err
 }
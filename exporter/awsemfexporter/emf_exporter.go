@@ -22,6 +22,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -40,8 +42,10 @@ type emfExporter struct {
 	logger                 *zap.Logger
 
 	pusherMapLock sync.Mutex
-	retryCnt      int
-	collectorID   string
+	// streamLocks holds the lock of each (log group, log stream) pusher
+	streamLocks map[string]map[string]*sync.Mutex
+	retryCnt    int
+	collectorID string
 }
 
 // New func creates an EMF Exporter instance with data push callback func
@@ -88,6 +92,7 @@ func New(
 		collectorID:      collectorIdentifier.String(),
 	}
 	emfExporter.groupStreamToPusherMap = map[string]map[string]Pusher{}
+	emfExporter.streamLocks = map[string]map[string]*sync.Mutex{}
 
 	return emfExporter, nil
 }
@@ -108,6 +113,8 @@ func NewEmfExporter(
 		params.Logger,
 		exp.(*emfExporter).pushMetricsData,
 		exporterhelper.WithResourceToTelemetryConversion(config.(*Config).ResourceToTelemetrySettings),
+		exporterhelper.WithQueue(config.(*Config).QueueSettings),
+		exporterhelper.WithRetry(config.(*Config).RetrySettings),
 		exporterhelper.WithShutdown(exp.(*emfExporter).Shutdown),
 	)
 }
@@ -149,27 +156,49 @@ func (emf *emfExporter) pushMetricsData(_ context.Context, md pdata.Metrics) (dr
 
 		pusher := emf.getPusher(logGroup, logStream)
 		if pusher != nil {
-			for _, ple := range putLogEvents {
-				returnError := pusher.AddLogEntry(ple)
-				if returnError != nil {
-					err = wrapErrorIfBadRequest(&returnError)
-				}
-				if err != nil {
-					return totalDroppedMetrics, err
-				}
-			}
-			returnError := pusher.ForceFlush()
-			if returnError != nil {
-				err = wrapErrorIfBadRequest(&returnError)
-			}
-			if err != nil {
-				return totalDroppedMetrics, err
+			if err = emf.pushLogEvents(logGroup, logStream, pusher, putLogEvents); err != nil {
+				return totalDroppedMetrics, unsentMetricsError(err, rms, i)
 			}
 		}
 	}
 	return totalDroppedMetrics, nil
 }
 
+// pushLogEvents adds the log events to the pusher and flushes them. The requests are
+// pushed from the consumers of the sending queue concurrently, the pusher is locked
+// until the log events are flushed so that its batch only holds the log events of
+// one request, and a failed flush is only reported to the request it belongs to.
+func (emf *emfExporter) pushLogEvents(logGroup, logStream string, pusher Pusher, logEvents []*LogEvent) error {
+	lock := emf.getStreamLock(logGroup, logStream)
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, ple := range logEvents {
+		if err := pusher.AddLogEntry(ple); err != nil {
+			return wrapErrorIfBadRequest(&err)
+		}
+	}
+	if err := pusher.ForceFlush(); err != nil {
+		return wrapErrorIfBadRequest(&err)
+	}
+	return nil
+}
+
+// unsentMetricsError returns err as a partial error holding the resource metrics
+// from index failed on, so that the previous ones, already flushed to CloudWatch Logs,
+// are not sent twice when the request is retried.
+func unsentMetricsError(err error, rms pdata.ResourceMetricsSlice, failed int) error {
+	if consumererror.IsPermanent(err) {
+		return err
+	}
+	unsent := pdata.NewMetrics()
+	unsent.ResourceMetrics().Resize(rms.Len() - failed)
+	for i := failed; i < rms.Len(); i++ {
+		rms.At(i).CopyTo(unsent.ResourceMetrics().At(i - failed))
+	}
+	return consumererror.PartialMetricsError(err, unsent)
+}
+
 func (emf *emfExporter) getPusher(logGroup, logStream string) Pusher {
 	emf.pusherMapLock.Lock()
 	defer emf.pusherMapLock.Unlock()
@@ -189,6 +218,30 @@ func (emf *emfExporter) getPusher(logGroup, logStream string) Pusher {
 	return pusher
 }
 
+func (emf *emfExporter) getStreamLock(logGroup, logStream string) *sync.Mutex {
+	emf.pusherMapLock.Lock()
+	defer emf.pusherMapLock.Unlock()
+	return emf.streamLockLocked(logGroup, logStream)
+}
+
+// streamLockLocked returns the lock of the pusher, it must be called with pusherMapLock held.
+func (emf *emfExporter) streamLockLocked(logGroup, logStream string) *sync.Mutex {
+	if emf.streamLocks == nil {
+		emf.streamLocks = map[string]map[string]*sync.Mutex{}
+	}
+	streamToLockMap, ok := emf.streamLocks[logGroup]
+	if !ok {
+		streamToLockMap = map[string]*sync.Mutex{}
+		emf.streamLocks[logGroup] = streamToLockMap
+	}
+	lock, ok := streamToLockMap[logStream]
+	if !ok {
+		lock = &sync.Mutex{}
+		streamToLockMap[logStream] = lock
+	}
+	return lock
+}
+
 func (emf *emfExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	exporterCtx := obsreport.ExporterContext(ctx, "emf.exporterFullName")
 
@@ -202,10 +255,13 @@ func (emf *emfExporter) Shutdown(ctx context.Context) error {
 	defer emf.pusherMapLock.Unlock()
 
 	var err error
-	for _, streamToPusherMap := range emf.groupStreamToPusherMap {
-		for _, pusher := range streamToPusherMap {
+	for logGroup, streamToPusherMap := range emf.groupStreamToPusherMap {
+		for logStream, pusher := range streamToPusherMap {
 			if pusher != nil {
+				lock := emf.streamLockLocked(logGroup, logStream)
+				lock.Lock()
 				returnError := pusher.ForceFlush()
+				lock.Unlock()
 				if returnError != nil {
 					err = wrapErrorIfBadRequest(&returnError)
 				}
@@ -224,10 +280,28 @@ func (emf *emfExporter) Start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// wrapErrorIfBadRequest marks the client errors as permanent, except for the throttling
+// and sequence token errors, which are retried with a backoff by the queued retry of the exporter.
 func wrapErrorIfBadRequest(err *error) error {
+	if isRetryableError(*err) {
+		return *err
+	}
 	_, ok := (*err).(awserr.RequestFailure)
 	if ok && (*err).(awserr.RequestFailure).StatusCode() < 500 {
 		return consumererror.Permanent(*err)
 	}
 	return *err
 }
+
+func isRetryableError(err error) bool {
+	if request.IsErrorThrottle(err) {
+		return true
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case cloudwatchlogs.ErrCodeInvalidSequenceTokenException, cloudwatchlogs.ErrCodeOperationAbortedException:
+			return true
+		}
+	}
+	return false
+}
@@ -17,9 +17,11 @@ package awsemfexporter
 import (
 	"context"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
@@ -30,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -49,7 +52,7 @@ func (p *mockPusher) AddLogEntry(logEvent *LogEvent) error {
 	args := p.Called(nil)
 	errorStr := args.String(0)
 	if errorStr != "" {
-		return awserr.NewRequestFailure(nil, 400, "").(error)
+		return awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeInvalidParameterException, "", nil), 400, "").(error)
 	}
 	return nil
 }
@@ -58,7 +61,7 @@ func (p *mockPusher) ForceFlush() error {
 	args := p.Called(nil)
 	errorStr := args.String(0)
 	if errorStr != "" {
-		return awserr.NewRequestFailure(nil, 400, "").(error)
+		return awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeInvalidParameterException, "", nil), 400, "").(error)
 	}
 	return nil
 }
@@ -473,6 +476,145 @@ func TestPushMetricsDataWithErr(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// flushErrPusher returns the given errors from ForceFlush in turn.
+type flushErrPusher struct {
+	flushErrs []error
+	flushes   int
+}
+
+func (p *flushErrPusher) AddLogEntry(logEvent *LogEvent) error {
+	return nil
+}
+
+func (p *flushErrPusher) ForceFlush() error {
+	p.flushes++
+	if p.flushes > len(p.flushErrs) {
+		return nil
+	}
+	return p.flushErrs[p.flushes-1]
+}
+
+// newResourceMetrics returns metrics with one counter for each of the resources.
+func newResourceMetrics(resources ...string) pdata.Metrics {
+	mdata := consumerdata.MetricsData{
+		Metrics: []*metricspb.Metric{
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name: "spanCounter",
+					Unit: "Count",
+					Type: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				},
+				Timeseries: []*metricspb.TimeSeries{
+					{
+						Points: []*metricspb.Point{
+							{
+								Timestamp: &timestamp.Timestamp{Seconds: 100},
+								Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	md := internaldata.OCToMetrics(mdata)
+	md.ResourceMetrics().Resize(len(resources))
+	for i, resource := range resources {
+		if i > 0 {
+			md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().At(i))
+		}
+		md.ResourceMetrics().At(i).Resource().Attributes().Upsert("resource", pdata.NewAttributeValueString(resource))
+	}
+	return md
+}
+
+func TestPushMetricsDataReturnsUnsentResourceMetrics(t *testing.T) {
+	factory := NewFactory()
+	expCfg := factory.CreateDefaultConfig().(*Config)
+	expCfg.Region = "us-west-2"
+	expCfg.LogGroupName = "test-logGroupName"
+	expCfg.LogStreamName = "test-logStreamName"
+	exp, err := New(expCfg, component.ExporterCreateParams{Logger: zap.NewNop()})
+	require.NoError(t, err)
+
+	throttled := awserr.New(ErrCodeThrottlingException, "throttled", nil)
+	pusher := &flushErrPusher{flushErrs: []error{nil, throttled}}
+	exp.(*emfExporter).groupStreamToPusherMap = map[string]map[string]Pusher{
+		"test-logGroupName": {"test-logStreamName": pusher},
+	}
+
+	md := newResourceMetrics("R1", "R2", "R3")
+
+	_, err = exp.(*emfExporter).pushMetricsData(context.Background(), md)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	partialErr, ok := err.(consumererror.PartialError)
+	require.True(t, ok, "expected a partial error, got %v", err)
+	unsent := partialErr.GetMetrics().ResourceMetrics()
+	require.Equal(t, 2, unsent.Len())
+	for i, want := range []string{"R2", "R3"} {
+		v, _ := unsent.At(i).Resource().Attributes().Get("resource")
+		assert.Equal(t, want, v.StringVal())
+	}
+}
+
+// batchPusher fails the test if a flush sends the log events of several requests.
+type batchPusher struct {
+	t        *testing.T
+	perFlush int
+	pending  int
+	flushes  int
+}
+
+func (p *batchPusher) AddLogEntry(logEvent *LogEvent) error {
+	p.pending++
+	return nil
+}
+
+func (p *batchPusher) ForceFlush() error {
+	if p.flushes == 0 {
+		p.perFlush = p.pending
+	}
+	assert.Equal(p.t, p.perFlush, p.pending)
+	p.pending = 0
+	p.flushes++
+	return nil
+}
+
+func TestPushMetricsDataConcurrently(t *testing.T) {
+	factory := NewFactory()
+	expCfg := factory.CreateDefaultConfig().(*Config)
+	expCfg.Region = "us-west-2"
+	expCfg.LogGroupName = "test-logGroupName"
+	expCfg.LogStreamName = "test-logStreamName"
+	exp, err := New(expCfg, component.ExporterCreateParams{Logger: zap.NewNop()})
+	require.NoError(t, err)
+
+	pusher := &batchPusher{t: t}
+	exp.(*emfExporter).groupStreamToPusherMap = map[string]map[string]Pusher{
+		"test-logGroupName": {"test-logStreamName": pusher},
+	}
+
+	// The first request sets the number of log events of each flush
+	md := newResourceMetrics("R1")
+	_, err = exp.(*emfExporter).pushMetricsData(context.Background(), md)
+	require.NoError(t, err)
+	require.Greater(t, pusher.perFlush, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := exp.(*emfExporter).pushMetricsData(context.Background(), md)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 11, pusher.flushes)
+}
+
 func TestNewExporterWithoutConfig(t *testing.T) {
 	factory := NewFactory()
 	expCfg := factory.CreateDefaultConfig().(*Config)
@@ -549,10 +691,18 @@ func TestNewExporterWithoutSession(t *testing.T) {
 }
 
 func TestWrapErrorIfBadRequest(t *testing.T) {
-	awsErr := awserr.NewRequestFailure(nil, 400, "").(error)
+	awsErr := awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeInvalidParameterException, "", nil), 400, "").(error)
 	err := wrapErrorIfBadRequest(&awsErr)
 	assert.True(t, consumererror.IsPermanent(err))
-	awsErr = awserr.NewRequestFailure(nil, 500, "").(error)
+	awsErr = awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeServiceUnavailableException, "", nil), 500, "").(error)
+	err = wrapErrorIfBadRequest(&awsErr)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// Throttling and sequence token errors are retried
+	awsErr = awserr.NewRequestFailure(awserr.New(ErrCodeThrottlingException, "", nil), 400, "").(error)
+	err = wrapErrorIfBadRequest(&awsErr)
+	assert.False(t, consumererror.IsPermanent(err))
+	awsErr = &cloudwatchlogs.InvalidSequenceTokenException{}
 	err = wrapErrorIfBadRequest(&awsErr)
 	assert.False(t, consumererror.IsPermanent(err))
 }
@@ -8,6 +8,7 @@ require (
 	github.com/golang/protobuf v1.4.3
 	github.com/google/uuid v1.2.0
 	github.com/stretchr/testify v1.7.0
+	go.opencensus.io v0.22.5
 	go.opentelemetry.io/collector v0.19.0
 	go.uber.org/zap v1.16.0
 )
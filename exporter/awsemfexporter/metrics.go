@@ -0,0 +1,61 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// reasonTooOld is the reason of the log events rejected by CloudWatch Logs for being too old
+	reasonTooOld = "too_old"
+	// reasonTooNew is the reason of the log events rejected by CloudWatch Logs for being too new
+	reasonTooNew = "too_new"
+	// reasonExpired is the reason of the log events rejected by CloudWatch Logs for being older than the retention of the log group
+	reasonExpired = "expired"
+	// reasonOutOfRange is the reason of the log events discarded for a timestamp out of the range accepted by CloudWatch Logs
+	reasonOutOfRange = "timestamp_out_of_range"
+)
+
+var (
+	tagReason = tag.MustNewKey("reason")
+
+	mDroppedLogEvents = stats.Int64("awsemf_dropped_log_events", "Number of log events dropped by the exporter", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views of the exporter.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mDroppedLogEvents.Name(),
+			Measure:     mDroppedLogEvents,
+			Description: mDroppedLogEvents.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagReason},
+		},
+	}
+}
+
+// recordDroppedLogEvents records the number of log events dropped for the given reason.
+func recordDroppedLogEvents(reason string, count int64) {
+	if count <= 0 {
+		return
+	}
+	_ = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(tagReason, reason)}, mDroppedLogEvents.M(count))
+}
@@ -0,0 +1,79 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+func TestExporterMetrics(t *testing.T) {
+	expectedViewNames := []string{
+		"awsemf_dropped_log_events",
+	}
+
+	views := MetricViews()
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+func TestRejectedLogEventsMetrics(t *testing.T) {
+	// Reset the data recorded by the other tests
+	views := MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	svc := new(mockCloudWatchLogsClient)
+	putLogEventsInput := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  &logGroup,
+		LogStreamName: &logStreamName,
+		SequenceToken: &previousSequenceToken,
+		LogEvents:     make([]*cloudwatchlogs.InputLogEvent, 10),
+	}
+	putLogEventsOutput := &cloudwatchlogs.PutLogEventsOutput{
+		NextSequenceToken: &expectedNextSequenceToken,
+		RejectedLogEventsInfo: &cloudwatchlogs.RejectedLogEventsInfo{
+			ExpiredLogEventEndIndex:  aws.Int64(1),
+			TooOldLogEventEndIndex:   aws.Int64(3),
+			TooNewLogEventStartIndex: aws.Int64(6),
+		},
+	}
+	svc.On("PutLogEvents", putLogEventsInput).Return(putLogEventsOutput, nil)
+
+	client := newCloudWatchLogClient(svc, zap.NewNop())
+	_, err := client.PutLogEvents(putLogEventsInput, defaultRetryCount)
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(mDroppedLogEvents.Name())
+	require.NoError(t, err)
+	dropped := map[string]float64{}
+	for _, row := range rows {
+		require.Len(t, row.Tags, 1)
+		dropped[row.Tags[0].Value] = row.Data.(*view.SumData).Value
+	}
+	assert.Equal(t, map[string]float64{
+		reasonExpired: 1,
+		reasonTooOld:  3,
+		reasonTooNew:  4,
+	}, dropped)
+}
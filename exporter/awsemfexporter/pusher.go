@@ -16,6 +16,7 @@ package awsemfexporter
 
 import (
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -150,6 +151,10 @@ type pusher struct {
 
 	logEventBatch *LogEventBatch
 	retryCnt      int
+
+	// the log events are pushed from the consumers of the sending queue concurrently,
+	// the lock ensures that the requests to the log stream are sequential.
+	mutex sync.Mutex
 }
 
 //Create a pusher instance and start the instance afterwards
@@ -188,6 +193,9 @@ func newPusher(logGroupName, logStreamName *string,
 // Event size 256 KB (maximum). This limit cannot be changed.
 // Batch size 1 MB (maximum). This limit cannot be changed.
 func (p *pusher) AddLogEntry(logEvent *LogEvent) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	var err error
 	if logEvent != nil {
 		logEvent.truncateIfNeeded(p.logger)
@@ -200,6 +208,9 @@ func (p *pusher) AddLogEntry(logEvent *LogEvent) error {
 }
 
 func (p *pusher) ForceFlush() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	return p.flushLogEventBatch()
 }
 
@@ -229,6 +240,10 @@ func (p *pusher) pushLogEventBatch(req interface{}) error {
 	tmpToken, err = p.svcStructuredLog.PutLogEvents(putLogEventsInput, p.retryCnt)
 
 	if err != nil {
+		// Keep the last sequence token the service expects for the retry of the request
+		if tmpToken != nil {
+			p.streamToken = *tmpToken
+		}
 		return err
 	}
 
@@ -299,6 +314,7 @@ func (p *pusher) addLogEvent(logEvent *LogEvent) error {
 	if duration > LogEventTimestampLimitInPast || duration < LogEventTimestampLimitInFuture {
 		p.logger.Error("logpusher: the log entry's timestamp is older than 14 days or more than 2 hours in the future. Discard the log entry.",
 			zap.String("LogGroupName", *p.logGroupName), zap.String("LogEventTimestamp", utcTime.String()), zap.String("CurrentTime", currentTime.String()))
+		recordDroppedLogEvents(reasonOutOfRange, 1)
 		return err
 	}
 
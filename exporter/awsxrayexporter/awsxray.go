@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/xray"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -37,8 +38,6 @@ const (
 // request and then posts the request to the configured region's X-Ray endpoint.
 func newTraceExporter(
 	config configmodels.Exporter, params component.ExporterCreateParams, cn connAttr) (component.TracesExporter, error) {
-	typeLog := zap.String("type", string(config.Type()))
-	nameLog := zap.String("name", config.Name())
 	logger := params.Logger
 	awsConfig, session, err := GetAWSConfigSession(logger, cn, config.(*Config))
 	if err != nil {
@@ -48,58 +47,116 @@ func newTraceExporter(
 	return exporterhelper.NewTraceExporter(
 		config,
 		logger,
-		func(ctx context.Context, td pdata.Traces) (totalDroppedSpans int, err error) {
-			logger.Debug("TraceExporter", typeLog, nameLog, zap.Int("#spans", td.SpanCount()))
-			totalDroppedSpans = 0
-			documents := make([]*string, 0, td.SpanCount())
-			for i := 0; i < td.ResourceSpans().Len(); i++ {
-				rspans := td.ResourceSpans().At(i)
-				resource := rspans.Resource()
-				for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
-					spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
-					for k := 0; k < spans.Len(); k++ {
-						document, localErr := translator.MakeSegmentDocumentString(spans.At(k), resource,
-							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes)
-						if localErr != nil {
-							logger.Debug("Error translating span.", zap.Error(localErr))
-							totalDroppedSpans++
-							continue
-						}
-						documents = append(documents, &document)
+		pushTraceData(config.(*Config), logger, xrayClient),
+		exporterhelper.WithQueue(config.(*Config).QueueSettings),
+		exporterhelper.WithRetry(config.(*Config).RetrySettings),
+		exporterhelper.WithShutdown(func(context.Context) error {
+			return logger.Sync()
+		}),
+	)
+}
+
+// spanIndex is the position of a span in the traces.
+type spanIndex struct {
+	resource, library, span int
+}
+
+// pushTraceData returns the function converting the spans to segment documents and
+// posting them to X-Ray by batches of maxSegmentsPerPut.
+func pushTraceData(config *Config, logger *zap.Logger, xrayClient XRay) exporterhelper.PushTraces {
+	typeLog := zap.String("type", string(config.Type()))
+	nameLog := zap.String("name", config.Name())
+	return func(ctx context.Context, td pdata.Traces) (totalDroppedSpans int, err error) {
+		logger.Debug("TraceExporter", typeLog, nameLog, zap.Int("#spans", td.SpanCount()))
+		totalDroppedSpans = 0
+		documents := make([]*string, 0, td.SpanCount())
+		// indexes holds the position of the span of each document
+		indexes := make([]spanIndex, 0, td.SpanCount())
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rspans := td.ResourceSpans().At(i)
+			resource := rspans.Resource()
+			for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
+				spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					document, localErr := translator.MakeSegmentDocumentString(spans.At(k), resource,
+						config.IndexedAttributes, config.IndexAllAttributes,
+						config.LogGroupNames, config.InferRemoteNamespace)
+					if localErr != nil {
+						logger.Debug("Error translating span.", zap.Error(localErr))
+						totalDroppedSpans++
+						continue
 					}
+					documents = append(documents, &document)
+					indexes = append(indexes, spanIndex{resource: i, library: j, span: k})
 				}
 			}
-			for offset := 0; offset < len(documents); offset += maxSegmentsPerPut {
-				nextOffset := offset + maxSegmentsPerPut
-				if nextOffset > td.SpanCount() {
-					nextOffset = td.SpanCount()
-				}
-				input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents[offset:nextOffset]}
-				logger.Debug("request: " + input.String())
-				output, localErr := xrayClient.PutTraceSegments(&input)
-				if localErr != nil {
-					logger.Debug("response error", zap.Error(localErr))
-					err = wrapErrorIfBadRequest(&localErr) // record error
-				}
-				if output != nil {
-					logger.Debug("response: " + output.String())
-					if output.UnprocessedTraceSegments != nil {
-						totalDroppedSpans += len(output.UnprocessedTraceSegments)
-					}
+		}
+		recordDroppedSegments(ctx, reasonTranslation, totalDroppedSpans)
+		for offset := 0; offset < len(documents); offset += maxSegmentsPerPut {
+			nextOffset := offset + maxSegmentsPerPut
+			if nextOffset > len(documents) {
+				nextOffset = len(documents)
+			}
+			input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents[offset:nextOffset]}
+			logger.Debug("request: " + input.String())
+			output, localErr := xrayClient.PutTraceSegments(&input)
+			if localErr != nil {
+				logger.Debug("response error", zap.Error(localErr))
+				err = wrapErrorIfBadRequest(&localErr)
+				if consumererror.IsPermanent(err) {
+					return totalDroppedSpans, err
 				}
-				if err != nil {
-					break
+				// The previous batches were accepted by X-Ray, only the spans
+				// from this batch on are sent again when the request is retried.
+				return totalDroppedSpans, consumererror.PartialTracesError(err, unsentTraces(td, indexes[offset:]))
+			}
+			if output != nil {
+				logger.Debug("response: " + output.String())
+				if output.UnprocessedTraceSegments != nil {
+					totalDroppedSpans += len(output.UnprocessedTraceSegments)
+					recordDroppedSegments(ctx, reasonUnprocessed, len(output.UnprocessedTraceSegments))
 				}
 			}
-			return totalDroppedSpans, err
-		},
-		exporterhelper.WithShutdown(func(context.Context) error {
-			return logger.Sync()
-		}),
-	)
+		}
+		return totalDroppedSpans, nil
+	}
+}
+
+// unsentTraces returns the spans of td at the given indexes, with their resource and
+// instrumentation library.
+func unsentTraces(td pdata.Traces, indexes []spanIndex) pdata.Traces {
+	unsent := pdata.NewTraces()
+	rss := unsent.ResourceSpans()
+	var ilss pdata.InstrumentationLibrarySpansSlice
+	var spans pdata.SpanSlice
+	last := spanIndex{resource: -1, library: -1}
+	for _, index := range indexes {
+		rs := td.ResourceSpans().At(index.resource)
+		if index.resource != last.resource {
+			rss.Resize(rss.Len() + 1)
+			rs.Resource().CopyTo(rss.At(rss.Len() - 1).Resource())
+			ilss = rss.At(rss.Len() - 1).InstrumentationLibrarySpans()
+			last.library = -1
+		}
+		ils := rs.InstrumentationLibrarySpans().At(index.library)
+		if index.library != last.library {
+			ilss.Resize(ilss.Len() + 1)
+			ils.InstrumentationLibrary().CopyTo(ilss.At(ilss.Len() - 1).InstrumentationLibrary())
+			spans = ilss.At(ilss.Len() - 1).Spans()
+		}
+		spans.Resize(spans.Len() + 1)
+		ils.Spans().At(index.span).CopyTo(spans.At(spans.Len() - 1))
+		last = index
+	}
+	return unsent
 }
 
+// wrapErrorIfBadRequest marks the client errors as permanent, except for throttling
+// errors, which are retried with a backoff by the queued retry of the exporter.
 func wrapErrorIfBadRequest(err *error) error {
+	if request.IsErrorThrottle(*err) {
+		return *err
+	}
 	_, ok := (*err).(awserr.RequestFailure)
 	if ok && (*err).(awserr.RequestFailure).StatusCode() < 500 {
 		return consumererror.Permanent(*err)
@@ -23,8 +23,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/xray"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	semconventions "go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
@@ -59,6 +62,9 @@ func initializeTraceExporter() component.TracesExporter {
 	config := factory.CreateDefaultConfig()
 	config.(*Config).Region = "us-east-1"
 	config.(*Config).LocalMode = true
+	// Export synchronously so that the errors are returned
+	config.(*Config).QueueSettings.Enabled = false
+	config.(*Config).RetrySettings.Enabled = false
 	mconn := new(mockConn)
 	mconn.sn, _ = getDefaultSession(logger)
 	traceExporter, err := newTraceExporter(config, component.ExporterCreateParams{Logger: logger}, mconn)
@@ -188,3 +194,85 @@ func newSegmentID() pdata.SpanID {
 	}
 	return pdata.NewSpanID(r)
 }
+
+func TestWrapErrorIfBadRequest(t *testing.T) {
+	err := error(awserr.NewRequestFailure(awserr.New(xray.ErrCodeInvalidRequestException, "invalid", nil), 400, "requestID"))
+	assert.True(t, consumererror.IsPermanent(wrapErrorIfBadRequest(&err)))
+
+	// Throttling errors are retried
+	err = awserr.NewRequestFailure(awserr.New(xray.ErrCodeThrottledException, "throttled", nil), 429, "requestID")
+	assert.False(t, consumererror.IsPermanent(wrapErrorIfBadRequest(&err)))
+
+	err = awserr.NewRequestFailure(awserr.New("InternalFailure", "internal", nil), 500, "requestID")
+	assert.False(t, consumererror.IsPermanent(wrapErrorIfBadRequest(&err)))
+}
+
+// failingXRay fails the PutTraceSegments calls after the first accepted ones.
+type failingXRay struct {
+	XRay
+	accepted int
+	calls    int
+}
+
+func (x *failingXRay) PutTraceSegments(input *xray.PutTraceSegmentsInput) (*xray.PutTraceSegmentsOutput, error) {
+	x.calls++
+	if x.calls > x.accepted {
+		return nil, awserr.NewRequestFailure(awserr.New(xray.ErrCodeThrottledException, "throttled", nil), 429, "requestID")
+	}
+	return &xray.PutTraceSegmentsOutput{}, nil
+}
+
+func TestPushTraceDataReturnsUnsentSpans(t *testing.T) {
+	config := NewFactory().CreateDefaultConfig().(*Config)
+	client := &failingXRay{accepted: 1}
+	push := pushTraceData(config, zap.NewNop(), client)
+
+	// 60 spans in two libraries of a first resource, and 10 spans of a second one
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(2)
+	for i, libraries := range [][]int{{30, 30}, {10}} {
+		rspans := td.ResourceSpans().At(i)
+		constructResource().CopyTo(rspans.Resource())
+		rspans.Resource().Attributes().UpsertInt("resource", int64(i))
+		rspans.InstrumentationLibrarySpans().Resize(len(libraries))
+		for j, count := range libraries {
+			ispans := rspans.InstrumentationLibrarySpans().At(j)
+			ispans.InstrumentationLibrary().SetName(fmt.Sprintf("library%d", j))
+			ispans.Spans().Resize(count)
+			for k := 0; k < count; k++ {
+				constructHTTPServerSpan().CopyTo(ispans.Spans().At(k))
+				ispans.Spans().At(k).SetName(fmt.Sprintf("span%d.%d.%d", i, j, k))
+			}
+		}
+	}
+
+	dropped, err := push(context.Background(), td)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 2, client.calls)
+	partialErr, ok := err.(consumererror.PartialError)
+	if !assert.True(t, ok, "expected a partial error, got %v", err) {
+		return
+	}
+
+	// The spans of the first batch were accepted, the other ones are sent again
+	unsent := partialErr.GetTraces()
+	var names []string
+	for i := 0; i < unsent.ResourceSpans().Len(); i++ {
+		rspans := unsent.ResourceSpans().At(i)
+		resource, _ := rspans.Resource().Attributes().Get("resource")
+		for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
+			ispans := rspans.InstrumentationLibrarySpans().At(j)
+			for k := 0; k < ispans.Spans().Len(); k++ {
+				names = append(names, fmt.Sprintf("%d/%s/%s", resource.IntVal(), ispans.InstrumentationLibrary().Name(), ispans.Spans().At(k).Name()))
+			}
+		}
+	}
+	var want []string
+	for k := 20; k < 30; k++ {
+		want = append(want, fmt.Sprintf("0/library1/span0.1.%d", k))
+	}
+	for k := 0; k < 10; k++ {
+		want = append(want, fmt.Sprintf("1/library0/span1.0.%d", k))
+	}
+	assert.Equal(t, want, names)
+}
@@ -14,11 +14,16 @@
 
 package awsxrayexporter
 
-import "go.opentelemetry.io/collector/config/configmodels"
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
 
 // Config defines configuration for AWS X-Ray exporter.
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
 	// Maximum number of concurrent calls to AWS X-Ray to upload documents.
 	NumberOfWorkers int `mapstructure:"num_workers"`
 	// X-Ray service endpoint to which the collector sends segment documents.
@@ -46,4 +51,11 @@ type Config struct {
 	// Set to true to convert all OpenTelemetry attributes to X-Ray annotation (indexed) ignoring the IndexedAttributes option.
 	// Default value: false
 	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
+	// List of CloudWatch log group names the segments are linked to when the resource
+	// does not provide the `aws.log.group.names` or `aws.log.group.arns` attributes.
+	LogGroupNames []string `mapstructure:"aws_log_groups"`
+	// Set to true to use the `remote` namespace for subsegments describing SQL queries or calls to a
+	// `peer.service`, regardless of the span kind.
+	// Default value: false
+	InferRemoteNamespace bool `mapstructure:"infer_remote_namespace"`
 }
@@ -17,12 +17,14 @@ package awsxrayexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -59,5 +61,18 @@ func TestLoadConfig(t *testing.T) {
 			RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
 			IndexedAttributes:     []string{"indexed_attr_0", "indexed_attr_1"},
 			IndexAllAttributes:    false,
+			LogGroupNames:         []string{"group1", "group2"},
+			InferRemoteNamespace:  true,
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:      true,
+				NumConsumers: 4,
+				QueueSize:    100,
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     30 * time.Second,
+				MaxElapsedTime:  10 * time.Minute,
+			},
 		})
 }
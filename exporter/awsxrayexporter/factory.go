@@ -16,10 +16,13 @@ package awsxrayexporter
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -27,8 +30,19 @@ const (
 	typeStr = "awsxray"
 )
 
+var (
+	registerViewsOnce sync.Once
+	// errRegisterViews is the error registering the metric views, it is logged when an
+	// exporter is created since no logger is available to the factory.
+	errRegisterViews error
+)
+
 // NewFactory creates a factory for AWS-Xray exporter.
 func NewFactory() component.ExporterFactory {
+	registerViewsOnce.Do(func() {
+		errRegisterViews = view.Register(MetricViews()...)
+	})
+
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -41,6 +55,8 @@ func createDefaultConfig() configmodels.Exporter {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
+		QueueSettings:         exporterhelper.DefaultQueueSettings(),
+		RetrySettings:         exporterhelper.DefaultRetrySettings(),
 		NumberOfWorkers:       8,
 		Endpoint:              "",
 		RequestTimeoutSeconds: 30,
@@ -60,5 +76,8 @@ func createTraceExporter(
 	cfg configmodels.Exporter,
 ) (component.TracesExporter, error) {
 	eCfg := cfg.(*Config)
+	if errRegisterViews != nil {
+		params.Logger.Warn("Could not register the exporter metric views", zap.Error(errRegisterViews))
+	}
 	return newTraceExporter(eCfg, params, &Conn{})
 }
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +38,8 @@ func TestCreateDefaultConfig(t *testing.T) {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
+		QueueSettings:         exporterhelper.DefaultQueueSettings(),
+		RetrySettings:         exporterhelper.DefaultRetrySettings(),
 		NumberOfWorkers:       8,
 		Endpoint:              "",
 		RequestTimeoutSeconds: 30,
@@ -6,6 +6,7 @@ require (
 	github.com/aws/aws-sdk-go v1.36.31
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/awsxray v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.7.0
+	go.opencensus.io v0.22.5
 	go.opentelemetry.io/collector v0.19.0
 	go.uber.org/zap v1.16.0
 	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
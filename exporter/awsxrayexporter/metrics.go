@@ -0,0 +1,57 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxrayexporter
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// reasonTranslation is the reason of the spans that could not be translated into segments
+	reasonTranslation = "translation_error"
+	// reasonUnprocessed is the reason of the segments that X-Ray reported as unprocessed
+	reasonUnprocessed = "unprocessed"
+)
+
+var (
+	tagReason = tag.MustNewKey("reason")
+
+	mDroppedSegments = stats.Int64("awsxray_dropped_segments", "Number of segments dropped by the exporter", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views of the exporter.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mDroppedSegments.Name(),
+			Measure:     mDroppedSegments,
+			Description: mDroppedSegments.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagReason},
+		},
+	}
+}
+
+// recordDroppedSegments records the number of segments dropped for the given reason.
+func recordDroppedSegments(ctx context.Context, reason string, count int) {
+	if count == 0 {
+		return
+	}
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagReason, reason)}, mDroppedSegments.M(int64(count)))
+}
@@ -17,6 +17,7 @@ package translator
 import (
 	"bytes"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -34,9 +35,12 @@ const (
 	awsEcsLaunchType               = "aws.ecs.launchtype"
 	awsLogGroupNames               = "aws.log.group.names"
 	awsLogGroupArns                = "aws.log.group.arns"
+
+	// logGroupNamesSeparator separates the log group names when they are provided as a single string.
+	logGroupNamesSeparator = "&"
 )
 
-func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]string, *awsxray.AWSData) {
+func makeAws(attributes map[string]string, resource pdata.Resource, logGroupNames []string) (map[string]string, *awsxray.AWSData) {
 	var (
 		cloud        string
 		service      string
@@ -68,6 +72,7 @@ func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]
 		taskFamily   string
 		launchType   string
 		logGroups    pdata.AnyValueArray
+		logGroupList []string
 		logGroupArns pdata.AnyValueArray
 		cwl          []awsxray.LogGroupMetadata
 		ec2          *awsxray.EC2Metadata
@@ -128,7 +133,12 @@ func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]
 		case awsEcsLaunchType:
 			launchType = value.StringVal()
 		case awsLogGroupNames:
-			logGroups = value.ArrayVal()
+			switch value.Type() {
+			case pdata.AttributeValueARRAY:
+				logGroups = value.ArrayVal()
+			case pdata.AttributeValueSTRING:
+				logGroupList = strings.Split(value.StringVal(), logGroupNamesSeparator)
+			}
 		case awsLogGroupArns:
 			logGroupArns = value.ArrayVal()
 		}
@@ -206,11 +216,15 @@ func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]
 	}
 
 	// Since we must couple log group ARNs and Log Group Names in the same CWLogs object, we first try to derive the
-	// names from the ARN, then fall back to just recording the names
+	// names from the ARN, then fall back to just recording the names, and finally to the configured names
 	if logGroupArns != (pdata.AnyValueArray{}) && logGroupArns.Len() > 0 {
 		cwl = getLogGroupMetadata(logGroupArns, true)
 	} else if logGroups != (pdata.AnyValueArray{}) && logGroups.Len() > 0 {
 		cwl = getLogGroupMetadata(logGroups, false)
+	} else if len(logGroupList) > 0 {
+		cwl = getLogGroupMetadataFromNames(logGroupList)
+	} else if len(logGroupNames) > 0 {
+		cwl = getLogGroupMetadataFromNames(logGroupNames)
 	}
 
 	if sdkName != "" && sdkLanguage != "" {
@@ -264,6 +278,20 @@ func getLogGroupMetadata(logGroups pdata.AnyValueArray, isArn bool) []awsxray.Lo
 	return lgm
 }
 
+// Given a list of log group names, create the LogGroupMetadata objects with arn omitted, skipping empty names
+func getLogGroupMetadataFromNames(logGroups []string) []awsxray.LogGroupMetadata {
+	var lgm []awsxray.LogGroupMetadata
+	for _, name := range logGroups {
+		if name = strings.TrimSpace(name); name != "" {
+			lgm = append(lgm, awsxray.LogGroupMetadata{
+				LogGroup: awsxray.String(name),
+			})
+		}
+	}
+
+	return lgm
+}
+
 func parseLogGroup(arn string) string {
 	i := bytes.LastIndexByte([]byte(arn), byte(':'))
 	if i != -1 {
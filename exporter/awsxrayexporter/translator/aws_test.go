@@ -42,7 +42,7 @@ func TestAwsFromEc2Resource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -95,7 +95,7 @@ func TestAwsFromEcsResource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -130,7 +130,7 @@ func TestAwsFromBeanstalkResource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -168,7 +168,7 @@ func TestAwsFromEksResource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -212,7 +212,7 @@ func TestAwsWithAwsSqsResources(t *testing.T) {
 	attributes[awsxray.AWSQueueURLAttribute] = queueURL
 	attributes["employee.id"] = "XB477"
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -225,7 +225,7 @@ func TestAwsWithSqsAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[awsxray.AWSQueueURLAttribute2] = queueURL
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -259,7 +259,7 @@ func TestAwsWithAwsDynamoDbResources(t *testing.T) {
 	attributes[awsxray.AWSRequestIDAttribute] = "75107C82-EC8A-4F75-883F-4440B491B0AB"
 	attributes[awsxray.AWSTableNameAttribute] = tableName
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -273,7 +273,7 @@ func TestAwsWithDynamoDbAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[awsxray.AWSTableNameAttribute2] = tableName
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -285,7 +285,7 @@ func TestAwsWithRequestIdAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[awsxray.AWSRequestIDAttribute2] = requestid
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -299,7 +299,7 @@ func TestJavaSDK(t *testing.T) {
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKLanguage, "java")
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKVersion, "1.2.3")
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -315,7 +315,7 @@ func TestJavaAutoInstrumentation(t *testing.T) {
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKVersion, "1.2.3")
 	resource.Attributes().InsertString(semconventions.AttributeTelemetryAutoVersion, "3.4.5")
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -331,7 +331,7 @@ func TestGoSDK(t *testing.T) {
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKLanguage, "go")
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKVersion, "2.0.3")
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -346,7 +346,7 @@ func TestCustomSDK(t *testing.T) {
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKLanguage, "java")
 	resource.Attributes().InsertString(semconventions.AttributeTelemetrySDKVersion, "2.0.3")
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -372,7 +372,7 @@ func TestLogGroups(t *testing.T) {
 
 	resource.Attributes().Insert(awsLogGroupNames, lg)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -403,7 +403,7 @@ func TestLogGroupsFromArns(t *testing.T) {
 
 	resource.Attributes().Insert(awsLogGroupArns, lga)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -411,3 +411,32 @@ func TestLogGroupsFromArns(t *testing.T) {
 	assert.Contains(t, awsData.CWLogs, cwl1)
 	assert.Contains(t, awsData.CWLogs, cwl2)
 }
+
+func TestLogGroupsFromString(t *testing.T) {
+	attributes := make(map[string]string)
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(awsLogGroupNames, "group1&group2")
+
+	filtered, awsData := makeAws(attributes, resource, []string{"configured"})
+
+	assert.NotNil(t, filtered)
+	assert.NotNil(t, awsData)
+	assert.Equal(t, []awsxray.LogGroupMetadata{
+		{LogGroup: awsxray.String("group1")},
+		{LogGroup: awsxray.String("group2")},
+	}, awsData.CWLogs)
+}
+
+func TestLogGroupsFromConfig(t *testing.T) {
+	attributes := make(map[string]string)
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(semconventions.AttributeCloudProvider, semconventions.AttributeCloudProviderAWS)
+
+	filtered, awsData := makeAws(attributes, resource, []string{"group1", ""})
+
+	assert.NotNil(t, filtered)
+	assert.NotNil(t, awsData)
+	assert.Equal(t, []awsxray.LogGroupMetadata{
+		{LogGroup: awsxray.String("group1")},
+	}, awsData.CWLogs)
+}
@@ -59,13 +59,21 @@ const (
 	identifierOffset = 11 // offset of identifier within traceID
 )
 
+const (
+	// defaultMetadataNamespace is the metadata namespace the attributes and events are stored in
+	defaultMetadataNamespace = "default"
+	// eventsMetadataKey is the key of the span events in the default metadata namespace
+	eventsMetadataKey = "otel.events"
+)
+
 var (
 	writers = newWriterPool(2048)
 )
 
 // MakeSegmentDocumentString converts an OpenTelemetry Span to an X-Ray Segment and then serialzies to JSON
-func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (string, error) {
-	segment, err := MakeSegment(span, resource, indexedAttrs, indexAllAttrs)
+func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool,
+	logGroupNames []string, inferRemoteNamespace bool) (string, error) {
+	segment, err := MakeSegment(span, resource, indexedAttrs, indexAllAttrs, logGroupNames, inferRemoteNamespace)
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +87,8 @@ func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexed
 }
 
 // MakeSegment converts an OpenTelemetry Span to an X-Ray Segment
-func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (*awsxray.Segment, error) {
+func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool,
+	logGroupNames []string, inferRemoteNamespace bool) (*awsxray.Segment, error) {
 	var segmentType string
 
 	storeResource := true
@@ -102,10 +111,11 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		httpfiltered, http                     = makeHTTP(span)
 		isError, isFault, causefiltered, cause = makeCause(span, httpfiltered, resource)
 		origin                                 = determineAwsOrigin(resource)
-		awsfiltered, aws                       = makeAws(causefiltered, resource)
+		awsfiltered, aws                       = makeAws(causefiltered, resource, logGroupNames)
 		service                                = makeService(resource)
 		sqlfiltered, sql                       = makeSQL(awsfiltered)
 		user, annotations, metadata            = makeXRayAttributes(sqlfiltered, resource, storeResource, indexedAttrs, indexAllAttrs)
+		events                                 = makeEvents(span)
 		name                                   string
 		namespace                              string
 	)
@@ -176,6 +186,25 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		namespace = "remote"
 	}
 
+	if namespace == "" && inferRemoteNamespace && segmentType == "subsegment" {
+		// SQL queries and calls to a peer service are downstream calls even when the span kind
+		// does not say so, which is common for spans emitted by non-AWS SDKs.
+		_, peerService := attributes.Get(semconventions.AttributePeerService)
+		if peerService || sql != nil {
+			namespace = "remote"
+		}
+	}
+
+	if len(events) > 0 {
+		if metadata == nil {
+			metadata = map[string]map[string]interface{}{}
+		}
+		if _, ok := metadata[defaultMetadataNamespace]; !ok {
+			metadata[defaultMetadataNamespace] = map[string]interface{}{}
+		}
+		metadata[defaultMetadataNamespace][eventsMetadataKey] = events
+	}
+
 	return &awsxray.Segment{
 		ID:          awsxray.String(span.SpanID().HexString()),
 		TraceID:     awsxray.String(traceID),
@@ -384,12 +413,41 @@ func makeXRayAttributes(attributes map[string]string, resource pdata.Resource, s
 	}
 
 	if len(defaultMetadata) > 0 {
-		metadata["default"] = defaultMetadata
+		metadata[defaultMetadataNamespace] = defaultMetadata
 	}
 
 	return user, annotations, metadata
 }
 
+// makeEvents converts the span events into X-Ray metadata values, keeping their timestamps and attributes.
+// The exception events of spans with an error status are skipped as they are already part of the cause.
+func makeEvents(span pdata.Span) []interface{} {
+	var (
+		events      []interface{}
+		errorStatus = span.Status().Code() == pdata.StatusCodeError
+	)
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		if errorStatus && event.Name() == semconventions.AttributeExceptionEventName {
+			continue
+		}
+
+		converted := map[string]interface{}{
+			"name":      event.Name(),
+			"timestamp": timestampToFloatSeconds(event.Timestamp()),
+		}
+		if event.Attributes().Len() > 0 {
+			attributes := map[string]interface{}{}
+			event.Attributes().ForEach(func(key string, value pdata.AttributeValue) {
+				attributes[key] = metadataValue(value)
+			})
+			converted["attributes"] = attributes
+		}
+		events = append(events, converted)
+	}
+	return events
+}
+
 func annotationValue(value pdata.AttributeValue) interface{} {
 	switch value.Type() {
 	case pdata.AttributeValueSTRING:
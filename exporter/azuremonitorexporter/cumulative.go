@@ -0,0 +1,121 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+// Contains the conversion of cumulative sums and histograms into deltas
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
+)
+
+// Time series which are not reported for an hour are forgotten
+const cumulativeTTLSeconds = 3600
+
+// A point of a cumulative time series, or the difference between two of them
+type cumulativePoint struct {
+	startTime    pdata.TimestampUnixNano
+	value        float64
+	count        uint64
+	bucketCounts []uint64
+}
+
+// AppInsights aggregates the values it receives, so the points of cumulative time series are
+// sent as their difference with the previous point of the time series
+type cumulativeToDelta struct {
+	prevPts *ttlmap.TTLMap
+}
+
+func newCumulativeToDelta() *cumulativeToDelta {
+	prevPts := ttlmap.New(cumulativeTTLSeconds/2, cumulativeTTLSeconds)
+	prevPts.Start()
+	return &cumulativeToDelta{prevPts: prevPts}
+}
+
+// Records pt as the last point of the time series identified by key, and returns its difference
+// with the previous point. The first point of a time series has nothing to be compared with, and
+// ok is false. A restarted time series, with a new start time or with lower values, starts over
+// from pt.
+func (c *cumulativeToDelta) delta(key string, pt cumulativePoint) (delta cumulativePoint, ok bool) {
+	v := c.prevPts.Get(key)
+	c.prevPts.Put(key, pt)
+	if v == nil {
+		return cumulativePoint{}, false
+	}
+
+	prev := v.(cumulativePoint)
+	if pt.startTime != prev.startTime || pt.value < prev.value || pt.count < prev.count {
+		return pt, true
+	}
+
+	delta = cumulativePoint{
+		startTime: prev.startTime,
+		value:     pt.value - prev.value,
+		count:     pt.count - prev.count,
+	}
+	if len(pt.bucketCounts) == len(prev.bucketCounts) {
+		delta.bucketCounts = make([]uint64, len(pt.bucketCounts))
+		for i, count := range pt.bucketCounts {
+			if count < prev.bucketCounts[i] {
+				return pt, true
+			}
+			delta.bucketCounts[i] = count - prev.bucketCounts[i]
+		}
+	}
+	return delta, true
+}
+
+// Cumulative sums are converted to deltas only when monotonic, the value of other sums is sent as is, like a gauge
+func isCumulative(temporality pdata.AggregationTemporality, monotonic bool) bool {
+	return monotonic && temporality == pdata.AggregationTemporalityCumulative
+}
+
+// Replaces pt with its difference with the previous point of its time series, it returns false for
+// the first point of the time series, which is not sent
+func toDelta(deltas *cumulativeToDelta, resource pdata.Resource, metric pdata.Metric, labels pdata.StringMap, pt *cumulativePoint) bool {
+	delta, ok := deltas.delta(timeSeriesKey(resource, metric, labels), *pt)
+	if ok {
+		*pt = delta
+	}
+	return ok
+}
+
+// Returns a key identifying the time series of a data point. The parts of the key are quoted, so
+// that distinct time series never share a key.
+func timeSeriesKey(resource pdata.Resource, metric pdata.Metric, labels pdata.StringMap) string {
+	var attributes []string
+	resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		attributes = append(attributes, strconv.Quote(k)+"="+strconv.Quote(tracetranslator.AttributeValueToString(v, false)))
+	})
+	sort.Strings(attributes)
+
+	var labelPairs []string
+	labels.ForEach(func(k string, v string) {
+		labelPairs = append(labelPairs, strconv.Quote(k)+"="+strconv.Quote(v))
+	})
+	sort.Strings(labelPairs)
+
+	return strings.Join([]string{
+		strconv.Quote(metric.Name()),
+		metric.DataType().String(),
+		strings.Join(attributes, ","),
+		strings.Join(labelPairs, ","),
+	}, " ")
+}
@@ -65,3 +65,61 @@ func TestCreateTraceExporterUsingBadConfig(t *testing.T) {
 	assert.Nil(t, exporter)
 	assert.NotNil(t, err)
 }
+
+func TestCreateMetricsExporterUsingSpecificTransportChannel(t *testing.T) {
+	// mock transport channel creation
+	f := factory{tChannel: &mockTransportChannel{}}
+	ctx := context.Background()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := f.createMetricsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, exporter)
+	assert.Nil(t, err)
+}
+
+func TestCreateMetricsExporterUsingBadConfig(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	exporter, err := f.createMetricsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, exporter)
+	assert.NotNil(t, err)
+}
+
+func TestCreateLogsExporterUsingSpecificTransportChannel(t *testing.T) {
+	// mock transport channel creation
+	f := factory{tChannel: &mockTransportChannel{}}
+	ctx := context.Background()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := f.createLogsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, exporter)
+	assert.Nil(t, err)
+}
+
+func TestCreateLogsExporterUsingBadConfig(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	exporter, err := f.createLogsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, exporter)
+	assert.NotNil(t, err)
+}
+
+func TestExportersShareTransportChannel(t *testing.T) {
+	// All the exporters created by the same factory send through the same transport channel
+	f := factory{}
+	ctx := context.Background()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	config := createDefaultConfig()
+
+	_, err := f.createTraceExporter(ctx, params, config)
+	assert.Nil(t, err)
+	tChannel := f.tChannel
+
+	_, err = f.createMetricsExporter(ctx, params, config)
+	assert.Nil(t, err)
+	_, err = f.createLogsExporter(ctx, params, config)
+	assert.Nil(t, err)
+	assert.Same(t, tChannel, f.tChannel)
+}
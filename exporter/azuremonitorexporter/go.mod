@@ -5,6 +5,7 @@ go 1.14
 require (
 	code.cloudfoundry.org/clock v1.0.0 // indirect
 	github.com/microsoft/ApplicationInsights-Go v0.4.3
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
 	github.com/satori/go.uuid v1.2.1-0.20181028125025-b2ce2384e17b // indirect
 	github.com/stretchr/testify v1.7.0
 	github.com/tedsuo/ifrit v0.0.0-20191009134036-9a97d0632f00 // indirect
@@ -13,3 +14,5 @@ require (
 	golang.org/x/net v0.0.0-20201021035429-f5854403a974
 	google.golang.org/grpc/examples v0.0.0-20200728194956-1c32b02682df // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../internal/common
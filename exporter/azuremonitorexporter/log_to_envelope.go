@@ -0,0 +1,87 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+// Contains the transformation of log records into AppInsights MessageData envelopes
+import (
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.LogRecord into an AppInsights contracts.Envelope
+func logRecordToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	logRecord pdata.LogRecord,
+	logger *zap.Logger) *contracts.Envelope {
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(logRecord.Timestamp()).Format(time.RFC3339Nano)
+
+	// Correlate the log record with the trace and span it was recorded in, if any
+	if traceID := logRecord.TraceID(); !traceID.IsEmpty() {
+		envelope.Tags[contracts.OperationId] = traceID.HexString()
+	}
+	if spanID := logRecord.SpanID(); !spanID.IsEmpty() {
+		envelope.Tags[contracts.OperationParentId] = spanID.HexString()
+	}
+
+	messageData := contracts.NewMessageData()
+	messageData.Message = tracetranslator.AttributeValueToString(logRecord.Body(), false)
+	messageData.SeverityLevel = severityNumberToLevel(logRecord.SeverityNumber())
+	messageData.Properties = make(map[string]string)
+	logRecord.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		messageData.Properties[k] = tracetranslator.AttributeValueToString(v, false)
+	})
+	envelope.Name = messageData.EnvelopeName("")
+
+	data := contracts.NewData()
+	data.BaseData = messageData
+	data.BaseType = messageData.BaseType()
+	envelope.Data = data
+
+	applyResourceAndInstrumentationLibrary(resource, instrumentationLibrary, envelope, messageData.Properties)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(func() []string { return messageData.Sanitize() }, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
+
+// Maps the log record severity number to an AppInsights severity level
+// https://github.com/open-telemetry/opentelemetry-specification/blob/master/specification/logs/data-model.md#field-severitynumber
+func severityNumberToLevel(severityNumber pdata.SeverityNumber) contracts.SeverityLevel {
+	switch {
+	case severityNumber == pdata.SeverityNumberUNDEFINED:
+		return contracts.Information
+	case severityNumber < pdata.SeverityNumberINFO:
+		return contracts.Verbose
+	case severityNumber < pdata.SeverityNumberWARN:
+		return contracts.Information
+	case severityNumber < pdata.SeverityNumberERROR:
+		return contracts.Warning
+	case severityNumber < pdata.SeverityNumberFATAL:
+		return contracts.Error
+	default:
+		return contracts.Critical
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMessageDataEnvelopeName = "Microsoft.ApplicationInsights.Message"
+	defaultLogMessage              = "log message"
+)
+
+var (
+	defaultLogTimestamp = pdata.TimestampUnixNano(60000000000)
+)
+
+// Tests that a log record is mapped to a message correlated with its trace and span
+func TestLogRecordToEnvelope(t *testing.T) {
+	logRecord := getLogRecord()
+	logRecord.SetSeverityNumber(pdata.SeverityNumberWARN2)
+
+	envelope := logRecordToEnvelope(defaultResource, defaultInstrumentationLibrary, logRecord, zap.NewNop())
+	require.NotNil(t, envelope)
+	assert.Equal(t, defaultMessageDataEnvelopeName, envelope.Name)
+	assert.Equal(t, toTime(defaultLogTimestamp).Format(time.RFC3339Nano), envelope.Time)
+	assert.Equal(t, defaultTraceIDAsHex, envelope.Tags[contracts.OperationId])
+	assert.Equal(t, defaultSpanIDAsHex, envelope.Tags[contracts.OperationParentId])
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, defaultServiceInstance, envelope.Tags[contracts.CloudRoleInstance])
+	require.NotNil(t, envelope.Data)
+
+	messageData := envelope.Data.(*contracts.Data).BaseData.(*contracts.MessageData)
+	assert.Equal(t, defaultLogMessage, messageData.Message)
+	assert.Equal(t, contracts.Warning, messageData.SeverityLevel)
+	assert.Equal(t, "value", messageData.Properties["string"])
+	assert.Equal(t, "42", messageData.Properties["int"])
+	assert.Equal(t, "true", messageData.Properties["bool"])
+	assert.Equal(t, defaultServiceName, messageData.Properties["service.name"])
+	assert.Equal(t, defaultInstrumentationLibraryName, messageData.Properties[instrumentationLibraryName])
+}
+
+// Tests that a log record without trace context is not correlated
+func TestLogRecordWithoutTraceContextToEnvelope(t *testing.T) {
+	logRecord := getLogRecord()
+	logRecord.SetTraceID(pdata.NewTraceID([16]byte{}))
+	logRecord.SetSpanID(pdata.NewSpanID([8]byte{}))
+
+	envelope := logRecordToEnvelope(defaultResource, defaultInstrumentationLibrary, logRecord, zap.NewNop())
+	require.NotNil(t, envelope)
+	assert.NotContains(t, envelope.Tags, contracts.OperationId)
+	assert.NotContains(t, envelope.Tags, contracts.OperationParentId)
+
+	messageData := envelope.Data.(*contracts.Data).BaseData.(*contracts.MessageData)
+	assert.Equal(t, contracts.Information, messageData.SeverityLevel)
+}
+
+func TestSeverityNumberToLevel(t *testing.T) {
+	tests := []struct {
+		severityNumber pdata.SeverityNumber
+		severityLevel  contracts.SeverityLevel
+	}{
+		{pdata.SeverityNumberUNDEFINED, contracts.Information},
+		{pdata.SeverityNumberTRACE, contracts.Verbose},
+		{pdata.SeverityNumberDEBUG4, contracts.Verbose},
+		{pdata.SeverityNumberINFO, contracts.Information},
+		{pdata.SeverityNumberINFO4, contracts.Information},
+		{pdata.SeverityNumberWARN, contracts.Warning},
+		{pdata.SeverityNumberWARN4, contracts.Warning},
+		{pdata.SeverityNumberERROR, contracts.Error},
+		{pdata.SeverityNumberERROR4, contracts.Error},
+		{pdata.SeverityNumberFATAL, contracts.Critical},
+		{pdata.SeverityNumberFATAL4, contracts.Critical},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.severityLevel, severityNumberToLevel(tt.severityNumber))
+	}
+}
+
+func getLogRecord() pdata.LogRecord {
+	logRecord := pdata.NewLogRecord()
+	logRecord.SetTimestamp(defaultLogTimestamp)
+	logRecord.SetTraceID(pdata.NewTraceID(defaultTraceID))
+	logRecord.SetSpanID(pdata.NewSpanID(defaultSpanID))
+	logRecord.Body().SetStringVal(defaultLogMessage)
+	logRecord.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"string": pdata.NewAttributeValueString("value"),
+		"int":    pdata.NewAttributeValueInt(42),
+		"bool":   pdata.NewAttributeValueBool(true),
+	})
+	return logRecord
+}
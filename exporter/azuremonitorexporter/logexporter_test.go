@@ -0,0 +1,71 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Tests the export onLogData callback with no log records
+func TestExporterLogDataCallbackNoLogs(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := getLogsExporter(defaultConfig, mockTransportChannel)
+
+	droppedLogs, err := exporter.onLogData(context.Background(), pdata.NewLogs())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, droppedLogs)
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+}
+
+// Tests the export onLogData callback sends an envelope per log record
+func TestExporterLogDataCallbackLogRecords(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	config := createDefaultConfig().(*Config)
+	config.InstrumentationKey = "ikey"
+	exporter := getLogsExporter(config, mockTransportChannel)
+
+	logs := pdata.NewLogs()
+	logs.ResourceLogs().Resize(1)
+	rl := logs.ResourceLogs().At(0)
+	defaultResource.CopyTo(rl.Resource())
+	rl.InstrumentationLibraryLogs().Resize(1)
+	ill := rl.InstrumentationLibraryLogs().At(0)
+	defaultInstrumentationLibrary.CopyTo(ill.InstrumentationLibrary())
+	ill.Logs().Append(getLogRecord())
+	ill.Logs().Append(getLogRecord())
+
+	droppedLogs, err := exporter.onLogData(context.Background(), logs)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, droppedLogs)
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 2)
+	envelope := mockTransportChannel.Calls[0].Arguments.Get(0).(*contracts.Envelope)
+	assert.Equal(t, "ikey", envelope.IKey)
+}
+
+func getLogsExporter(config *Config, transportChannel transportChannel) *logsExporter {
+	return &logsExporter{
+		config,
+		transportChannel,
+		zap.NewNop(),
+	}
+}
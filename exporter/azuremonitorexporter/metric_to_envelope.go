@@ -0,0 +1,230 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+// Contains the transformation of metrics into AppInsights MetricData envelopes
+import (
+	"math"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.Metric into AppInsights contracts.Envelopes,
+// one for each data point of the metric. The points of cumulative sums and histograms are sent as deltas, starting
+// from the second point of each time series.
+func metricToEnvelopes(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	metric pdata.Metric,
+	deltas *cumulativeToDelta,
+	logger *zap.Logger) []*contracts.Envelope {
+
+	var envelopes []*contracts.Envelope
+	appendEnvelope := func(timestamp pdata.TimestampUnixNano, labels pdata.StringMap, dataPoint *contracts.DataPoint) {
+		dataPoint.Name = metric.Name()
+		envelope := dataPointToEnvelope(resource, instrumentationLibrary, timestamp, labels, dataPoint, logger)
+		envelopes = append(envelopes, envelope)
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(float64(dp.Value())))
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(dp.Value()))
+		}
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		cumulative := isCumulative(sum.AggregationTemporality(), sum.IsMonotonic())
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			pt := cumulativePoint{startTime: dp.StartTime(), value: float64(dp.Value())}
+			if cumulative && !toDelta(deltas, resource, metric, dp.LabelsMap(), &pt) {
+				continue
+			}
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(pt.value))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		cumulative := isCumulative(sum.AggregationTemporality(), sum.IsMonotonic())
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			pt := cumulativePoint{startTime: dp.StartTime(), value: dp.Value()}
+			if cumulative && !toDelta(deltas, resource, metric, dp.LabelsMap(), &pt) {
+				continue
+			}
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurementDataPoint(pt.value))
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		cumulative := isCumulative(histogram.AggregationTemporality(), true)
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			pt := cumulativePoint{startTime: dp.StartTime(), value: float64(dp.Sum()), count: dp.Count(), bucketCounts: dp.BucketCounts()}
+			if cumulative && !toDelta(deltas, resource, metric, dp.LabelsMap(), &pt) {
+				continue
+			}
+			dataPoint := newAggregationDataPoint(pt.count, pt.value)
+			dataPoint.Min, dataPoint.Max = estimateHistogramMinMax(dataPoint, pt.bucketCounts, dp.ExplicitBounds())
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		cumulative := isCumulative(histogram.AggregationTemporality(), true)
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			pt := cumulativePoint{startTime: dp.StartTime(), value: dp.Sum(), count: dp.Count(), bucketCounts: dp.BucketCounts()}
+			if cumulative && !toDelta(deltas, resource, metric, dp.LabelsMap(), &pt) {
+				continue
+			}
+			dataPoint := newAggregationDataPoint(pt.count, pt.value)
+			dataPoint.Min, dataPoint.Max = estimateHistogramMinMax(dataPoint, pt.bucketCounts, dp.ExplicitBounds())
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dataPoint := newAggregationDataPoint(dp.Count(), dp.Sum())
+			dataPoint.Min, dataPoint.Max = summaryMinMax(dataPoint, dp.QuantileValues())
+			appendEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	}
+
+	return envelopes
+}
+
+// Wraps a single AppInsights DataPoint into a MetricData envelope, with the labels of the data point as properties
+func dataPointToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	timestamp pdata.TimestampUnixNano,
+	labels pdata.StringMap,
+	dataPoint *contracts.DataPoint,
+	logger *zap.Logger) *contracts.Envelope {
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(timestamp).Format(time.RFC3339Nano)
+
+	metricData := contracts.NewMetricData()
+	metricData.Metrics = []*contracts.DataPoint{dataPoint}
+	metricData.Properties = make(map[string]string)
+	labels.ForEach(func(k string, v string) { metricData.Properties[k] = v })
+	envelope.Name = metricData.EnvelopeName("")
+
+	data := contracts.NewData()
+	data.BaseData = metricData
+	data.BaseType = metricData.BaseType()
+	envelope.Data = data
+
+	applyResourceAndInstrumentationLibrary(resource, instrumentationLibrary, envelope, metricData.Properties)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(func() []string { return metricData.Sanitize() }, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
+
+// Returns a DataPoint for a single value, as reported by gauges and sums
+func newMeasurementDataPoint(value float64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Kind = contracts.Measurement
+	dataPoint.Value = value
+	dataPoint.Count = 1
+	return dataPoint
+}
+
+// Returns a DataPoint for pre-aggregated values, as reported by histograms and summaries.
+// Min and Max default to the mean, until something better is known.
+func newAggregationDataPoint(count uint64, sum float64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Kind = contracts.Aggregation
+	dataPoint.Value = sum
+	dataPoint.Count = int(count)
+	if count > 0 {
+		mean := sum / float64(count)
+		dataPoint.Min = mean
+		dataPoint.Max = mean
+	}
+	return dataPoint
+}
+
+// Histograms do not record their minimum and maximum, so they are estimated from the bounds of
+// the first and last non-empty buckets, and kept on either side of the mean
+func estimateHistogramMinMax(dataPoint *contracts.DataPoint, bucketCounts []uint64, explicitBounds []float64) (min float64, max float64) {
+	min, max = dataPoint.Min, dataPoint.Max
+	if dataPoint.Count == 0 || len(explicitBounds) == 0 || len(bucketCounts) != len(explicitBounds)+1 {
+		return min, max
+	}
+
+	first, last := -1, -1
+	for i, count := range bucketCounts {
+		if count == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first < 0 {
+		return min, max
+	}
+
+	// The first bucket has no lower bound, and the last bucket has no upper bound
+	if first > 0 {
+		min = math.Min(min, explicitBounds[first-1])
+	} else {
+		min = math.Min(min, explicitBounds[0])
+	}
+	if last < len(explicitBounds) {
+		max = math.Max(max, explicitBounds[last])
+	} else {
+		max = math.Max(max, explicitBounds[len(explicitBounds)-1])
+	}
+
+	return min, max
+}
+
+// Summaries record their minimum and maximum as the 0 and 1 quantiles, if at all
+func summaryMinMax(dataPoint *contracts.DataPoint, quantileValues pdata.ValueAtQuantileSlice) (min float64, max float64) {
+	min, max = dataPoint.Min, dataPoint.Max
+	for i := 0; i < quantileValues.Len(); i++ {
+		quantileValue := quantileValues.At(i)
+		switch quantileValue.Quantile() {
+		case 0:
+			min = quantileValue.Value()
+		case 1:
+			max = quantileValue.Value()
+		}
+	}
+	return min, max
+}
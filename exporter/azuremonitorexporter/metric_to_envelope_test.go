@@ -0,0 +1,293 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMetricDataEnvelopeName = "Microsoft.ApplicationInsights.Metric"
+	defaultMetricName             = "mymetric"
+)
+
+var (
+	defaultMetricTimestamp = pdata.TimestampUnixNano(60000000000)
+)
+
+// Tests that gauges and sums are mapped to a measurement for each data point
+func TestGaugesAndSumsToEnvelopes(t *testing.T) {
+	intGauge := getMetric(pdata.MetricDataTypeIntGauge)
+	fillIntDataPoints(intGauge.IntGauge().DataPoints(), 3, 5)
+
+	doubleGauge := getMetric(pdata.MetricDataTypeDoubleGauge)
+	fillDoubleDataPoints(doubleGauge.DoubleGauge().DataPoints(), 1.5, 2.5)
+
+	intSum := getMetric(pdata.MetricDataTypeIntSum)
+	fillIntDataPoints(intSum.IntSum().DataPoints(), 7)
+
+	doubleSum := getMetric(pdata.MetricDataTypeDoubleSum)
+	fillDoubleDataPoints(doubleSum.DoubleSum().DataPoints(), 0.5)
+
+	tests := []struct {
+		metric pdata.Metric
+		values []float64
+	}{
+		{intGauge, []float64{3, 5}},
+		{doubleGauge, []float64{1.5, 2.5}},
+		{intSum, []float64{7}},
+		{doubleSum, []float64{0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric.DataType().String(), func(t *testing.T) {
+			envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, tt.metric, newCumulativeToDelta(), zap.NewNop())
+			require.Len(t, envelopes, len(tt.values))
+
+			for i, envelope := range envelopes {
+				metricData := commonMetricDataValidations(t, envelope)
+				dataPoint := metricData.Metrics[0]
+				assert.Equal(t, contracts.Measurement, dataPoint.Kind)
+				assert.Equal(t, tt.values[i], dataPoint.Value)
+				assert.Equal(t, 1, dataPoint.Count)
+			}
+		})
+	}
+}
+
+// Tests that histograms are mapped to an aggregation with their count, sum, and estimated min and max
+func TestHistogramsToEnvelopes(t *testing.T) {
+	doubleHistogram := getMetric(pdata.MetricDataTypeDoubleHistogram)
+	doubleHistogram.DoubleHistogram().DataPoints().Resize(1)
+	doubleDataPoint := doubleHistogram.DoubleHistogram().DataPoints().At(0)
+	doubleDataPoint.SetTimestamp(defaultMetricTimestamp)
+	doubleDataPoint.LabelsMap().Insert("label", "value")
+	doubleDataPoint.SetCount(4)
+	doubleDataPoint.SetSum(30)
+	doubleDataPoint.SetExplicitBounds([]float64{1, 5, 10, 20})
+	doubleDataPoint.SetBucketCounts([]uint64{0, 1, 2, 1, 0})
+
+	envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, doubleHistogram, newCumulativeToDelta(), zap.NewNop())
+	require.Len(t, envelopes, 1)
+	dataPoint := commonMetricDataValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, 4, dataPoint.Count)
+	assert.Equal(t, 30.0, dataPoint.Value)
+	assert.Equal(t, 1.0, dataPoint.Min)
+	assert.Equal(t, 20.0, dataPoint.Max)
+
+	intHistogram := getMetric(pdata.MetricDataTypeIntHistogram)
+	intHistogram.IntHistogram().DataPoints().Resize(1)
+	intDataPoint := intHistogram.IntHistogram().DataPoints().At(0)
+	intDataPoint.SetTimestamp(defaultMetricTimestamp)
+	intDataPoint.LabelsMap().Insert("label", "value")
+	intDataPoint.SetCount(2)
+	intDataPoint.SetSum(100)
+	intDataPoint.SetExplicitBounds([]float64{10, 20})
+	intDataPoint.SetBucketCounts([]uint64{1, 0, 1})
+
+	envelopes = metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, intHistogram, newCumulativeToDelta(), zap.NewNop())
+	require.Len(t, envelopes, 1)
+	dataPoint = commonMetricDataValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, 2, dataPoint.Count)
+	assert.Equal(t, 100.0, dataPoint.Value)
+	// The unbounded buckets are estimated with the mean
+	assert.Equal(t, 10.0, dataPoint.Min)
+	assert.Equal(t, 50.0, dataPoint.Max)
+}
+
+// Tests that summaries are mapped to an aggregation, with the 0 and 1 quantiles as min and max
+func TestSummaryToEnvelopes(t *testing.T) {
+	summary := getMetric(pdata.MetricDataTypeDoubleSummary)
+	summary.DoubleSummary().DataPoints().Resize(1)
+	dp := summary.DoubleSummary().DataPoints().At(0)
+	dp.SetTimestamp(defaultMetricTimestamp)
+	dp.LabelsMap().Insert("label", "value")
+	dp.SetCount(10)
+	dp.SetSum(50)
+	dp.QuantileValues().Resize(3)
+	for i, quantile := range []float64{0, 0.5, 1} {
+		dp.QuantileValues().At(i).SetQuantile(quantile)
+		dp.QuantileValues().At(i).SetValue(quantile * 20)
+	}
+
+	envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, summary, newCumulativeToDelta(), zap.NewNop())
+	require.Len(t, envelopes, 1)
+	dataPoint := commonMetricDataValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, 10, dataPoint.Count)
+	assert.Equal(t, 50.0, dataPoint.Value)
+	assert.Equal(t, 0.0, dataPoint.Min)
+	assert.Equal(t, 20.0, dataPoint.Max)
+}
+
+// Tests that the points of cumulative sums are sent as deltas, from the second point of a time series
+func TestCumulativeSumsToEnvelopes(t *testing.T) {
+	deltas := newCumulativeToDelta()
+	intSum := getMetric(pdata.MetricDataTypeIntSum)
+	intSum.IntSum().SetIsMonotonic(true)
+	intSum.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	// The first point has nothing to be compared with
+	fillIntDataPoints(intSum.IntSum().DataPoints(), 10)
+	assert.Empty(t, metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, intSum, deltas, zap.NewNop()))
+
+	// A decreasing value is a restart of the time series
+	for _, tt := range []struct {
+		value int64
+		delta float64
+	}{{15, 5}, {15, 0}, {4, 4}, {6, 2}} {
+		fillIntDataPoints(intSum.IntSum().DataPoints(), tt.value)
+		envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, intSum, deltas, zap.NewNop())
+		require.Len(t, envelopes, 1)
+		dataPoint := commonMetricDataValidations(t, envelopes[0]).Metrics[0]
+		assert.Equal(t, contracts.Measurement, dataPoint.Kind)
+		assert.Equal(t, tt.delta, dataPoint.Value)
+	}
+
+	// A non monotonic sum is sent as is
+	doubleSum := getMetric(pdata.MetricDataTypeDoubleSum)
+	doubleSum.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	fillDoubleDataPoints(doubleSum.DoubleSum().DataPoints(), 2.5)
+	envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, doubleSum, deltas, zap.NewNop())
+	require.Len(t, envelopes, 1)
+	assert.Equal(t, 2.5, commonMetricDataValidations(t, envelopes[0]).Metrics[0].Value)
+}
+
+// Tests that the points of cumulative histograms are sent as deltas of their count, sum and buckets
+func TestCumulativeHistogramsToEnvelopes(t *testing.T) {
+	deltas := newCumulativeToDelta()
+	histogram := getMetric(pdata.MetricDataTypeDoubleHistogram)
+	histogram.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	histogram.DoubleHistogram().DataPoints().Resize(1)
+	dp := histogram.DoubleHistogram().DataPoints().At(0)
+	dp.SetTimestamp(defaultMetricTimestamp)
+	dp.LabelsMap().Insert("label", "value")
+	dp.SetExplicitBounds([]float64{1, 5, 10, 20})
+
+	dp.SetCount(4)
+	dp.SetSum(30)
+	dp.SetBucketCounts([]uint64{0, 1, 2, 1, 0})
+	assert.Empty(t, metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, histogram, deltas, zap.NewNop()))
+
+	dp.SetCount(6)
+	dp.SetSum(44)
+	dp.SetBucketCounts([]uint64{0, 1, 4, 1, 0})
+	envelopes := metricToEnvelopes(defaultResource, defaultInstrumentationLibrary, histogram, deltas, zap.NewNop())
+	require.Len(t, envelopes, 1)
+	dataPoint := commonMetricDataValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, 2, dataPoint.Count)
+	assert.Equal(t, 14.0, dataPoint.Value)
+	assert.Equal(t, 5.0, dataPoint.Min)
+	assert.Equal(t, 10.0, dataPoint.Max)
+}
+
+// Tests that the keys of distinct time series do not collide
+func TestTimeSeriesKey(t *testing.T) {
+	labels := func(m map[string]string) pdata.StringMap {
+		sm := pdata.NewStringMap()
+		sm.InitFromMap(m)
+		return sm
+	}
+	metric := getMetric(pdata.MetricDataTypeIntSum)
+
+	assert.Equal(t,
+		timeSeriesKey(defaultResource, metric, labels(map[string]string{"a": "1", "b": "2"})),
+		timeSeriesKey(defaultResource, metric, labels(map[string]string{"b": "2", "a": "1"})))
+	assert.NotEqual(t,
+		timeSeriesKey(defaultResource, metric, labels(map[string]string{"a": "1,\"b\"=\"2"})),
+		timeSeriesKey(defaultResource, metric, labels(map[string]string{"a": "1", "b": "2"})))
+	assert.NotEqual(t,
+		timeSeriesKey(defaultResource, metric, labels(map[string]string{"a": "1"})),
+		timeSeriesKey(pdata.NewResource(), metric, labels(map[string]string{"a": "1"})))
+}
+
+func TestEstimateHistogramMinMax(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        uint64
+		sum          float64
+		bucketCounts []uint64
+		bounds       []float64
+		min          float64
+		max          float64
+	}{
+		{"no data", 0, 0, []uint64{0, 0}, []float64{1}, 0, 0},
+		{"no buckets", 2, 10, nil, nil, 5, 5},
+		{"mismatched buckets", 2, 10, []uint64{2}, []float64{1}, 5, 5},
+		{"bounded buckets", 3, 9, []uint64{0, 2, 1, 0}, []float64{1, 2, 4}, 1, 4},
+		{"unbounded buckets", 2, 2, []uint64{1, 1}, []float64{3}, 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataPoint := newAggregationDataPoint(tt.count, tt.sum)
+			min, max := estimateHistogramMinMax(dataPoint, tt.bucketCounts, tt.bounds)
+			assert.Equal(t, tt.min, min)
+			assert.Equal(t, tt.max, max)
+		})
+	}
+}
+
+func commonMetricDataValidations(t *testing.T, envelope *contracts.Envelope) *contracts.MetricData {
+	require.NotNil(t, envelope)
+	assert.Equal(t, defaultMetricDataEnvelopeName, envelope.Name)
+	assert.Equal(t, toTime(defaultMetricTimestamp).Format(time.RFC3339Nano), envelope.Time)
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, defaultServiceInstance, envelope.Tags[contracts.CloudRoleInstance])
+	require.NotNil(t, envelope.Data)
+
+	metricData := envelope.Data.(*contracts.Data).BaseData.(*contracts.MetricData)
+	require.Len(t, metricData.Metrics, 1)
+	assert.Equal(t, defaultMetricName, metricData.Metrics[0].Name)
+	assert.Equal(t, "value", metricData.Properties["label"])
+	assert.Equal(t, defaultServiceName, metricData.Properties["service.name"])
+	assert.Equal(t, defaultInstrumentationLibraryName, metricData.Properties[instrumentationLibraryName])
+	return metricData
+}
+
+func getMetric(dataType pdata.MetricDataType) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.SetName(defaultMetricName)
+	metric.SetDataType(dataType)
+	return metric
+}
+
+func fillIntDataPoints(dps pdata.IntDataPointSlice, values ...int64) {
+	dps.Resize(len(values))
+	for i, value := range values {
+		dps.At(i).SetTimestamp(defaultMetricTimestamp)
+		dps.At(i).LabelsMap().Insert("label", "value")
+		dps.At(i).SetValue(value)
+	}
+}
+
+func fillDoubleDataPoints(dps pdata.DoubleDataPointSlice, values ...float64) {
+	dps.Resize(len(values))
+	for i, value := range values {
+		dps.At(i).SetTimestamp(defaultMetricTimestamp)
+		dps.At(i).LabelsMap().Insert("label", "value")
+		dps.At(i).SetValue(value)
+	}
+}
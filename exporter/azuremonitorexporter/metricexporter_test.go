@@ -0,0 +1,73 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Tests the export onMetricData callback with no metrics
+func TestExporterMetricDataCallbackNoMetrics(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := getMetricsExporter(defaultConfig, mockTransportChannel)
+
+	droppedTimeSeries, err := exporter.onMetricData(context.Background(), pdata.NewMetrics())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, droppedTimeSeries)
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+}
+
+// Tests the export onMetricData callback sends an envelope per data point
+func TestExporterMetricDataCallbackDataPoints(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	config := createDefaultConfig().(*Config)
+	config.InstrumentationKey = "ikey"
+	exporter := getMetricsExporter(config, mockTransportChannel)
+
+	metrics := pdata.NewMetrics()
+	metrics.ResourceMetrics().Resize(1)
+	rm := metrics.ResourceMetrics().At(0)
+	defaultResource.CopyTo(rm.Resource())
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	defaultInstrumentationLibrary.CopyTo(ilm.InstrumentationLibrary())
+	gauge := getMetric(pdata.MetricDataTypeDoubleGauge)
+	fillDoubleDataPoints(gauge.DoubleGauge().DataPoints(), 1, 2)
+	ilm.Metrics().Append(gauge)
+
+	droppedTimeSeries, err := exporter.onMetricData(context.Background(), metrics)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, droppedTimeSeries)
+
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 2)
+	envelope := mockTransportChannel.Calls[0].Arguments.Get(0).(*contracts.Envelope)
+	assert.Equal(t, "ikey", envelope.IKey)
+}
+
+func getMetricsExporter(config *Config, transportChannel transportChannel) *metricsExporter {
+	return &metricsExporter{
+		config,
+		transportChannel,
+		newCumulativeToDelta(),
+		zap.NewNop(),
+	}
+}
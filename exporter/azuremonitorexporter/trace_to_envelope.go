@@ -114,6 +114,24 @@ func spanToEnvelope(
 	}
 
 	envelope.Data = data
+	applyResourceAndInstrumentationLibrary(resource, instrumentationLibrary, envelope, dataProperties)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(dataSanitizeFunc, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope, nil
+}
+
+// Copies the resource attributes and the instrumentation library into the base data properties,
+// and sets the CloudRole and CloudRoleInstance envelope tags from the service.* resource attributes
+func applyResourceAndInstrumentationLibrary(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	envelope *contracts.Envelope,
+	dataProperties map[string]string) {
+
 	resourceAttributes := resource.Attributes()
 
 	// Copy all the resource labels into the base data properties. Resource values are always strings
@@ -143,13 +161,6 @@ func spanToEnvelope(
 	if serviceInstance, exists := resourceAttributes.Get(conventions.AttributeServiceInstance); exists {
 		envelope.Tags[contracts.CloudRoleInstance] = serviceInstance.StringVal()
 	}
-
-	// Sanitize the base data, the envelope and envelope tags
-	sanitize(dataSanitizeFunc, logger)
-	sanitize(func() []string { return envelope.Sanitize() }, logger)
-	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
-
-	return envelope, nil
 }
 
 // Maps Server/Consumer Span to AppInsights RequestData
@@ -60,6 +60,10 @@ type MetricsConfig struct {
 	// Buckets states whether to report buckets from distribution metrics
 	Buckets bool `mapstructure:"report_buckets"`
 
+	// SendDistributions states whether to report histograms as Datadog distributions
+	// instead of count and sum metrics
+	SendDistributions bool `mapstructure:"send_distributions"`
+
 	// SendMonotonic states whether to report cumulative monotonic metrics as counters
 	// or gauges
 	SendMonotonic bool `mapstructure:"send_monotonic_counter"`
@@ -97,6 +101,18 @@ type TracesConfig struct {
 	SampleRate uint `mapstructure:"sample_rate"`
 }
 
+// LogsConfig defines the logs exporter specific configuration options
+type LogsConfig struct {
+	// TCPAddr.Endpoint is the host of the Datadog log intake server to send logs to.
+	// It can also be set through the `DD_LOGS_URL` environment variable.
+	// If unset, the value is obtained from the Site.
+	confignet.TCPAddr `mapstructure:",squash"`
+
+	// Source is the default source of the logs, used when the resource of the logs
+	// has no `datadog.log.source` attribute.
+	Source string `mapstructure:"source"`
+}
+
 // TagsConfig defines the tag-related configuration
 // It is embedded in the configuration
 type TagsConfig struct {
@@ -157,6 +173,9 @@ type Config struct {
 	// Traces defines the Traces exporter specific configuration
 	Traces TracesConfig `mapstructure:"traces"`
 
+	// Logs defines the Logs exporter specific configuration
+	Logs LogsConfig `mapstructure:"logs"`
+
 	// SendMetadata defines whether to send host metadata
 	// This is undocumented and only used for unit testing.
 	//
@@ -171,7 +190,7 @@ type Config struct {
 	// This flag is incompatible with disabling `send_metadata`
 	OnlyMetadata bool `mapstructure:"only_metadata"`
 
-	// onceMetadata ensures only one exporter (metrics/traces) sends host metadata
+	// onceMetadata ensures only one exporter (metrics/traces/logs) sends host metadata
 	onceMetadata sync.Once
 }
 
@@ -209,5 +228,9 @@ func (c *Config) Sanitize() error {
 		c.Traces.TCPAddr.Endpoint = fmt.Sprintf("https://trace.agent.%s", c.API.Site)
 	}
 
+	if c.Logs.TCPAddr.Endpoint == "" {
+		c.Logs.TCPAddr.Endpoint = fmt.Sprintf("https://http-intake.logs.%s", c.API.Site)
+	}
+
 	return nil
 }
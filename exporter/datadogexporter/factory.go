@@ -39,6 +39,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithMetrics(createMetricsExporter),
 		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithLogs(createLogsExporter),
 	)
 }
 
@@ -81,6 +82,13 @@ func createDefaultConfig() configmodels.Exporter {
 			},
 		},
 
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL", // If not provided, set during config sanitization
+			},
+			Source: "otel",
+		},
+
 		SendMetadata: true,
 	}
 }
@@ -148,7 +156,10 @@ func createTraceExporter(
 		return nil, err
 	}
 
-	var pushTracesFn exporterhelper.PushTraces
+	var (
+		pushTracesFn exporterhelper.PushTraces
+		exp          *traceExporter
+	)
 
 	if cfg.OnlyMetadata {
 		pushTracesFn = func(context.Context, pdata.Traces) (int, error) {
@@ -156,7 +167,8 @@ func createTraceExporter(
 			return 0, nil
 		}
 	} else {
-		pushTracesFn = newTraceExporter(params, cfg).pushTraceData
+		exp = newTraceExporter(params, cfg)
+		pushTracesFn = exp.pushTraceData
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -171,6 +183,61 @@ func createTraceExporter(
 		cfg,
 		params.Logger,
 		pushTracesFn,
+		exporterhelper.WithStart(func(ctx context.Context, host component.Host) error {
+			if exp != nil {
+				return exp.Start(ctx, host)
+			}
+			return nil
+		}),
+		exporterhelper.WithShutdown(func(ctx context.Context) error {
+			cancel()
+			if exp != nil {
+				return exp.Shutdown(ctx)
+			}
+			return nil
+		}),
+	)
+}
+
+// createLogsExporter creates a logs exporter based on this config.
+func createLogsExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	c configmodels.Exporter,
+) (component.LogsExporter, error) {
+
+	cfg := c.(*config.Config)
+
+	params.Logger.Info("sanitizing Datadog logs exporter configuration")
+	if err := cfg.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	var pushLogsFn exporterhelper.PushLogs
+
+	if cfg.OnlyMetadata {
+		pushLogsFn = func(context.Context, pdata.Logs) (int, error) {
+			// if only sending metadata, ignore all logs
+			return 0, nil
+		}
+	} else {
+		pushLogsFn = newLogsExporter(params, cfg).pushLogsData
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	if cfg.SendMetadata {
+		once := cfg.OnceMetadata()
+		once.Do(func() {
+			go metadata.Pusher(ctx, params, cfg)
+		})
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		pushLogsFn,
+		exporterhelper.WithQueue(exporterhelper.DefaultQueueSettings()),
+		exporterhelper.WithRetry(exporterhelper.DefaultRetrySettings()),
 		exporterhelper.WithShutdown(func(context.Context) error {
 			cancel()
 			return nil
@@ -67,6 +67,13 @@ func TestCreateDefaultConfig(t *testing.T) {
 			},
 		},
 
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL",
+			},
+			Source: "otel",
+		},
+
 		TagsConfig: config.TagsConfig{
 			Hostname:   "$DD_HOST",
 			Env:        "$DD_ENV",
@@ -132,6 +139,13 @@ func TestLoadConfig(t *testing.T) {
 				Endpoint: "https://trace.agent.datadoghq.eu",
 			},
 		},
+
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.eu",
+			},
+			Source: "otel",
+		},
 		SendMetadata: true,
 		OnlyMetadata: false,
 	}, apiConfig)
@@ -173,6 +187,13 @@ func TestLoadConfig(t *testing.T) {
 				Endpoint: "https://trace.agent.datadoghq.com",
 			},
 		},
+
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+			Source: "otel",
+		},
 		SendMetadata: true,
 		OnlyMetadata: false,
 	}, defaultConfig)
@@ -194,6 +215,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 	assert.NoError(t, os.Setenv("DD_TAGS", "envexample:tag envexample2:tag"))
 	assert.NoError(t, os.Setenv("DD_URL", "https://api.datadoghq.com"))
 	assert.NoError(t, os.Setenv("DD_APM_URL", "https://trace.agent.datadoghq.com"))
+	assert.NoError(t, os.Setenv("DD_LOGS_URL", "https://http-intake.logs.datadoghq.com"))
 
 	defer func() {
 		assert.NoError(t, os.Unsetenv("DD_API_KEY"))
@@ -205,6 +227,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 		assert.NoError(t, os.Unsetenv("DD_TAGS"))
 		assert.NoError(t, os.Unsetenv("DD_URL"))
 		assert.NoError(t, os.Unsetenv("DD_APM_URL"))
+		assert.NoError(t, os.Unsetenv("DD_LOGS_URL"))
 	}()
 
 	factories, err := componenttest.ExampleComponents()
@@ -256,6 +279,13 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 				Endpoint: "https://trace.agent.datadoghq.test",
 			},
 		},
+
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.test",
+			},
+			Source: "otel",
+		},
 		SendMetadata: true,
 		OnlyMetadata: false,
 	}, apiConfig)
@@ -300,6 +330,13 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 				Endpoint: "https://trace.agent.datadoghq.com",
 			},
 		},
+
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+			Source: "otel",
+		},
 		SendMetadata: true,
 		OnlyMetadata: false,
 	}, defaultConfig)
@@ -370,6 +407,33 @@ func TestCreateAPITracesExporter(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestCreateAPILogsExporter(t *testing.T) {
+	logger := zap.NewNop()
+
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	c := (cfg.Exporters["datadog/api"]).(*config.Config)
+	c.SendMetadata = false
+
+	ctx := context.Background()
+	exp, err := factory.CreateLogsExporter(
+		ctx,
+		component.ExporterCreateParams{Logger: logger},
+		cfg.Exporters["datadog/api"],
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestOnlyMetadata(t *testing.T) {
 	logger := zap.NewNop()
 
@@ -406,4 +470,13 @@ func TestOnlyMetadata(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, expMetrics)
+
+	expLogs, err := factory.CreateLogsExporter(
+		ctx,
+		component.ExporterCreateParams{Logger: logger},
+		cfg,
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, expLogs)
 }
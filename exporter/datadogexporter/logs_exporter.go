@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/utils"
+)
+
+// Limits of the Datadog log intake
+// See https://docs.datadoghq.com/api/latest/logs/#send-logs
+const (
+	// maxLogsPerPayload is the maximum number of logs in a payload
+	maxLogsPerPayload = 1000
+	// maxLogsPayloadSize is the maximum size of a payload before compression
+	maxLogsPayloadSize = 5 * 1024 * 1024
+	// maxLogSize is the maximum size of a log, larger ones are dropped
+	maxLogSize = 1024 * 1024
+)
+
+type logsExporter struct {
+	logger    *zap.Logger
+	cfg       *config.Config
+	client    *http.Client
+	startInfo component.ApplicationStartInfo
+	url       string
+}
+
+func newLogsExporter(params component.ExporterCreateParams, cfg *config.Config) *logsExporter {
+	return &logsExporter{
+		logger:    params.Logger,
+		cfg:       cfg,
+		client:    utils.NewHTTPClient(10 * time.Second),
+		startInfo: params.ApplicationStartInfo,
+		url:       cfg.Logs.TCPAddr.Endpoint + "/v1/input",
+	}
+}
+
+// logsPayload is a batch of encoded logs sent in a single request
+type logsPayload struct {
+	logs [][]byte
+	refs []logRef
+	// size is the size of the JSON array of the logs
+	size int
+}
+
+func newLogsPayload() *logsPayload {
+	// The size of an empty payload is the one of the opening bracket, the
+	// closing one is counted as the separator of the first log.
+	return &logsPayload{size: 1}
+}
+
+// pushLogsData sends the logs to the intake in batches within its limits. The logs of
+// the batches that could not be sent are retried, unless the error is permanent.
+func (exp *logsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	logs, refs := convertToDatadogLogs(ld, exp.logger, exp.cfg)
+
+	var droppedLogs int
+	var payloads []*logsPayload
+	payload := newLogsPayload()
+	for i, log := range logs {
+		encoded, err := json.Marshal(log)
+		if err != nil {
+			exp.logger.Warn("failed to serialize log", zap.Error(err))
+			droppedLogs++
+			continue
+		}
+		if len(encoded) > maxLogSize {
+			exp.logger.Warn("dropping log larger than the maximum size of the intake", zap.Int("size", len(encoded)))
+			droppedLogs++
+			continue
+		}
+
+		// The logs are sent in a JSON array, so each one takes a separator
+		if len(payload.logs) == maxLogsPerPayload || payload.size+len(encoded)+1 > maxLogsPayloadSize {
+			payloads = append(payloads, payload)
+			payload = newLogsPayload()
+		}
+		payload.logs = append(payload.logs, encoded)
+		payload.refs = append(payload.refs, refs[i])
+		payload.size += len(encoded) + 1
+	}
+	if len(payload.logs) > 0 {
+		payloads = append(payloads, payload)
+	}
+
+	for i, payload := range payloads {
+		if err := exp.sendPayload(ctx, payload); err != nil {
+			if consumererror.IsPermanent(err) {
+				for _, p := range payloads[i:] {
+					droppedLogs += len(p.logs)
+				}
+				return droppedLogs, err
+			}
+
+			// Only retry the logs that were not sent yet
+			var failed []logRef
+			for _, p := range payloads[i:] {
+				failed = append(failed, p.refs...)
+			}
+			return droppedLogs, consumererror.PartialLogsError(err, logsSubset(ld, failed))
+		}
+	}
+	return droppedLogs, nil
+}
+
+// sendPayload sends a batch of logs to the intake, compressed with gzip
+func (exp *logsExporter) sendPayload(ctx context.Context, payload *logsPayload) error {
+	body := make([]byte, 0, payload.size)
+	body = append(body, '[')
+	body = append(body, bytes.Join(payload.logs, []byte(","))...)
+	body = append(body, ']')
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to compress logs payload: %w", err))
+	}
+	if err := gz.Close(); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to compress logs payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", exp.url, &buf)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	utils.SetDDHeaders(req.Header, exp.startInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.JSONHeaders)
+
+	resp, err := exp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err := fmt.Errorf("request to %s responded with %s", exp.url, resp.Status)
+		if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+			// 5xx and throttling errors are retriable
+			return err
+		}
+
+		// All others aren't
+		return consumererror.Permanent(err)
+	}
+	return nil
+}
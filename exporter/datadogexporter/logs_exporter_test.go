@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+)
+
+// logsIntakeMock mocks the Datadog log intake, it responds with the
+// given status codes in turn, and with 200 once they are exhausted.
+type logsIntakeMock struct {
+	t        *testing.T
+	mutex    sync.Mutex
+	statuses []int
+	payloads [][]datadogLog
+}
+
+func (m *logsIntakeMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(m.t, "/v1/input", r.URL.Path)
+	assert.Equal(m.t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(m.t, "gzip", r.Header.Get("Content-Encoding"))
+	assert.Equal(m.t, "ddog_32_characters_long_api_key1", r.Header.Get("DD-Api-Key"))
+
+	gz, err := gzip.NewReader(r.Body)
+	require.NoError(m.t, err)
+	var payload []datadogLog
+	require.NoError(m.t, json.NewDecoder(gz).Decode(&payload))
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.payloads = append(m.payloads, payload)
+	if len(m.statuses) > 0 {
+		w.WriteHeader(m.statuses[0])
+		m.statuses = m.statuses[1:]
+	}
+}
+
+func newTestLogsExporter(t *testing.T, statuses ...int) (*logsExporter, *logsIntakeMock, func()) {
+	intake := &logsIntakeMock{t: t, statuses: statuses}
+	server := httptest.NewServer(intake)
+
+	cfg := testLogsConfig()
+	cfg.API.Key = "ddog_32_characters_long_api_key1"
+	cfg.Logs.TCPAddr = confignet.TCPAddr{Endpoint: server.URL}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	return newLogsExporter(params, cfg), intake, server.Close
+}
+
+func newTestHostLogs(count int) pdata.Logs {
+	return newTestLogs(count, map[string]pdata.AttributeValue{
+		metadata.AttributeDatadogHostname: pdata.NewAttributeValueString("test-host"),
+	})
+}
+
+func TestPushLogsData(t *testing.T) {
+	exp, intake, closeServer := newTestLogsExporter(t)
+	defer closeServer()
+
+	dropped, err := exp.pushLogsData(context.Background(), newTestHostLogs(2))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	require.Len(t, intake.payloads, 1)
+	require.Len(t, intake.payloads[0], 2)
+	assert.Equal(t, datadogLog{
+		"message":  "log message",
+		"status":   "info",
+		"hostname": "test-host",
+		"service":  "test-service",
+		"ddsource": "otel",
+		"ddtags":   "key:value,env:test-env,version:1.0",
+	}, intake.payloads[0][0])
+}
+
+func TestPushLogsDataBatches(t *testing.T) {
+	exp, intake, closeServer := newTestLogsExporter(t)
+	defer closeServer()
+
+	// The number of logs of a payload is limited
+	_, err := exp.pushLogsData(context.Background(), newTestHostLogs(maxLogsPerPayload+1))
+	require.NoError(t, err)
+	require.Len(t, intake.payloads, 2)
+	assert.Len(t, intake.payloads[0], maxLogsPerPayload)
+	assert.Len(t, intake.payloads[1], 1)
+
+	// So is the size of a payload, and the size of each log
+	ld := newTestHostLogs(12)
+	records := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	for i := 0; i < records.Len(); i++ {
+		records.At(i).Body().SetStringVal(strings.Repeat("a", maxLogSize/2))
+	}
+	records.At(3).Body().SetStringVal(strings.Repeat("a", maxLogSize))
+
+	intake.payloads = nil
+	dropped, err := exp.pushLogsData(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	require.Len(t, intake.payloads, 2)
+	assert.Len(t, intake.payloads[0], 9)
+	assert.Len(t, intake.payloads[1], 2)
+}
+
+func TestPushLogsDataErrors(t *testing.T) {
+	// The logs of the payloads that failed to be sent are retried
+	exp, intake, closeServer := newTestLogsExporter(t, http.StatusOK, http.StatusServiceUnavailable)
+	defer closeServer()
+
+	ld := newTestHostLogs(2*maxLogsPerPayload + 1)
+	dropped, err := exp.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.Len(t, intake.payloads, 2)
+
+	partialErr, ok := err.(consumererror.PartialError)
+	require.True(t, ok)
+	assert.Equal(t, maxLogsPerPayload+1, partialErr.GetLogs().LogRecordCount())
+
+	// Other client errors are permanent, and the logs dropped
+	exp, intake, closeServer = newTestLogsExporter(t, http.StatusOK, http.StatusBadRequest)
+	defer closeServer()
+
+	dropped, err = exp.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Equal(t, maxLogsPerPayload+1, dropped)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Len(t, intake.payloads, 2)
+}
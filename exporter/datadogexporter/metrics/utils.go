@@ -22,6 +22,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/sketches"
 )
 
 const (
@@ -76,6 +77,18 @@ func ProcessMetrics(ms []datadog.Metric, logger *zap.Logger, cfg *config.Config)
 	addHostname(ms, logger, cfg)
 }
 
+// ProcessSketches does the same processing as ProcessMetrics for sketch series
+func ProcessSketches(sl []sketches.SketchSeries, logger *zap.Logger, cfg *config.Config) {
+	overrideHostname := cfg.Hostname != ""
+
+	for i := range sl {
+		sl[i].Name = otelNamespacePrefix + "." + sl[i].Name
+		if overrideHostname || sl[i].Host == "" {
+			sl[i].Host = *metadata.GetHost(logger, cfg)
+		}
+	}
+}
+
 // addHostname adds an hostname to metrics, either using the hostname given
 // in the config, or retrieved from the host metadata
 func addHostname(metrics []datadog.Metric, logger *zap.Logger, cfg *config.Config) {
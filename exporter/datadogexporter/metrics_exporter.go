@@ -15,25 +15,34 @@
 package datadogexporter
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 	"gopkg.in/zorkian/go-datadog-api.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/sketches"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/utils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
 )
 
 type metricsExporter struct {
-	logger  *zap.Logger
-	cfg     *config.Config
-	client  *datadog.Client
-	prevPts *ttlmap.TTLMap
+	logger    *zap.Logger
+	cfg       *config.Config
+	client    *datadog.Client
+	prevPts   *ttlmap.TTLMap
+	startInfo component.ApplicationStartInfo
+	// sketchesClient sends the distributions, which are not supported by the Datadog API client
+	sketchesClient *http.Client
 }
 
 func newMetricsExporter(params component.ExporterCreateParams, cfg *config.Config) *metricsExporter {
@@ -50,11 +59,46 @@ func newMetricsExporter(params component.ExporterCreateParams, cfg *config.Confi
 	prevPts := ttlmap.New(sweepInterval, cfg.Metrics.DeltaTTL)
 	prevPts.Start()
 
-	return &metricsExporter{params.Logger, cfg, client, prevPts}
+	return &metricsExporter{
+		logger:         params.Logger,
+		cfg:            cfg,
+		client:         client,
+		prevPts:        prevPts,
+		startInfo:      params.ApplicationStartInfo,
+		sketchesClient: utils.NewHTTPClient(10 * time.Second),
+	}
+}
+
+// pushSketches sends sketch series to the sketches endpoint
+func (exp *metricsExporter) pushSketches(ctx context.Context, sl []sketches.SketchSeries) error {
+	payload, err := sketches.Marshal(sl)
+	if err != nil {
+		return fmt.Errorf("failed to serialize sketches payload to protobuf: %w", err)
+	}
+
+	url := exp.cfg.Metrics.TCPAddr.Endpoint + "/api/beta/sketches"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	utils.SetDDHeaders(req.Header, exp.startInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.ProtobufHeaders)
+
+	resp, err := exp.sketchesClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s responded with %s", url, resp.Status)
+	}
+	return nil
 }
 
 func (exp *metricsExporter) PushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
-	ms, droppedTimeSeries := mapMetrics(exp.cfg.Metrics, exp.prevPts, md)
+	ms, sl, droppedTimeSeries := mapMetrics(exp.cfg.Metrics, exp.prevPts, md)
 
 	// Append the default 'running' metric
 	pushTime := uint64(time.Now().UTC().UnixNano())
@@ -62,6 +106,26 @@ func (exp *metricsExporter) PushMetricsData(ctx context.Context, md pdata.Metric
 
 	metrics.ProcessMetrics(ms, exp.logger, exp.cfg)
 
-	err := exp.client.PostMetrics(ms)
-	return droppedTimeSeries, err
+	var sketchesErr error
+	if len(sl) > 0 {
+		metrics.ProcessSketches(sl, exp.logger, exp.cfg)
+		sketchesErr = exp.pushSketches(ctx, sl)
+	}
+
+	// The series are posted even if the sketches failed: mapMetrics already
+	// advanced the cumulative points in prevPts, a retry would not find the deltas again.
+	if err := exp.client.PostMetrics(ms); err != nil {
+		errs := []error{err}
+		if sketchesErr != nil {
+			errs = append(errs, fmt.Errorf("failed to send sketches: %w", sketchesErr))
+		}
+		return droppedTimeSeries, componenterror.CombineErrors(errs)
+	}
+
+	if sketchesErr != nil {
+		// Retrying would post the series a second time while the sketches
+		// of cumulative histograms would be empty, so the sketches are dropped.
+		return droppedTimeSeries + len(sl), consumererror.Permanent(fmt.Errorf("failed to send sketches: %w", sketchesErr))
+	}
+	return droppedTimeSeries, nil
 }
@@ -15,15 +15,25 @@
 package datadogexporter
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/sketches"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/testutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/utils"
 )
 
 func TestNewExporter(t *testing.T) {
@@ -48,3 +58,107 @@ func TestNewExporter(t *testing.T) {
 	exp := newMetricsExporter(params, cfg)
 	assert.NotNil(t, exp)
 }
+
+func TestPushSketches(t *testing.T) {
+	sketch := sketches.NewSketch()
+	sketch.Insert(1, 1)
+	sl := []sketches.SketchSeries{{Name: "test.metric", Host: "test-host", Sketch: sketch}}
+	expected, err := sketches.Marshal(sl)
+	require.NoError(t, err)
+
+	var got []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/beta/sketches" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		headers = r.Header
+		got, err = ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Key: "ddog_32_characters_long_api_key1",
+		},
+		Metrics: config.MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+		},
+	}
+	exp := &metricsExporter{
+		logger:         zap.NewNop(),
+		cfg:            cfg,
+		startInfo:      component.ApplicationStartInfo{ExeName: "otelcontribcol", Version: "1.0"},
+		sketchesClient: server.Client(),
+	}
+
+	require.NoError(t, exp.pushSketches(context.Background(), sl))
+	assert.Equal(t, expected, got)
+	assert.Equal(t, "application/x-protobuf", headers.Get("Content-Type"))
+	assert.Equal(t, "ddog_32_characters_long_api_key1", headers.Get("DD-Api-Key"))
+	assert.Equal(t, "otelcontribcol/1.0", headers.Get("User-Agent"))
+
+	// Errors of the endpoint are reported
+	cfg.Metrics.TCPAddr.Endpoint = server.URL + "/invalid"
+	assert.Error(t, exp.pushSketches(context.Background(), sl))
+}
+
+func TestPushMetricsDataPostsSeriesWhenSketchesFail(t *testing.T) {
+	seriesPosted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/series":
+			seriesPosted = true
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Key: "ddog_32_characters_long_api_key1",
+		},
+		Metrics: config.MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+			SendDistributions: true,
+		},
+	}
+	exp := &metricsExporter{
+		logger:         zap.NewNop(),
+		cfg:            cfg,
+		client:         utils.CreateClient(cfg.API.Key, server.URL),
+		prevPts:        newTTLMap(),
+		startInfo:      component.ApplicationStartInfo{ExeName: "otelcontribcol", Version: "1.0"},
+		sketchesClient: server.Client(),
+	}
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	ilms.At(0).Metrics().Resize(1)
+	metric := ilms.At(0).Metrics().At(0)
+	metric.SetName("test.histogram")
+	metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	metric.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	metric.DoubleHistogram().DataPoints().Resize(1)
+	point := metric.DoubleHistogram().DataPoints().At(0)
+	point.SetCount(2)
+	point.SetSum(3)
+	point.SetTimestamp(pdata.TimestampUnixNano(time.Now().UnixNano()))
+
+	dropped, err := exp.PushMetricsData(context.Background(), md)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err), "failed sketches must not be retried with the posted series")
+	assert.Equal(t, 1, dropped)
+	assert.True(t, seriesPosted, "series should be posted even if the sketches failed")
+}
@@ -17,6 +17,7 @@ package datadogexporter
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -26,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/sketches"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
 )
 
@@ -247,8 +249,190 @@ func mapDoubleHistogramMetrics(name string, slice pdata.DoubleHistogramDataPoint
 	return ms
 }
 
+// histogramPoint keeps the values of a cumulative
+// histogram at a given point in time
+type histogramPoint struct {
+	ts           uint64
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+// histogramToSketch builds the sketch of the values of an histogram datapoint
+//
+// The values of each bucket are assumed to be uniformly distributed between its bounds.
+// Only one bound of the first and last buckets is known, so their values are all
+// assumed to be equal to it.
+func histogramToSketch(bounds []float64, bucketCounts []uint64, count uint64, sum float64) *sketches.Sketch {
+	sketch := sketches.NewSketch()
+	if len(bounds) == 0 || len(bucketCounts) != len(bounds)+1 {
+		// Without valid buckets, the best estimate of the values is their average
+		sketch.Insert(sum/float64(count), count)
+	} else {
+		for i, n := range bucketCounts {
+			switch i {
+			case 0:
+				sketch.Insert(bounds[0], n)
+			case len(bounds):
+				sketch.Insert(bounds[len(bounds)-1], n)
+			default:
+				sketch.InsertInterpolate(bounds[i-1], bounds[i], n)
+			}
+		}
+	}
+
+	// The sum is known exactly, unlike the values of the buckets
+	sketch.Sum = sum
+	return sketch
+}
+
+// mapHistogramSketch maps an histogram datapoint into a Datadog distribution
+//
+// Datadog distributions hold the values reported over an interval, so the difference
+// between successive points of cumulative histograms are reported, similarly to
+// cumulative monotonic metrics. ok is false when there is nothing to report.
+func mapHistogramSketch(name string, prevPts *ttlmap.TTLMap, cumulative bool, ts uint64, tags []string,
+	bounds []float64, bucketCounts []uint64, count uint64, sum float64) (series sketches.SketchSeries, ok bool) {
+	if cumulative {
+		key := metricDimensionsToMapKey(name, tags)
+		prev, hasPrev := prevPts.Get(key).(histogramPoint)
+		if hasPrev && prev.ts > ts {
+			// We were given a point older than the one in memory so we drop it
+			// We keep the existing point in memory since it is the most recent
+			return series, false
+		}
+		prevPts.Put(key, histogramPoint{ts, count, sum, bucketCounts})
+
+		// The first point of an histogram, or the first one after a reset,
+		// is saved but not exported since we can't do a delta
+		if !hasPrev || count < prev.count || len(prev.bucketCounts) != len(bucketCounts) {
+			return series, false
+		}
+		deltas := make([]uint64, len(bucketCounts))
+		for i := range bucketCounts {
+			if bucketCounts[i] < prev.bucketCounts[i] {
+				return series, false
+			}
+			deltas[i] = bucketCounts[i] - prev.bucketCounts[i]
+		}
+		bucketCounts, count, sum = deltas, count-prev.count, sum-prev.sum
+	}
+
+	if count == 0 {
+		return series, false
+	}
+
+	return sketches.SketchSeries{
+		Name:      name,
+		Tags:      tags,
+		Timestamp: ts,
+		Sketch:    histogramToSketch(bounds, bucketCounts, count, sum),
+	}, true
+}
+
+// mapIntHistogramSketches maps histogram metrics slices to Datadog distributions
+func mapIntHistogramSketches(name string, prevPts *ttlmap.TTLMap, cumulative bool, slice pdata.IntHistogramDataPointSlice, attrTags []string) []sketches.SketchSeries {
+	sl := make([]sketches.SketchSeries, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		p := slice.At(i)
+		tags := getTags(p.LabelsMap())
+		tags = append(tags, attrTags...)
+
+		series, ok := mapHistogramSketch(name, prevPts, cumulative, uint64(p.Timestamp()), tags,
+			p.ExplicitBounds(), p.BucketCounts(), p.Count(), float64(p.Sum()))
+		if ok {
+			sl = append(sl, series)
+		}
+	}
+	return sl
+}
+
+// mapDoubleHistogramSketches maps double histogram metrics slices to Datadog distributions
+func mapDoubleHistogramSketches(name string, prevPts *ttlmap.TTLMap, cumulative bool, slice pdata.DoubleHistogramDataPointSlice, attrTags []string) []sketches.SketchSeries {
+	sl := make([]sketches.SketchSeries, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		p := slice.At(i)
+		tags := getTags(p.LabelsMap())
+		tags = append(tags, attrTags...)
+
+		series, ok := mapHistogramSketch(name, prevPts, cumulative, uint64(p.Timestamp()), tags,
+			p.ExplicitBounds(), p.BucketCounts(), p.Count(), p.Sum())
+		if ok {
+			sl = append(sl, series)
+		}
+	}
+	return sl
+}
+
+// mapDoubleSummaryMetrics maps summary metrics slices to Datadog metrics
+//
+// A Summary metric has:
+// - The count of values in the population
+// - The sum of values in the population
+// - A number of quantiles, each of them having
+//    - the quantile
+//    - the value of the quantile
+//
+// The count and sum are reported like cumulative monotonic metrics, as deltas
+// if monotonic is true and as gauges otherwise. The quantiles are reported as
+// a single gauge, 'quantile', which is tagged with the quantile.
+func mapDoubleSummaryMetrics(name string, prevPts *ttlmap.TTLMap, monotonic bool, slice pdata.DoubleSummaryDataPointSlice, attrTags []string) []datadog.Metric {
+	// Allocate assuming no quantiles
+	ms := make([]datadog.Metric, 0, 2*slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		p := slice.At(i)
+		ts := uint64(p.Timestamp())
+		tags := getTags(p.LabelsMap())
+		tags = append(tags, attrTags...)
+
+		countName, sumName := fmt.Sprintf("%s.count", name), fmt.Sprintf("%s.sum", name)
+		if monotonic {
+			if dx, ok := monotonicDelta(prevPts, countName, ts, float64(p.Count()), tags); ok {
+				ms = append(ms, metrics.NewCount(countName, ts, dx, tags))
+			}
+			if dx, ok := monotonicDelta(prevPts, sumName, ts, p.Sum(), tags); ok {
+				ms = append(ms, metrics.NewCount(sumName, ts, dx, tags))
+			}
+		} else {
+			ms = append(ms,
+				metrics.NewGauge(countName, ts, float64(p.Count()), tags),
+				metrics.NewGauge(sumName, ts, p.Sum(), tags),
+			)
+		}
+
+		fullName := fmt.Sprintf("%s.quantile", name)
+		quantiles := p.QuantileValues()
+		for j := 0; j < quantiles.Len(); j++ {
+			q := quantiles.At(j)
+			quantileTags := append(tags[:len(tags):len(tags)], fmt.Sprintf("quantile:%s", strconv.FormatFloat(q.Quantile(), 'g', -1, 64)))
+			ms = append(ms,
+				metrics.NewGauge(fullName, ts, q.Value(), quantileTags),
+			)
+		}
+	}
+	return ms
+}
+
+// monotonicDelta returns the difference between a value of a cumulative monotonic
+// metric and its previous value, with the same semantics as mapDoubleMonotonicMetrics.
+// ok is false when there is nothing to report.
+func monotonicDelta(prevPts *ttlmap.TTLMap, name string, ts uint64, value float64, tags []string) (dx float64, ok bool) {
+	key := metricDimensionsToMapKey(name, tags)
+	cnt, hasPrev := prevPts.Get(key).(doubleCounter)
+	if hasPrev && cnt.ts > ts {
+		return 0, false
+	}
+	prevPts.Put(key, doubleCounter{ts, value})
+
+	if !hasPrev {
+		return 0, false
+	}
+	dx = value - cnt.value
+	return dx, dx >= 0
+}
+
 // mapMetrics maps OTLP metrics into the DataDog format
-func mapMetrics(cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, md pdata.Metrics) (series []datadog.Metric, droppedTimeSeries int) {
+func mapMetrics(cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, md pdata.Metrics) (series []datadog.Metric, sl []sketches.SketchSeries, droppedTimeSeries int) {
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
@@ -268,6 +452,7 @@ func mapMetrics(cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, md pdata.Metri
 			for k := 0; k < metrics.Len(); k++ {
 				md := metrics.At(k)
 				var datapoints []datadog.Metric
+				var sketchSeries []sketches.SketchSeries
 				switch md.DataType() {
 				case pdata.MetricDataTypeNone:
 					continue
@@ -288,9 +473,21 @@ func mapMetrics(cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, md pdata.Metri
 						datapoints = mapDoubleMetrics(md.Name(), md.DoubleSum().DataPoints(), attributeTags)
 					}
 				case pdata.MetricDataTypeIntHistogram:
-					datapoints = mapIntHistogramMetrics(md.Name(), md.IntHistogram().DataPoints(), cfg.Buckets, attributeTags)
+					if cfg.SendDistributions {
+						cumulative := md.IntHistogram().AggregationTemporality() == pdata.AggregationTemporalityCumulative
+						sketchSeries = mapIntHistogramSketches(md.Name(), prevPts, cumulative, md.IntHistogram().DataPoints(), attributeTags)
+					} else {
+						datapoints = mapIntHistogramMetrics(md.Name(), md.IntHistogram().DataPoints(), cfg.Buckets, attributeTags)
+					}
 				case pdata.MetricDataTypeDoubleHistogram:
-					datapoints = mapDoubleHistogramMetrics(md.Name(), md.DoubleHistogram().DataPoints(), cfg.Buckets, attributeTags)
+					if cfg.SendDistributions {
+						cumulative := md.DoubleHistogram().AggregationTemporality() == pdata.AggregationTemporalityCumulative
+						sketchSeries = mapDoubleHistogramSketches(md.Name(), prevPts, cumulative, md.DoubleHistogram().DataPoints(), attributeTags)
+					} else {
+						datapoints = mapDoubleHistogramMetrics(md.Name(), md.DoubleHistogram().DataPoints(), cfg.Buckets, attributeTags)
+					}
+				case pdata.MetricDataTypeDoubleSummary:
+					datapoints = mapDoubleSummaryMetrics(md.Name(), prevPts, cfg.SendMonotonic, md.DoubleSummary().DataPoints(), attributeTags)
 				}
 
 				// Try to get host from resource
@@ -298,9 +495,13 @@ func mapMetrics(cfg config.MetricsConfig, prevPts *ttlmap.TTLMap, md pdata.Metri
 					for i := range datapoints {
 						datapoints[i].SetHost(host)
 					}
+					for i := range sketchSeries {
+						sketchSeries[i].Host = host
+					}
 				}
 
 				series = append(series, datapoints...)
+				sl = append(sl, sketchSeries...)
 			}
 		}
 	}
@@ -20,10 +20,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"gopkg.in/zorkian/go-datadog-api.v2"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/sketches"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
 )
 
@@ -516,3 +519,145 @@ func TestMapDoubleHistogramMetrics(t *testing.T) {
 		append(noBucketsAttributeTags, bucketsAttributeTags...),
 	)
 }
+
+func TestHistogramToSketch(t *testing.T) {
+	sketch := histogramToSketch([]float64{0, 10, 20}, []uint64{1, 10, 5, 2}, 18, 180)
+
+	expected := sketches.NewSketch()
+	expected.Insert(0, 1)
+	expected.InsertInterpolate(0, 10, 10)
+	expected.InsertInterpolate(10, 20, 5)
+	expected.Insert(20, 2)
+	expected.Sum = 180
+	assert.Equal(t, expected, sketch)
+
+	// Without buckets, all the values are assumed to be equal to their average
+	sketch = histogramToSketch(nil, []uint64{4}, 4, 20)
+	expected = sketches.NewSketch()
+	expected.Insert(5, 4)
+	assert.Equal(t, expected, sketch)
+}
+
+func TestMapDoubleHistogramSketches(t *testing.T) {
+	slice := pdata.NewDoubleHistogramDataPointSlice()
+	slice.Resize(3)
+	for i, counts := range [][]uint64{{1, 2, 0}, {2, 5, 1}, {1, 1, 0}} {
+		point := slice.At(i)
+		point.SetTimestamp(seconds(i))
+		point.SetExplicitBounds([]float64{1, 2})
+		point.SetBucketCounts(counts)
+		var count uint64
+		for _, n := range counts {
+			count += n
+		}
+		point.SetCount(count)
+		point.SetSum(float64(3 * count))
+	}
+
+	// Delta histograms are all reported
+	sl := mapDoubleHistogramSketches("doubleHist.test", newTTLMap(), false, slice, []string{"attribute_tag:attribute_value"})
+	require.Len(t, sl, 3)
+	assert.Equal(t, sketches.SketchSeries{
+		Name:      "doubleHist.test",
+		Tags:      []string{"attribute_tag:attribute_value"},
+		Timestamp: uint64(seconds(0)),
+		Sketch:    histogramToSketch([]float64{1, 2}, []uint64{1, 2, 0}, 3, 9),
+	}, sl[0])
+
+	// The difference between the points of cumulative histograms is reported,
+	// the first point and the ones after a reset are not.
+	sl = mapDoubleHistogramSketches("doubleHist.test", newTTLMap(), true, slice, []string{})
+	require.Len(t, sl, 1)
+	assert.Equal(t, uint64(seconds(1)), sl[0].Timestamp)
+	assert.Equal(t, histogramToSketch([]float64{1, 2}, []uint64{1, 3, 1}, 5, 15), sl[0].Sketch)
+}
+
+func TestMapIntHistogramSketchesOutOfOrder(t *testing.T) {
+	slice := pdata.NewIntHistogramDataPointSlice()
+	slice.Resize(3)
+	for i, ts := range []int{1, 3, 2} {
+		point := slice.At(i)
+		point.SetTimestamp(seconds(ts))
+		point.SetBucketCounts([]uint64{uint64(ts), uint64(ts)})
+		point.SetExplicitBounds([]float64{10})
+		point.SetCount(uint64(2 * ts))
+		point.SetSum(int64(10 * ts))
+	}
+
+	sl := mapIntHistogramSketches("intHist.test", newTTLMap(), true, slice, []string{})
+	require.Len(t, sl, 1)
+	assert.Equal(t, uint64(seconds(3)), sl[0].Timestamp)
+	assert.Equal(t, histogramToSketch([]float64{10}, []uint64{2, 2}, 4, 20), sl[0].Sketch)
+}
+
+func TestMapDoubleSummaryMetrics(t *testing.T) {
+	slice := pdata.NewDoubleSummaryDataPointSlice()
+	slice.Resize(2)
+	for i := 0; i < slice.Len(); i++ {
+		point := slice.At(i)
+		point.SetTimestamp(seconds(i))
+		point.SetCount(uint64(10 * (i + 1)))
+		point.SetSum(float64(100 * (i + 1)))
+		quantiles := point.QuantileValues()
+		quantiles.Resize(2)
+		quantiles.At(0).SetQuantile(0.5)
+		quantiles.At(0).SetValue(float64(i + 1))
+		quantiles.At(1).SetQuantile(0.99)
+		quantiles.At(1).SetValue(float64(i + 2))
+	}
+
+	tags := []string{"attribute_tag:attribute_value"}
+	quantiles := []datadog.Metric{
+		metrics.NewGauge("summary.test.quantile", uint64(seconds(0)), 1, []string{"attribute_tag:attribute_value", "quantile:0.5"}),
+		metrics.NewGauge("summary.test.quantile", uint64(seconds(0)), 2, []string{"attribute_tag:attribute_value", "quantile:0.99"}),
+		metrics.NewGauge("summary.test.quantile", uint64(seconds(1)), 2, []string{"attribute_tag:attribute_value", "quantile:0.5"}),
+		metrics.NewGauge("summary.test.quantile", uint64(seconds(1)), 3, []string{"attribute_tag:attribute_value", "quantile:0.99"}),
+	}
+
+	assert.ElementsMatch(t,
+		mapDoubleSummaryMetrics("summary.test", newTTLMap(), false, slice, tags),
+		append([]datadog.Metric{
+			metrics.NewGauge("summary.test.count", uint64(seconds(0)), 10, tags),
+			metrics.NewGauge("summary.test.sum", uint64(seconds(0)), 100, tags),
+			metrics.NewGauge("summary.test.count", uint64(seconds(1)), 20, tags),
+			metrics.NewGauge("summary.test.sum", uint64(seconds(1)), 200, tags),
+		}, quantiles...),
+	)
+
+	assert.ElementsMatch(t,
+		mapDoubleSummaryMetrics("summary.test", newTTLMap(), true, slice, tags),
+		append([]datadog.Metric{
+			metrics.NewCount("summary.test.count", uint64(seconds(1)), 10, tags),
+			metrics.NewCount("summary.test.sum", uint64(seconds(1)), 100, tags),
+		}, quantiles...),
+	)
+}
+
+func TestMapMetricsDistributions(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("datadog.host.name", "test-host")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	ms.Resize(1)
+	m := ms.At(0)
+	m.SetName("doubleHist.test")
+	m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	m.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	m.DoubleHistogram().DataPoints().Resize(1)
+	point := m.DoubleHistogram().DataPoints().At(0)
+	point.SetCount(2)
+	point.SetSum(3)
+	point.SetBucketCounts([]uint64{2})
+
+	series, sl, _ := mapMetrics(config.MetricsConfig{}, newTTLMap(), md)
+	assert.Len(t, series, 2)
+	assert.Empty(t, sl)
+
+	series, sl, _ = mapMetrics(config.MetricsConfig{SendDistributions: true}, newTTLMap(), md)
+	assert.Empty(t, series)
+	require.Len(t, sl, 1)
+	assert.Equal(t, "doubleHist.test", sl[0].Name)
+	assert.Equal(t, "test-host", sl[0].Host)
+}
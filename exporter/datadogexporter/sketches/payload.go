@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketches
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// SketchSeries is a sketch of the values of a distribution metric over an interval
+type SketchSeries struct {
+	Name string
+	Host string
+	Tags []string
+	// Timestamp is the Unix nanoseconds timestamp of the end of the interval
+	Timestamp uint64
+	Sketch    *Sketch
+}
+
+// The following messages are the subset of the Datadog Agent sketch payload
+// used by the exporter.
+// See https://github.com/DataDog/agent-payload/blob/master/proto/metrics/agent_payload.proto
+
+// sketchPayload is the payload accepted by the sketches endpoint
+type sketchPayload struct {
+	Sketches []*sketchMessage `protobuf:"bytes,1,rep,name=sketches"`
+}
+
+func (m *sketchPayload) Reset()         { *m = sketchPayload{} }
+func (m *sketchPayload) String() string { return proto.CompactTextString(m) }
+func (*sketchPayload) ProtoMessage()    {}
+
+type sketchMessage struct {
+	Metric      string              `protobuf:"bytes,1,opt,name=metric"`
+	Host        string              `protobuf:"bytes,2,opt,name=host"`
+	Tags        []string            `protobuf:"bytes,4,rep,name=tags"`
+	Dogsketches []*dogsketchMessage `protobuf:"bytes,7,rep,name=dogsketches"`
+}
+
+func (m *sketchMessage) Reset()         { *m = sketchMessage{} }
+func (m *sketchMessage) String() string { return proto.CompactTextString(m) }
+func (*sketchMessage) ProtoMessage()    {}
+
+type dogsketchMessage struct {
+	// Ts is the Unix timestamp in seconds
+	Ts  int64   `protobuf:"varint,1,opt,name=ts"`
+	Cnt int64   `protobuf:"varint,2,opt,name=cnt"`
+	Min float64 `protobuf:"fixed64,3,opt,name=min"`
+	Max float64 `protobuf:"fixed64,4,opt,name=max"`
+	Avg float64 `protobuf:"fixed64,5,opt,name=avg"`
+	Sum float64 `protobuf:"fixed64,6,opt,name=sum"`
+	// K are the keys of the bins and N the number of values in each of them
+	K []int32  `protobuf:"zigzag32,7,rep,packed,name=k"`
+	N []uint32 `protobuf:"varint,8,rep,packed,name=n"`
+}
+
+func (m *dogsketchMessage) Reset()         { *m = dogsketchMessage{} }
+func (m *dogsketchMessage) String() string { return proto.CompactTextString(m) }
+func (*dogsketchMessage) ProtoMessage()    {}
+
+// Marshal serializes sketch series into the protobuf payload of the sketches endpoint
+func Marshal(sl []SketchSeries) ([]byte, error) {
+	payload := &sketchPayload{Sketches: make([]*sketchMessage, 0, len(sl))}
+	for _, series := range sl {
+		keys, counts := series.Sketch.Bins()
+		payload.Sketches = append(payload.Sketches, &sketchMessage{
+			Metric: series.Name,
+			Host:   series.Host,
+			Tags:   series.Tags,
+			Dogsketches: []*dogsketchMessage{{
+				Ts:  int64(series.Timestamp / 1e9),
+				Cnt: int64(series.Sketch.Count),
+				Min: series.Sketch.Min,
+				Max: series.Sketch.Max,
+				Avg: series.Sketch.Sum / float64(series.Sketch.Count),
+				Sum: series.Sketch.Sum,
+				K:   keys,
+				N:   counts,
+			}},
+		})
+	}
+	return proto.Marshal(payload)
+}
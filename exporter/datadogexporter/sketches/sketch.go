@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketches
+
+import (
+	"math"
+	"sort"
+)
+
+// The sketches use the same logarithmic mapping of values to keys as the
+// Datadog Agent sketches, so that they can be merged with them by the backend.
+// See https://github.com/DataDog/datadog-agent/tree/master/pkg/quantile
+const (
+	// relativeAccuracy is the relative accuracy of the quantiles computed from the sketches
+	relativeAccuracy = 1.0 / 128
+	// minValue is the smallest positive value distinguished from zero
+	minValue = 1e-9
+	// maxKey is the key of the values too large to be mapped
+	maxKey = math.MaxInt16
+)
+
+var (
+	gammaLn = math.Log1p(2 * relativeAccuracy)
+	// minKeyExponent is the exponent of the smallest key: values lower than
+	// gamma^minKeyExponent are mapped to the zero key
+	minKeyExponent = int(math.Floor(math.Log(minValue) / gammaLn))
+	// bias is added to the exponents so that the positive keys start at 1
+	bias = -minKeyExponent + 1
+	// normMin is the lower bound of the smallest positive key
+	normMin = math.Exp(float64(minKeyExponent) * gammaLn)
+)
+
+// key returns the key of the bin value v falls in
+func key(v float64) int32 {
+	if v < 0 {
+		return -key(-v)
+	}
+	if v < normMin {
+		return 0
+	}
+
+	k := int(math.RoundToEven(math.Log(v)/gammaLn)) + bias
+	if k > maxKey {
+		return maxKey
+	}
+	if k < 1 {
+		return 1
+	}
+	return int32(k)
+}
+
+// keyBounds returns the range of the values mapped to key k
+func keyBounds(k int32) (lower, upper float64) {
+	switch {
+	case k < 0:
+		lower, upper = keyBounds(-k)
+		return -upper, -lower
+	case k == 0:
+		return -normMin, normMin
+	case k == maxKey:
+		return math.Exp((float64(k-int32(bias)) - 0.5) * gammaLn), math.Inf(1)
+	}
+	exp := float64(k - int32(bias))
+	lower = math.Exp((exp - 0.5) * gammaLn)
+	if k == 1 {
+		lower = normMin
+	}
+	return lower, math.Exp((exp + 0.5) * gammaLn)
+}
+
+// Sketch is a distribution of values, summarized by the number of values
+// falling in each bin of a logarithmic mapping.
+type Sketch struct {
+	bins map[int32]uint64
+
+	// Summary of the values inserted in the sketch
+	Count uint64
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+// NewSketch returns an empty sketch
+func NewSketch() *Sketch {
+	return &Sketch{
+		bins: make(map[int32]uint64),
+		Min:  math.Inf(1),
+		Max:  math.Inf(-1),
+	}
+}
+
+// Insert adds count occurrences of value v to the sketch
+func (s *Sketch) Insert(v float64, count uint64) {
+	if count == 0 {
+		return
+	}
+	s.bins[key(v)] += count
+	s.updateSummary(v, v, count, v*float64(count))
+}
+
+// InsertInterpolate adds count values uniformly distributed between lower and upper
+// to the sketch. This is used to convert the buckets of an histogram, for which only
+// the number of values falling between the bucket bounds is known.
+func (s *Sketch) InsertInterpolate(lower, upper float64, count uint64) {
+	if count == 0 {
+		return
+	}
+	if upper <= lower {
+		s.Insert(lower, count)
+		return
+	}
+
+	// The keys are monotonic with the values, so the values between lower and upper
+	// fall in the bins between their keys. Each bin gets its share of the values,
+	// rounded so that the total is exactly count.
+	lowerKey, upperKey := key(lower), key(upper)
+	var inserted uint64
+	for k := lowerKey; k <= upperKey; k++ {
+		_, binUpper := keyBounds(k)
+		var binCount uint64
+		if k == upperKey {
+			binCount = count - inserted
+		} else {
+			share := (math.Min(binUpper, upper) - lower) / (upper - lower)
+			binCount = uint64(math.Round(share*float64(count))) - inserted
+		}
+		if binCount > 0 {
+			s.bins[k] += binCount
+			inserted += binCount
+		}
+	}
+	s.updateSummary(lower, upper, count, (lower+upper)/2*float64(count))
+}
+
+func (s *Sketch) updateSummary(min, max float64, count uint64, sum float64) {
+	s.Count += count
+	s.Sum += sum
+	s.Min = math.Min(s.Min, min)
+	s.Max = math.Max(s.Max, max)
+}
+
+// Bins returns the keys of the non-empty bins of the sketch in increasing order,
+// and the number of values in each of them.
+func (s *Sketch) Bins() (keys []int32, counts []uint32) {
+	sorted := make([]int32, 0, len(s.bins))
+	for k := range s.bins {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	keys = make([]int32, 0, len(sorted))
+	counts = make([]uint32, 0, len(sorted))
+	for _, k := range sorted {
+		// The number of values of a bin is sent on 32 bits,
+		// larger ones are split into several bins with the same key.
+		n := s.bins[k]
+		for ; n > math.MaxUint32; n -= math.MaxUint32 {
+			keys = append(keys, k)
+			counts = append(counts, math.MaxUint32)
+		}
+		keys = append(keys, k)
+		counts = append(counts, uint32(n))
+	}
+	return keys, counts
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketches
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, int32(0), key(0))
+	assert.Equal(t, int32(0), key(minValue/2))
+	assert.Equal(t, int32(1), key(minValue))
+	assert.Equal(t, int32(maxKey), key(math.MaxFloat64))
+	assert.Equal(t, -key(42), key(-42))
+
+	for _, v := range []float64{minValue, 1e-3, 0.5, 1, 2, 42, 1e6, 1e12} {
+		k := key(v)
+		lower, upper := keyBounds(k)
+		assert.True(t, lower <= v && v < upper, "%v is not in the bounds of its key [%v, %v)", v, lower, upper)
+		// The bins are within the relative accuracy of the sketches
+		assert.InDelta(t, 1, upper/lower, 2*relativeAccuracy+1e-9)
+		assert.Equal(t, k+1, key(upper*(1+1e-9)))
+	}
+}
+
+func TestInsert(t *testing.T) {
+	sketch := NewSketch()
+	sketch.Insert(2, 3)
+	sketch.Insert(-1, 1)
+	sketch.Insert(0, 0)
+
+	keys, counts := sketch.Bins()
+	assert.Equal(t, []int32{key(-1), key(2)}, keys)
+	assert.Equal(t, []uint32{1, 3}, counts)
+	assert.Equal(t, uint64(4), sketch.Count)
+	assert.Equal(t, 5.0, sketch.Sum)
+	assert.Equal(t, -1.0, sketch.Min)
+	assert.Equal(t, 2.0, sketch.Max)
+}
+
+func TestInsertInterpolate(t *testing.T) {
+	sketch := NewSketch()
+	sketch.InsertInterpolate(10, 20, 1000)
+
+	keys, counts := sketch.Bins()
+	assert.Equal(t, key(10), keys[0])
+	assert.Equal(t, key(20), keys[len(keys)-1])
+	var total uint32
+	for i := range keys {
+		total += counts[i]
+		// The values are uniformly distributed, so the bins get a number of values
+		// proportional to their width.
+		if i > 0 && i < len(keys)-1 {
+			lower, upper := keyBounds(keys[i])
+			assert.InDelta(t, 1000*(upper-lower)/10, counts[i], 1)
+		}
+	}
+	assert.Equal(t, uint32(1000), total)
+	assert.Equal(t, uint64(1000), sketch.Count)
+	assert.Equal(t, 15000.0, sketch.Sum)
+	assert.Equal(t, 10.0, sketch.Min)
+	assert.Equal(t, 20.0, sketch.Max)
+
+	// Intervals across zero
+	sketch = NewSketch()
+	sketch.InsertInterpolate(-1, 1, 10)
+	_, counts = sketch.Bins()
+	total = 0
+	for _, n := range counts {
+		total += n
+	}
+	assert.Equal(t, uint32(10), total)
+
+	// Empty intervals
+	sketch = NewSketch()
+	sketch.InsertInterpolate(1, 1, 10)
+	keys, counts = sketch.Bins()
+	assert.Equal(t, []int32{key(1)}, keys)
+	assert.Equal(t, []uint32{10}, counts)
+}
+
+func TestBinsLargeCounts(t *testing.T) {
+	sketch := NewSketch()
+	sketch.Insert(1, 2*math.MaxUint32+1)
+
+	keys, counts := sketch.Bins()
+	assert.Equal(t, []int32{key(1), key(1), key(1)}, keys)
+	assert.Equal(t, []uint32{math.MaxUint32, math.MaxUint32, 1}, counts)
+}
+
+func TestMarshal(t *testing.T) {
+	sketch := NewSketch()
+	sketch.Insert(1, 2)
+	sketch.Insert(3, 2)
+
+	b, err := Marshal([]SketchSeries{{
+		Name:      "test.metric",
+		Host:      "test-host",
+		Tags:      []string{"key:value"},
+		Timestamp: 1000e9 + 1,
+		Sketch:    sketch,
+	}})
+	require.NoError(t, err)
+
+	var payload sketchPayload
+	require.NoError(t, proto.Unmarshal(b, &payload))
+	assert.Equal(t, sketchPayload{Sketches: []*sketchMessage{{
+		Metric: "test.metric",
+		Host:   "test-host",
+		Tags:   []string{"key:value"},
+		Dogsketches: []*dogsketchMessage{{
+			Ts:  1000,
+			Cnt: 4,
+			Min: 1,
+			Max: 3,
+			Avg: 2,
+			Sum: 8,
+			K:   []int32{key(1), key(3)},
+			N:   []uint32{2, 2},
+		}},
+	}}}, payload)
+}
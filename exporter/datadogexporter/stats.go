@@ -15,6 +15,7 @@
 package datadogexporter
 
 import (
+	"sync"
 	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
@@ -24,53 +25,120 @@ import (
 const (
 	statsBucketDuration   int64  = int64(10 * time.Second)
 	versionAggregationTag string = "version"
+
+	// statsBufferLen is the number of buckets, including the current one, that are kept
+	// open so that spans reported late are still counted in the bucket they ended in.
+	// This is the same trade-off as the datadog-agent concentrator.
+	statsBufferLen int64 = 2
 )
 
-// ComputeAPMStats calculates the stats that should be submitted to APM about a given trace
-func computeAPMStats(tracePayload *pb.TracePayload, calculator *sublayerCalculator, pushTime int64) *stats.Payload {
-	statsRawBuckets := make(map[int64]*stats.RawBucket)
+// statsPayloadKey identifies the stats payload a bucket is sent in.
+type statsPayloadKey struct {
+	hostname string
+	env      string
+}
+
+// statsConcentrator aggregates the APM stats of the analyzed spans in time buckets,
+// keyed by the end time of the spans, like the datadog-agent concentrator does.
+// The buckets are only flushed once they can no longer receive spans.
+type statsConcentrator struct {
+	mutex sync.Mutex
+	// Start of the oldest bucket spans can still be added to. Spans ending before it
+	// are counted in this bucket, as older ones may have been flushed already.
+	oldestTs int64
+	buckets  map[statsPayloadKey]map[int64]*stats.RawBucket
+}
+
+func newStatsConcentrator(now int64) *statsConcentrator {
+	return &statsConcentrator{
+		oldestTs: alignStatsTs(now),
+		buckets:  make(map[statsPayloadKey]map[int64]*stats.RawBucket),
+	}
+}
+
+// add computes the stats of the analyzed spans of the payload into their buckets.
+func (c *statsConcentrator) add(tracePayload *pb.TracePayload, calculator *sublayerCalculator) {
+	key := statsPayloadKey{hostname: tracePayload.HostName, env: tracePayload.Env}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	buckets, ok := c.buckets[key]
+	if !ok {
+		buckets = make(map[int64]*stats.RawBucket)
+		c.buckets[key] = buckets
+	}
 
-	bucketTS := pushTime - statsBucketDuration
 	for _, trace := range tracePayload.Traces {
 		spans := getAnalyzedSpans(trace.Spans)
 		sublayers := calculator.computeSublayers(trace.Spans)
 		for _, span := range spans {
+			bucketTS := alignStatsTs(span.Start + span.Duration)
+			if bucketTS < c.oldestTs {
+				bucketTS = c.oldestTs
+			}
 
-			// TODO: While this is hardcoded to assume a single 10s buckets for now,
-			// An improvement would be to support keeping multiple 10s buckets in buffer
-			// ala, [0-10][10-20][20-30], only flushing the oldest bucket, to allow traces that
-			// get reported late to still be counted in the correct bucket. This is how the
-			// datadog- agent handles stats buckets, but would be non trivial to add.
-
-			var statsRawBucket *stats.RawBucket
-			if existingBucket, ok := statsRawBuckets[bucketTS]; ok {
-				statsRawBucket = existingBucket
-			} else {
-				statsRawBucket = stats.NewRawBucket(bucketTS, statsBucketDuration)
-				statsRawBuckets[bucketTS] = statsRawBucket
+			bucket, ok := buckets[bucketTS]
+			if !ok {
+				bucket = stats.NewRawBucket(bucketTS, statsBucketDuration)
+				buckets[bucketTS] = bucket
 			}
 
-			// Use weight 1, as sampling in opentelemetry would occur upstream in a processor.
-			// Generally we want to ship 100% of traces to the backend where more accurate tail based sampling can be performed.
+			// The weight is the inverse of the sampling rate of the span, if any, so that
+			// the hits and errors of spans sampled upstream are accounted for.
 			// TopLevel is always "true" since we only compute stats for top-level spans.
 			weightedSpan := &stats.WeightedSpan{
 				Span:     span,
-				Weight:   1,
+				Weight:   stats.Weight(span),
 				TopLevel: true,
 			}
-			statsRawBucket.HandleSpan(weightedSpan, tracePayload.Env, []string{versionAggregationTag}, sublayers)
+			bucket.HandleSpan(weightedSpan, tracePayload.Env, []string{versionAggregationTag}, sublayers)
 		}
 	}
+}
+
+// flush returns the payloads of the buckets that can no longer receive spans, or of all
+// the buckets if force is set.
+func (c *statsConcentrator) flush(now int64, force bool) []*stats.Payload {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var payloads []*stats.Payload
+	for key, buckets := range c.buckets {
+		var statsBuckets []stats.Bucket
+		for ts, bucket := range buckets {
+			if !force && ts > now-statsBufferLen*statsBucketDuration {
+				continue
+			}
+			statsBuckets = append(statsBuckets, bucket.Export())
+			delete(buckets, ts)
+		}
 
-	// Export statsRawBuckets to statsBuckets
-	statsBuckets := make([]stats.Bucket, 0)
-	for _, statsRawBucket := range statsRawBuckets {
-		statsBuckets = append(statsBuckets, statsRawBucket.Export())
+		if len(buckets) == 0 {
+			delete(c.buckets, key)
+		}
+		if len(statsBuckets) > 0 {
+			payloads = append(payloads, &stats.Payload{
+				HostName: key.hostname,
+				Env:      key.env,
+				Stats:    statsBuckets,
+			})
+		}
 	}
 
-	return &stats.Payload{
-		HostName: tracePayload.HostName,
-		Env:      tracePayload.Env,
-		Stats:    statsBuckets,
+	// Spans can no longer be added to the flushed buckets.
+	oldestTs := alignStatsTs(now) - (statsBufferLen-1)*statsBucketDuration
+	if force {
+		oldestTs = alignStatsTs(now) + statsBucketDuration
+	}
+	if oldestTs > c.oldestTs {
+		c.oldestTs = oldestTs
 	}
+
+	return payloads
+}
+
+// alignStatsTs returns the start of the bucket the timestamp falls in.
+func alignStatsTs(ts int64) int64 {
+	return ts - ts%statsBucketDuration
 }
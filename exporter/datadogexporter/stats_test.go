@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bucketStart is an aligned bucket start used as the current time of the tests.
+var bucketStart = alignStatsTs(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+
+func statsTestPayload(env string, spans ...*pb.Span) *pb.TracePayload {
+	traces := make([]*pb.APITrace, 0, len(spans))
+	for _, span := range spans {
+		traces = append(traces, &pb.APITrace{TraceID: span.TraceID, Spans: []*pb.Span{span}})
+	}
+	return &pb.TracePayload{HostName: "test-host", Env: env, Traces: traces}
+}
+
+// statsTestSpan returns a root span ending at the given offset from bucketStart.
+func statsTestSpan(id uint64, end time.Duration, sampleRate float64) *pb.Span {
+	span := &pb.Span{
+		TraceID:  id,
+		SpanID:   id,
+		Service:  "test-service",
+		Name:     "test-name",
+		Resource: "test-resource",
+		Start:    bucketStart + int64(end) - int64(time.Millisecond),
+		Duration: int64(time.Millisecond),
+		Meta:     map[string]string{},
+		Metrics:  map[string]float64{},
+	}
+	if sampleRate > 0 {
+		span.Metrics[keySamplingRate] = sampleRate
+	}
+	return span
+}
+
+// hitsByBucket returns the weighted hits of the payloads by bucket start.
+func hitsByBucket(payloads []*stats.Payload) map[int64]float64 {
+	hits := make(map[int64]float64)
+	for _, payload := range payloads {
+		for _, bucket := range payload.Stats {
+			for _, count := range bucket.Counts {
+				if count.Measure == stats.HITS {
+					hits[bucket.Start] += count.Value
+				}
+			}
+		}
+	}
+	return hits
+}
+
+func TestStatsConcentratorBucketsBySpanEnd(t *testing.T) {
+	calculator := newSublayerCalculator()
+	bucketDuration := time.Duration(statsBucketDuration)
+
+	concentrator := newStatsConcentrator(bucketStart)
+	concentrator.add(statsTestPayload("test-env",
+		statsTestSpan(1, time.Second, 0),
+		statsTestSpan(2, bucketDuration+time.Second, 0),
+		statsTestSpan(3, bucketDuration+2*time.Second, 0),
+	), calculator)
+
+	// No bucket is closed yet.
+	assert.Empty(t, concentrator.flush(bucketStart+int64(bucketDuration)+int64(time.Second), false))
+
+	// The first bucket is kept open one more bucket duration for late spans.
+	concentrator.add(statsTestPayload("test-env", statsTestSpan(4, 2*time.Second, 0)), calculator)
+	payloads := concentrator.flush(bucketStart+int64(2*bucketDuration), false)
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "test-host", payloads[0].HostName)
+	assert.Equal(t, "test-env", payloads[0].Env)
+	assert.Equal(t, map[int64]float64{bucketStart: 2}, hitsByBucket(payloads))
+
+	// Spans ending in flushed buckets are counted in the oldest open one.
+	concentrator.add(statsTestPayload("test-env", statsTestSpan(5, 3*time.Second, 0)), calculator)
+	payloads = concentrator.flush(bucketStart+int64(3*bucketDuration), false)
+	assert.Equal(t, map[int64]float64{bucketStart + statsBucketDuration: 3}, hitsByBucket(payloads))
+
+	assert.Empty(t, concentrator.flush(bucketStart+int64(4*bucketDuration), false))
+}
+
+func TestStatsConcentratorForceFlush(t *testing.T) {
+	calculator := newSublayerCalculator()
+
+	concentrator := newStatsConcentrator(bucketStart)
+	concentrator.add(statsTestPayload("env-a", statsTestSpan(1, time.Second, 0)), calculator)
+	concentrator.add(statsTestPayload("env-b", statsTestSpan(2, time.Second, 0)), calculator)
+
+	payloads := concentrator.flush(bucketStart+int64(time.Second), true)
+	require.Len(t, payloads, 2)
+	envs := []string{payloads[0].Env, payloads[1].Env}
+	sort.Strings(envs)
+	assert.Equal(t, []string{"env-a", "env-b"}, envs)
+	assert.Equal(t, map[int64]float64{bucketStart: 2}, hitsByBucket(payloads))
+
+	assert.Empty(t, concentrator.flush(bucketStart+int64(time.Second), true))
+}
+
+func TestStatsConcentratorSamplingWeight(t *testing.T) {
+	calculator := newSublayerCalculator()
+
+	concentrator := newStatsConcentrator(bucketStart)
+	concentrator.add(statsTestPayload("test-env",
+		statsTestSpan(1, time.Second, 0.25),
+		statsTestSpan(2, time.Second, 0.5),
+		// Invalid rates are ignored.
+		statsTestSpan(3, time.Second, 2),
+		statsTestSpan(4, time.Second, 0),
+	), calculator)
+
+	payloads := concentrator.flush(bucketStart, true)
+	assert.Equal(t, map[int64]float64{bucketStart: 4 + 2 + 1 + 1}, hitsByBucket(payloads))
+}
@@ -54,7 +54,12 @@ type traceExporter struct {
 	edgeConnection TraceEdgeConnection
 	obfuscator     *obfuscate.Obfuscator
 	calculator     *sublayerCalculator
+	concentrator   *statsConcentrator
 	client         *datadog.Client
+
+	// stops the periodic flush of the stats
+	done chan struct{}
+	wg   sync.WaitGroup
 }
 
 var (
@@ -91,7 +96,9 @@ func newTraceExporter(params component.ExporterCreateParams, cfg *config.Config)
 		edgeConnection: createTraceEdgeConnection(cfg.Traces.TCPAddr.Endpoint, cfg.API.Key, params.ApplicationStartInfo),
 		obfuscator:     obfuscator,
 		calculator:     calculator,
+		concentrator:   newStatsConcentrator(time.Now().UnixNano()),
 		client:         client,
+		done:           make(chan struct{}),
 	}
 
 	return exporter
@@ -104,9 +111,48 @@ func newTraceExporter(params component.ExporterCreateParams, cfg *config.Config)
 // by connecting to the endpoint. Host parameter can be used for communicating
 // with the host after Start() has already returned. If error is returned by
 // Start() then the collector startup will be aborted.
-// func (exp *traceExporter) Start(_ context.Context, _ component.Host) error {
-// 	return nil
-// }
+func (exp *traceExporter) Start(_ context.Context, _ component.Host) error {
+	exp.wg.Add(1)
+	go func() {
+		defer exp.wg.Done()
+		exp.flushStatsPeriodically()
+	}()
+	return nil
+}
+
+// Shutdown stops the periodic flush of the stats and sends all the stats computed so far.
+func (exp *traceExporter) Shutdown(context.Context) error {
+	close(exp.done)
+	exp.wg.Wait()
+	exp.flushStats(true)
+	return nil
+}
+
+// flushStatsPeriodically sends the closed stats buckets every bucket duration.
+func (exp *traceExporter) flushStatsPeriodically() {
+	ticker := time.NewTicker(time.Duration(statsBucketDuration))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			exp.flushStats(false)
+		case <-exp.done:
+			return
+		}
+	}
+}
+
+// flushStats sends the closed stats buckets, or all of them if force is set.
+// Stats are used for generating metrics like hits, errors, and latency, they use a separate endpoint than Traces
+func (exp *traceExporter) flushStats(force bool) {
+	for _, statsPayload := range exp.concentrator.flush(time.Now().UTC().UnixNano(), force) {
+		// currently we don't want to do retries since api endpoints may not dedupe in certain situations
+		if err := exp.edgeConnection.SendStats(context.Background(), statsPayload, 1); err != nil {
+			exp.logger.Info("failed to send trace stats", zap.Error(err))
+		}
+	}
+}
 
 func (exp *traceExporter) pushTraceData(
 	ctx context.Context,
@@ -129,7 +175,7 @@ func (exp *traceExporter) pushTraceData(
 	for _, ddTracePayload := range aggregatedTraces {
 		// currently we don't want to do retries since api endpoints may not dedupe in certain situations
 		// adding a helper function here to make custom retry logic easier in the future
-		exp.pushWithRetry(ctx, ddTracePayload, 1, pushTime, func() error {
+		exp.pushWithRetry(ctx, ddTracePayload, 1, func() error {
 			return nil
 		})
 	}
@@ -143,20 +189,15 @@ func (exp *traceExporter) pushTraceData(
 }
 
 // gives us flexibility to add custom retry logic later
-func (exp *traceExporter) pushWithRetry(ctx context.Context, ddTracePayload *pb.TracePayload, maxRetries int, pushTime int64, fn func() error) error {
+func (exp *traceExporter) pushWithRetry(ctx context.Context, ddTracePayload *pb.TracePayload, maxRetries int, fn func() error) error {
 	err := exp.edgeConnection.SendTraces(ctx, ddTracePayload, maxRetries)
 
 	if err != nil {
 		exp.logger.Info("failed to send traces", zap.Error(err))
 	}
 
-	// this is for generating metrics like hits, errors, and latency, it uses a separate endpoint than Traces
-	stats := computeAPMStats(ddTracePayload, exp.calculator, pushTime)
-	errStats := exp.edgeConnection.SendStats(context.Background(), stats, maxRetries)
-
-	if errStats != nil {
-		exp.logger.Info("failed to send trace stats", zap.Error(errStats))
-	}
+	// the stats are sent by flushStats once their time bucket is closed
+	exp.concentrator.add(ddTracePayload, exp.calculator)
 
 	return fn()
 }
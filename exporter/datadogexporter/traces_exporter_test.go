@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
@@ -86,13 +87,15 @@ func testTraceExporterHelper(td pdata.Traces, t *testing.T) []string {
 	exporter, err := createTraceExporter(context.Background(), params, &cfg)
 
 	assert.NoError(t, err)
-
-	defer exporter.Shutdown(context.Background())
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
 
 	ctx := context.Background()
 	errConsume := exporter.ConsumeTraces(ctx, td)
 	assert.NoError(t, errConsume)
 
+	// the stats are sent at shutdown, when their time bucket is still open
+	assert.NoError(t, exporter.Shutdown(context.Background()))
+
 	return got
 }
 
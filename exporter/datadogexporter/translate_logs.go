@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+)
+
+const (
+	// Reserved attributes of the Datadog logs
+	// See https://docs.datadoghq.com/logs/log_collection/?tab=http#reserved-attributes
+	logMessage   string = "message"
+	logStatus    string = "status"
+	logTimestamp string = "timestamp"
+	logHostname  string = "hostname"
+	logService   string = "service"
+	logSource    string = "ddsource"
+	logTags      string = "ddtags"
+	// Attributes correlating the logs with the traces
+	// See https://docs.datadoghq.com/tracing/connect_logs_and_traces/opentelemetry/
+	logTraceID string = "dd.trace_id"
+	logSpanID  string = "dd.span_id"
+
+	// AttributeDatadogLogSource is the resource attribute overriding the source of the logs
+	AttributeDatadogLogSource string = "datadog.log.source"
+)
+
+// datadogLog is an entry of the Datadog log intake payload
+type datadogLog map[string]interface{}
+
+// logRef references a log record of pdata.Logs
+type logRef struct {
+	resource, library, record int
+}
+
+// convertToDatadogLogs converts logs into Datadog log intake entries, in the order of the
+// log records. The records they were converted from are referenced by refs.
+func convertToDatadogLogs(ld pdata.Logs, logger *zap.Logger, cfg *config.Config) (logs []datadogLog, refs []logRef) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceLog := resourceToDatadogLog(rl.Resource(), logger, cfg)
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			records := ills.At(j).Logs()
+			for k := 0; k < records.Len(); k++ {
+				logs = append(logs, logRecordToDatadogLog(records.At(k), resourceLog))
+				refs = append(refs, logRef{i, j, k})
+			}
+		}
+	}
+	return logs, refs
+}
+
+// resourceToDatadogLog returns the reserved attributes of the logs of the resource
+func resourceToDatadogLog(resource pdata.Resource, logger *zap.Logger, cfg *config.Config) datadogLog {
+	attrs := resource.Attributes()
+
+	hostname, ok := metadata.HostnameFromAttributes(attrs)
+	if !ok {
+		hostname = *metadata.GetHost(logger, cfg)
+	}
+
+	service := cfg.Service
+	if v, ok := attrs.Get(conventions.AttributeServiceName); ok {
+		if sn := v.StringVal(); sn != "" && sn != tracetranslator.ResourceNoServiceName {
+			service = sn
+		}
+	}
+
+	source := cfg.Logs.Source
+	if v, ok := attrs.Get(AttributeDatadogLogSource); ok && v.StringVal() != "" {
+		source = v.StringVal()
+	}
+
+	log := datadogLog{
+		logHostname: hostname,
+		logTags:     strings.Join(resourceToLogTags(attrs, cfg), ","),
+	}
+	if service != "" {
+		log[logService] = service
+	}
+	if source != "" {
+		log[logSource] = source
+	}
+	return log
+}
+
+// resourceToLogTags returns the tags of the logs of the resource. The resource
+// attributes are added as tags to the ones of the configuration, and the canonical
+// environment and version attributes override the configured ones.
+func resourceToLogTags(attrs pdata.AttributeMap, cfg *config.Config) []string {
+	tags := cfg.Tags
+	if len(tags) == 0 && cfg.EnvVarTags != "" {
+		tags = strings.Split(cfg.EnvVarTags, " ")
+	}
+	tags = append([]string{}, tags...)
+
+	env, version := cfg.Env, cfg.Version
+	var resourceTags []string
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		value := tracetranslator.AttributeValueToString(v, false)
+		switch k {
+		case conventions.AttributeDeploymentEnvironment:
+			env = value
+		case conventions.AttributeServiceVersion:
+			version = value
+		case conventions.AttributeServiceName, AttributeDatadogLogSource, metadata.AttributeDatadogHostname:
+			// Already sent as reserved attributes
+		default:
+			resourceTags = append(resourceTags, fmt.Sprintf("%s:%s", k, value))
+		}
+	})
+	sort.Strings(resourceTags)
+
+	if env != "" && env != "none" {
+		tags = append(tags, fmt.Sprintf("env:%s", env))
+	}
+	if version != "" {
+		tags = append(tags, fmt.Sprintf("%s:%s", versionTag, version))
+	}
+	return append(tags, resourceTags...)
+}
+
+// logRecordToDatadogLog converts a log record into a Datadog log entry. The attributes
+// of the record are sent as attributes of the log, the reserved attributes are taken
+// from the resource log.
+func logRecordToDatadogLog(lr pdata.LogRecord, resourceLog datadogLog) datadogLog {
+	log := make(datadogLog, lr.Attributes().Len()+len(resourceLog)+5)
+	lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		log[k] = attributeValueToLogValue(v)
+	})
+	for k, v := range resourceLog {
+		log[k] = v
+	}
+
+	log[logMessage] = tracetranslator.AttributeValueToString(lr.Body(), false)
+	log[logStatus] = severityToLogStatus(lr.SeverityNumber(), lr.SeverityText())
+	if lr.Timestamp() != 0 {
+		// The intake expects milliseconds since the epoch
+		log[logTimestamp] = int64(lr.Timestamp()) / 1e6
+	}
+	if !lr.TraceID().IsEmpty() {
+		log[logTraceID] = strconv.FormatUint(decodeAPMTraceID(lr.TraceID().Bytes()), 10)
+	}
+	if !lr.SpanID().IsEmpty() {
+		log[logSpanID] = strconv.FormatUint(decodeAPMSpanID(lr.SpanID().Bytes()), 10)
+	}
+	return log
+}
+
+// attributeValueToLogValue converts an attribute value into a JSON value,
+// maps and arrays are serialized into strings.
+func attributeValueToLogValue(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	case pdata.AttributeValueNULL:
+		return nil
+	}
+	return tracetranslator.AttributeValueToString(v, false)
+}
+
+// severityToLogStatus maps the severity of a log record to a Datadog log status
+func severityToLogStatus(number pdata.SeverityNumber, text string) string {
+	switch {
+	case number >= pdata.SeverityNumberFATAL:
+		return "critical"
+	case number >= pdata.SeverityNumberERROR:
+		return "error"
+	case number >= pdata.SeverityNumberWARN:
+		return "warning"
+	case number >= pdata.SeverityNumberINFO:
+		return "info"
+	case number >= pdata.SeverityNumberTRACE:
+		return "debug"
+	}
+
+	// The intake parses the usual severity names when the number is not set
+	if text != "" {
+		return strings.ToLower(text)
+	}
+	return "info"
+}
+
+// logsSubset returns the referenced log records of ld, with their resource
+// and instrumentation library.
+func logsSubset(ld pdata.Logs, refs []logRef) pdata.Logs {
+	subset := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+
+	lastResource, lastLibrary := -1, -1
+	var ills pdata.InstrumentationLibraryLogsSlice
+	var records pdata.LogSlice
+	for _, ref := range refs {
+		rl := rls.At(ref.resource)
+		if ref.resource != lastResource {
+			subset.ResourceLogs().Resize(subset.ResourceLogs().Len() + 1)
+			subsetRl := subset.ResourceLogs().At(subset.ResourceLogs().Len() - 1)
+			rl.Resource().CopyTo(subsetRl.Resource())
+			ills = subsetRl.InstrumentationLibraryLogs()
+			lastResource, lastLibrary = ref.resource, -1
+		}
+
+		ill := rl.InstrumentationLibraryLogs().At(ref.library)
+		if ref.library != lastLibrary {
+			ills.Resize(ills.Len() + 1)
+			subsetIll := ills.At(ills.Len() - 1)
+			ill.InstrumentationLibrary().CopyTo(subsetIll.InstrumentationLibrary())
+			records = subsetIll.Logs()
+			lastLibrary = ref.library
+		}
+
+		records.Resize(records.Len() + 1)
+		ill.Logs().At(ref.record).CopyTo(records.At(records.Len() - 1))
+	}
+	return subset
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+)
+
+func testLogsConfig() *config.Config {
+	return &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "test-env",
+			Service: "test-service",
+			Version: "1.0",
+			Tags:    []string{"key:value"},
+		},
+		Logs: config.LogsConfig{
+			Source: "otel",
+		},
+	}
+}
+
+// newTestLogs returns logs with a resource per element of resourceAttrs,
+// each with count records.
+func newTestLogs(count int, resourceAttrs ...map[string]pdata.AttributeValue) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(len(resourceAttrs))
+	for i, attrs := range resourceAttrs {
+		rl := ld.ResourceLogs().At(i)
+		rl.Resource().Attributes().InitFromMap(attrs)
+		rl.InstrumentationLibraryLogs().Resize(1)
+		rl.InstrumentationLibraryLogs().At(0).InstrumentationLibrary().SetName("test-library")
+		records := rl.InstrumentationLibraryLogs().At(0).Logs()
+		records.Resize(count)
+		for j := 0; j < count; j++ {
+			records.At(j).Body().SetStringVal("log message")
+		}
+	}
+	return ld
+}
+
+func TestConvertToDatadogLogs(t *testing.T) {
+	ld := newTestLogs(1, map[string]pdata.AttributeValue{
+		metadata.AttributeDatadogHostname:          pdata.NewAttributeValueString("test-host"),
+		conventions.AttributeServiceName:           pdata.NewAttributeValueString("resource-service"),
+		conventions.AttributeServiceVersion:        pdata.NewAttributeValueString("2.0"),
+		conventions.AttributeDeploymentEnvironment: pdata.NewAttributeValueString("resource-env"),
+		AttributeDatadogLogSource:                  pdata.NewAttributeValueString("nginx"),
+		conventions.AttributeContainerName:         pdata.NewAttributeValueString("test-container"),
+		conventions.AttributeK8sNamespace:          pdata.NewAttributeValueString("test-namespace"),
+	})
+	record := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	ts := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	record.SetTimestamp(pdata.TimestampUnixNano(ts.UnixNano()))
+	record.SetSeverityNumber(pdata.SeverityNumberWARN)
+	record.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	record.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	record.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"http.status_code": pdata.NewAttributeValueInt(500),
+		"duration":         pdata.NewAttributeValueDouble(1.5),
+		"retried":          pdata.NewAttributeValueBool(true),
+		"user":             pdata.NewAttributeValueString("alice"),
+		// Reserved attributes are overridden
+		"hostname": pdata.NewAttributeValueString("other-host"),
+	})
+
+	logs, refs := convertToDatadogLogs(ld, zap.NewNop(), testLogsConfig())
+	assert.Equal(t, []logRef{{0, 0, 0}}, refs)
+	assert.Equal(t, []datadogLog{{
+		"message":          "log message",
+		"status":           "warning",
+		"timestamp":        ts.UnixNano() / 1e6,
+		"hostname":         "test-host",
+		"service":          "resource-service",
+		"ddsource":         "nginx",
+		"ddtags":           "key:value,env:resource-env,version:2.0,container.name:test-container,k8s.namespace.name:test-namespace",
+		"dd.trace_id":      "2",
+		"dd.span_id":       "3",
+		"http.status_code": int64(500),
+		"duration":         1.5,
+		"retried":          true,
+		"user":             "alice",
+	}}, logs)
+}
+
+func TestConvertToDatadogLogsDefaults(t *testing.T) {
+	ld := newTestLogs(2,
+		map[string]pdata.AttributeValue{
+			metadata.AttributeDatadogHostname: pdata.NewAttributeValueString("test-host"),
+		},
+		map[string]pdata.AttributeValue{
+			metadata.AttributeDatadogHostname: pdata.NewAttributeValueString("test-host"),
+			conventions.AttributeServiceName:  pdata.NewAttributeValueString("resource-service"),
+		},
+	)
+
+	cfg := testLogsConfig()
+	logs, refs := convertToDatadogLogs(ld, zap.NewNop(), cfg)
+	assert.Equal(t, []logRef{{0, 0, 0}, {0, 0, 1}, {1, 0, 0}, {1, 0, 1}}, refs)
+	require.Len(t, logs, 4)
+	assert.Equal(t, datadogLog{
+		"message":  "log message",
+		"status":   "info",
+		"hostname": "test-host",
+		"service":  "test-service",
+		"ddsource": "otel",
+		"ddtags":   "key:value,env:test-env,version:1.0",
+	}, logs[0])
+	assert.Equal(t, "resource-service", logs[2]["service"])
+
+	// The tags of the environment variable are used if no tags are configured,
+	// and the environment is not sent if it is not set.
+	cfg.Tags = nil
+	cfg.EnvVarTags = "a:b c:d"
+	cfg.Env = "none"
+	cfg.Version = ""
+	cfg.Service = ""
+	cfg.Logs.Source = ""
+	logs, _ = convertToDatadogLogs(ld, zap.NewNop(), cfg)
+	assert.Equal(t, datadogLog{
+		"message":  "log message",
+		"status":   "info",
+		"hostname": "test-host",
+		"ddtags":   "a:b,c:d",
+	}, logs[0])
+}
+
+func TestSeverityToLogStatus(t *testing.T) {
+	tests := []struct {
+		number pdata.SeverityNumber
+		text   string
+		status string
+	}{
+		{pdata.SeverityNumberTRACE2, "", "debug"},
+		{pdata.SeverityNumberDEBUG, "", "debug"},
+		{pdata.SeverityNumberINFO4, "", "info"},
+		{pdata.SeverityNumberWARN, "", "warning"},
+		{pdata.SeverityNumberERROR3, "", "error"},
+		{pdata.SeverityNumberFATAL, "", "critical"},
+		{pdata.SeverityNumberERROR, "Warning", "error"},
+		{pdata.SeverityNumberUNDEFINED, "Notice", "notice"},
+		{pdata.SeverityNumberUNDEFINED, "", "info"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, severityToLogStatus(tt.number, tt.text))
+	}
+}
+
+func TestLogsSubset(t *testing.T) {
+	ld := newTestLogs(3,
+		map[string]pdata.AttributeValue{"resource": pdata.NewAttributeValueString("first")},
+		map[string]pdata.AttributeValue{"resource": pdata.NewAttributeValueString("second")},
+	)
+	for i := 0; i < 2; i++ {
+		records := ld.ResourceLogs().At(i).InstrumentationLibraryLogs().At(0).Logs()
+		for j := 0; j < records.Len(); j++ {
+			records.At(j).SetName(string(rune('a' + 3*i + j)))
+		}
+	}
+
+	subset := logsSubset(ld, []logRef{{0, 0, 2}, {1, 0, 0}, {1, 0, 2}})
+	require.Equal(t, 3, subset.LogRecordCount())
+	require.Equal(t, 2, subset.ResourceLogs().Len())
+
+	first := subset.ResourceLogs().At(0)
+	assert.Equal(t, ld.ResourceLogs().At(0).Resource(), first.Resource())
+	assert.Equal(t, "test-library", first.InstrumentationLibraryLogs().At(0).InstrumentationLibrary().Name())
+	assert.Equal(t, "c", first.InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+
+	second := subset.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, second.Len())
+	assert.Equal(t, "d", second.At(0).Name())
+	assert.Equal(t, "f", second.At(1).Name())
+}
@@ -34,15 +34,18 @@ import (
 
 const (
 	keySamplingPriority string = "_sampling_priority_v1"
-	versionTag          string = "version"
-	oldILNameTag        string = "otel.instrumentation_library.name"
-	currentILNameTag    string = "otel.library.name"
-	errorCode           int32  = 1
-	okCode              int32  = 0
-	httpKind            string = "http"
-	webKind             string = "web"
-	customKind          string = "custom"
-	grpcPath            string = "grpc.path"
+	// keySamplingRate is the sampling rate the span was kept with, it is used to weight the span
+	// in the APM stats
+	keySamplingRate  string = "_sample_rate"
+	versionTag       string = "version"
+	oldILNameTag     string = "otel.instrumentation_library.name"
+	currentILNameTag string = "otel.library.name"
+	errorCode        int32  = 1
+	okCode           int32  = 0
+	httpKind         string = "http"
+	webKind          string = "web"
+	customKind       string = "custom"
+	grpcPath         string = "grpc.path"
 	// tagContainersTags specifies the name of the tag which holds key/value
 	// pairs representing information about the container (Docker, EC2, etc).
 	tagContainersTags = "_dd.tags.container"
@@ -355,6 +358,12 @@ func setStringTag(s *pb.Span, key, v string) {
 		} else {
 			setMetric(s, ext.EventSampleRate, 0)
 		}
+	case keySamplingRate:
+		if rate, err := strconv.ParseFloat(v, 64); err == nil {
+			setMetric(s, keySamplingRate, rate)
+		} else {
+			s.Meta[key] = v
+		}
 	default:
 		s.Meta[key] = v
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
 	"github.com/DataDog/datadog-agent/pkg/trace/exportable/stats"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
@@ -890,6 +891,22 @@ func TestTracePayloadAggr(t *testing.T) {
 	assert.Equal(t, 2, len(updatedPayloadsDifferentEnv))
 }
 
+// ensure that the sampling rate is set as a metric so that it weights the span in the stats
+func TestSamplingRateTag(t *testing.T) {
+	span := &pb.Span{Meta: map[string]string{}, Metrics: map[string]float64{}}
+
+	setStringTag(span, keySamplingRate, "0.25")
+	assert.Equal(t, 0.25, span.Metrics[keySamplingRate])
+	assert.NotContains(t, span.Meta, keySamplingRate)
+	assert.Equal(t, 4.0, stats.Weight(span))
+
+	span = &pb.Span{Meta: map[string]string{}, Metrics: map[string]float64{}}
+	setStringTag(span, keySamplingRate, "invalid")
+	assert.Equal(t, "invalid", span.Meta[keySamplingRate])
+	assert.NotContains(t, span.Metrics, keySamplingRate)
+	assert.Equal(t, 1.0, stats.Weight(span))
+}
+
 // ensure that stats payloads get tagged with version tag
 func TestStatsAggregations(t *testing.T) {
 	hostname := "testhostname"
@@ -909,12 +926,15 @@ func TestStatsAggregations(t *testing.T) {
 
 	datadogPayload := resourceSpansToDatadogSpans(rs, calculator, hostname, &cfg)
 
-	statsOutput := computeAPMStats(&datadogPayload, calculator, time.Now().UTC().UnixNano())
+	concentrator := newStatsConcentrator(time.Now().UTC().UnixNano())
+	concentrator.add(&datadogPayload, calculator)
+	statsOutput := concentrator.flush(time.Now().UTC().UnixNano(), true)
+	require.Len(t, statsOutput, 1)
 
 	var statsVersionTag stats.Tag
 
 	// extract the first stats.TagSet containing a stats.Tag of "version"
-	for _, countVal := range statsOutput.Stats[0].Counts {
+	for _, countVal := range statsOutput[0].Stats[0].Counts {
 		for _, tagVal := range countVal.TagSet {
 			if tagVal.Name == versionAggregationTag {
 				statsVersionTag = tagVal
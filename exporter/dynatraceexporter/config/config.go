@@ -41,6 +41,10 @@ type Config struct {
 
 	// String to prefix all metric names
 	Prefix string `mapstructure:"prefix"`
+
+	// DeltaTTL defines the time in seconds that previous points of a cumulative
+	// monotonic sum are kept in memory to compute the deltas sent to Dynatrace.
+	DeltaTTL int64 `mapstructure:"delta_ttl"`
 }
 
 // Sanitize ensures an API token has been provided
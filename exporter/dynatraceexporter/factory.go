@@ -55,7 +55,8 @@ func createDefaultConfig() configmodels.Exporter {
 		APIToken:           "",
 		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ""},
 
-		Tags: []string{},
+		Tags:     []string{},
+		DeltaTTL: 3600,
 	}
 }
 
@@ -49,7 +49,8 @@ func TestCreateDefaultConfig(t *testing.T) {
 			Enabled: false,
 		},
 
-		Tags: []string{},
+		Tags:     []string{},
+		DeltaTTL: 3600,
 	}, cfg, "failed to create default config")
 
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
@@ -92,6 +93,8 @@ func TestLoadConfig(t *testing.T) {
 		Prefix: "myprefix",
 
 		Tags: []string{"example=tag"},
+
+		DeltaTTL: 600,
 	}, apiConfig)
 
 	invalidConfig2 := cfg.Exporters["dynatrace/invalid"].(*config.Config)
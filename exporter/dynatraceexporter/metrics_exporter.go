@@ -38,10 +38,19 @@ func newMetricsExporter(params component.ExporterCreateParams, cfg *config.Confi
 	if err != nil {
 		return nil, err
 	}
+
+	var sweepInterval int64 = 1
+	if cfg.DeltaTTL > 1 {
+		sweepInterval = cfg.DeltaTTL / 2
+	}
+	counters := serialization.NewCounterCache(sweepInterval, cfg.DeltaTTL)
+	counters.Start()
+
 	return &exporter{
-		logger: params.Logger,
-		cfg:    cfg,
-		client: client,
+		logger:   params.Logger,
+		cfg:      cfg,
+		client:   client,
+		counters: counters,
 	}, nil
 }
 
@@ -51,6 +60,8 @@ type exporter struct {
 	cfg        *config.Config
 	client     *http.Client
 	isDisabled bool
+	// counters holds the last point of each cumulative monotonic series
+	counters *serialization.CounterCache
 }
 
 const (
@@ -65,9 +76,11 @@ func (e *exporter) PushMetricsData(ctx context.Context, md pdata.Metrics) (int,
 	lines, dropped := e.serializeMetrics(md)
 
 	// If request is empty string, there are no serializable metrics in the batch.
-	// This can happen if all metric names are invalid
+	// This can happen if all metrics are invalid, or if the batch only has
+	// the first points of cumulative counters, which are not dropped as they
+	// are used to compute the deltas of the next points.
 	if len(lines) == 0 {
-		return md.MetricCount(), nil
+		return dropped, nil
 	}
 
 	droppedByCluster, err := e.send(ctx, lines)
@@ -104,19 +117,32 @@ func (e *exporter) serializeMetrics(md pdata.Metrics) ([]string, int) {
 
 				switch metric.DataType() {
 				case pdata.MetricDataTypeNone:
+					dropped++
 					continue
 				case pdata.MetricDataTypeIntGauge:
-					lines = append(lines, serialization.SerializeIntDataPoints(name, metric.IntGauge().DataPoints(), e.cfg.Tags))
+					lines = append(lines, serialization.SerializeIntDataPoints(name, metric.IntGauge().DataPoints(), e.cfg.Tags)...)
 				case pdata.MetricDataTypeDoubleGauge:
-					lines = append(lines, serialization.SerializeDoubleDataPoints(name, metric.DoubleGauge().DataPoints(), e.cfg.Tags))
+					lines = append(lines, serialization.SerializeDoubleDataPoints(name, metric.DoubleGauge().DataPoints(), e.cfg.Tags)...)
 				case pdata.MetricDataTypeIntSum:
-					lines = append(lines, serialization.SerializeIntDataPoints(name, metric.IntSum().DataPoints(), e.cfg.Tags))
+					sum := metric.IntSum()
+					if sum.IsMonotonic() {
+						lines = append(lines, serialization.SerializeIntCounterDataPoints(name, sum.DataPoints(), e.cfg.Tags, sum.AggregationTemporality(), resourceMetric.Resource(), e.counters)...)
+					} else {
+						lines = append(lines, serialization.SerializeIntDataPoints(name, sum.DataPoints(), e.cfg.Tags)...)
+					}
 				case pdata.MetricDataTypeDoubleSum:
-					lines = append(lines, serialization.SerializeDoubleDataPoints(name, metric.DoubleSum().DataPoints(), e.cfg.Tags))
+					sum := metric.DoubleSum()
+					if sum.IsMonotonic() {
+						lines = append(lines, serialization.SerializeDoubleCounterDataPoints(name, sum.DataPoints(), e.cfg.Tags, sum.AggregationTemporality(), resourceMetric.Resource(), e.counters)...)
+					} else {
+						lines = append(lines, serialization.SerializeDoubleDataPoints(name, sum.DataPoints(), e.cfg.Tags)...)
+					}
 				case pdata.MetricDataTypeIntHistogram:
-					lines = append(lines, serialization.SerializeIntHistogramMetrics(name, metric.IntHistogram().DataPoints(), e.cfg.Tags))
+					lines = append(lines, serialization.SerializeIntHistogramMetrics(name, metric.IntHistogram().DataPoints(), e.cfg.Tags)...)
 				case pdata.MetricDataTypeDoubleHistogram:
-					lines = append(lines, serialization.SerializeDoubleHistogramMetrics(name, metric.DoubleHistogram().DataPoints(), e.cfg.Tags))
+					lines = append(lines, serialization.SerializeDoubleHistogramMetrics(name, metric.DoubleHistogram().DataPoints(), e.cfg.Tags)...)
+				case pdata.MetricDataTypeDoubleSummary:
+					lines = append(lines, serialization.SerializeDoubleSummaryMetrics(name, metric.DoubleSummary().DataPoints(), e.cfg.Tags)...)
 				}
 			}
 		}
@@ -164,12 +190,12 @@ func (e *exporter) send(ctx context.Context, lines []string) (int, error) {
 		}
 
 		e.logger.Debug(fmt.Sprintf("Accepted %d lines", responseBody.Ok))
-		e.logger.Error(fmt.Sprintf("Rejected %d lines", responseBody.Invalid))
 
 		if responseBody.Error.Message != "" {
 			e.logger.Error(fmt.Sprintf("Error from Dynatrace: %s", responseBody.Error.Message))
 		}
 
+		rejected, reasons := rejectedLines(lines, responseBody.Error.InvalidLines)
 		for _, line := range responseBody.Error.InvalidLines {
 			// Enabled debug logging to see which lines were dropped
 			if line.Line >= 0 && line.Line < len(lines) {
@@ -177,7 +203,13 @@ func (e *exporter) send(ctx context.Context, lines []string) (int, error) {
 			}
 		}
 
-		return responseBody.Invalid, nil
+		if rejected == 0 {
+			// Dynatrace did not report which lines were rejected
+			rejected = responseBody.Invalid
+		}
+		e.logger.Error(fmt.Sprintf("Rejected %d lines", rejected), zap.Any("reasons", reasons))
+
+		return rejected, nil
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
@@ -195,6 +227,21 @@ func (e *exporter) send(ctx context.Context, lines []string) (int, error) {
 	return 0, nil
 }
 
+// rejectedLines returns the number of distinct sent lines reported as invalid
+// and how many of them were rejected for each reason.
+func rejectedLines(lines []string, invalidLines []metricsResponseErrorInvalidLine) (int, map[string]int) {
+	seen := make(map[int]bool, len(invalidLines))
+	reasons := make(map[string]int)
+	for _, line := range invalidLines {
+		if line.Line < 0 || line.Line >= len(lines) || seen[line.Line] {
+			continue
+		}
+		seen[line.Line] = true
+		reasons[line.Error]++
+	}
+	return len(seen), reasons
+}
+
 // normalizeMetricName formats the custom namespace and view name to
 // Metric naming Conventions
 func normalizeMetricName(prefix, name string) (string, error) {
@@ -28,6 +28,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/serialization"
 )
 
 func Test_exporter_PushMetricsData(t *testing.T) {
@@ -156,8 +157,9 @@ func Test_exporter_PushMetricsData(t *testing.T) {
 			ctx: context.Background(),
 			md:  md,
 		},
-		wantErr:               false,
-		wantDroppedTimeSeries: 1,
+		wantErr: false,
+		// the metric with an invalid name and the metric without data type
+		wantDroppedTimeSeries: 2,
 	}
 
 	t.Run(test.name, func(t *testing.T) {
@@ -296,6 +298,104 @@ func Test_exporter_send_BadRequest(t *testing.T) {
 	}
 }
 
+func Test_exporter_send_BadRequest_InvalidLines(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		body, _ := json.Marshal(metricsResponse{
+			Ok:      1,
+			Invalid: 3,
+			Error: metricsResponseError{
+				Code:    "400",
+				Message: "3 invalid lines",
+				InvalidLines: []metricsResponseErrorInvalidLine{
+					{Line: 0, Error: "invalid value"},
+					{Line: 2, Error: "invalid value"},
+					{Line: 2, Error: "invalid dimension"},
+					{Line: 7, Error: "out of range"},
+				},
+			},
+		})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	e := &exporter{
+		logger: zap.NewNop(),
+		cfg: &config.Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ts.URL},
+		},
+		client: ts.Client(),
+	}
+	invalid, err := e.send(context.Background(), []string{"a 1", "b 2", "c x"})
+	if invalid != 2 {
+		t.Errorf("Expected 2 distinct lines to be reported invalid, got %d", invalid)
+		return
+	}
+	if err != nil {
+		t.Errorf("Expected no error %v", err)
+		return
+	}
+}
+
+func Test_exporter_PushMetricsData_MonotonicSum(t *testing.T) {
+	sent := "not sent"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		sent = string(bodyBytes)
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	e := &exporter{
+		logger: zap.NewNop(),
+		cfg: &config.Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ts.URL},
+			Prefix:             "prefix",
+			Tags:               []string{},
+		},
+		client:   ts.Client(),
+		counters: serialization.NewCounterCache(1, 10),
+	}
+
+	newMetrics := func(value int64, ts uint64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(1)
+		ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+		ilms.Resize(1)
+		metrics := ilms.At(0).Metrics()
+		metrics.Resize(1)
+		metric := metrics.At(0)
+		metric.SetDataType(pdata.MetricDataTypeIntSum)
+		metric.SetName("counter")
+		sum := metric.IntSum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.DataPoints().Resize(1)
+		sum.DataPoints().At(0).SetValue(value)
+		sum.DataPoints().At(0).SetTimestamp(pdata.TimestampUnixNano(ts))
+		return md
+	}
+
+	// The first cumulative point only primes the cache, so nothing is sent.
+	dropped, err := e.PushMetricsData(context.Background(), newMetrics(10, 100_000_000))
+	if err != nil {
+		t.Fatalf("exporter.PushMetricsData() error = %v", err)
+	}
+	if dropped != 0 {
+		t.Errorf("exporter.PushMetricsData() dropped = %d, want 0", dropped)
+	}
+	if sent != "not sent" {
+		t.Errorf("exporter.PushMetricsData():ResponseBody = %v, want nothing sent", sent)
+	}
+
+	if _, err := e.PushMetricsData(context.Background(), newMetrics(15, 200_000_000)); err != nil {
+		t.Fatalf("exporter.PushMetricsData() error = %v", err)
+	}
+	if wantBody := "prefix.counter count,delta=5 200"; sent != wantBody {
+		t.Errorf("exporter.PushMetricsData():ResponseBody = %v, want %v", sent, wantBody)
+	}
+}
+
 func Test_exporter_send_Unauthorized(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serialization
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
+)
+
+// CounterCache holds the previous point of each cumulative counter series, so that
+// their points can be converted to deltas. It is safe for concurrent use.
+type CounterCache struct {
+	mutex sync.Mutex
+	prev  *ttlmap.TTLMap
+}
+
+type intCounterPoint struct {
+	startTime pdata.TimestampUnixNano
+	value     int64
+}
+
+type doubleCounterPoint struct {
+	startTime pdata.TimestampUnixNano
+	value     float64
+}
+
+// NewCounterCache creates a cache whose series expire after maxAgeSeconds without points.
+// Call Start() on the returned cache to begin the periodic sweeps of expired series.
+func NewCounterCache(sweepIntervalSeconds int64, maxAgeSeconds int64) *CounterCache {
+	return &CounterCache{prev: ttlmap.New(sweepIntervalSeconds, maxAgeSeconds)}
+}
+
+// Start starts the periodic sweeps of expired series.
+func (c *CounterCache) Start() {
+	c.prev.Start()
+}
+
+// intDelta records the point of the series and returns its delta from the previous point.
+// It returns false for the first point of a series, which has nothing to be compared with.
+func (c *CounterCache) intDelta(key string, startTime pdata.TimestampUnixNano, value int64) (int64, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	last, ok := c.prev.Get(key).(intCounterPoint)
+	c.prev.Put(key, intCounterPoint{startTime: startTime, value: value})
+	if !ok {
+		return 0, false
+	}
+	if startTime != last.startTime || value < last.value {
+		// the counter was reset since the last point
+		return value, true
+	}
+	return value - last.value, true
+}
+
+// doubleDelta records the point of the series and returns its delta from the previous point.
+// It returns false for the first point of a series, which has nothing to be compared with.
+func (c *CounterCache) doubleDelta(key string, startTime pdata.TimestampUnixNano, value float64) (float64, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	last, ok := c.prev.Get(key).(doubleCounterPoint)
+	c.prev.Put(key, doubleCounterPoint{startTime: startTime, value: value})
+	if !ok {
+		return 0, false
+	}
+	if startTime != last.startTime || value < last.value {
+		// the counter was reset since the last point
+		return value, true
+	}
+	return value - last.value, true
+}
+
+// seriesKey returns the key of the previous point of a cumulative series.
+// The parts are quoted so that different series never share a key.
+func seriesKey(resource pdata.Resource, name string, labels pdata.StringMap) string {
+	attrs := make([]string, 0, resource.Attributes().Len())
+	resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		attrs = append(attrs, strconv.Quote(k)+"="+strconv.Quote(tracetranslator.AttributeValueToString(v, false)))
+	})
+	sort.Strings(attrs)
+
+	pairs := make([]string, 0, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		pairs = append(pairs, strconv.Quote(k)+"="+strconv.Quote(v))
+	})
+	sort.Strings(pairs)
+	return strings.Join(attrs, ",") + " " + strconv.Quote(name) + " " + strings.Join(pairs, ",")
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serialization
+
+import (
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newResource(attrs map[string]string) pdata.Resource {
+	resource := pdata.NewResource()
+	for k, v := range attrs {
+		resource.Attributes().InsertString(k, v)
+	}
+	return resource
+}
+
+func TestCounterCache(t *testing.T) {
+	counters := NewCounterCache(1, 10)
+	points := []struct {
+		startTime pdata.TimestampUnixNano
+		value     int64
+		want      int64
+		wantOk    bool
+	}{
+		{startTime: 1, value: 10, wantOk: false},
+		{startTime: 1, value: 15, want: 5, wantOk: true},
+		// the counter was reset and reached a higher value since the last point
+		{startTime: 2, value: 20, want: 20, wantOk: true},
+		{startTime: 2, value: 3, want: 3, wantOk: true},
+	}
+	for i, p := range points {
+		if got, ok := counters.intDelta("key", p.startTime, p.value); got != p.want || ok != p.wantOk {
+			t.Errorf("point %d: intDelta() = %d, %t, want %d, %t", i, got, ok, p.want, p.wantOk)
+		}
+	}
+}
+
+func TestSerializeCounterDataPointsByResource(t *testing.T) {
+	counters := NewCounterCache(1, 10)
+	serialize := func(resource pdata.Resource, value float64) []string {
+		data := pdata.NewDoubleDataPointSlice()
+		data.Resize(1)
+		data.At(0).SetStartTime(pdata.TimestampUnixNano(100_000_000))
+		data.At(0).SetTimestamp(pdata.TimestampUnixNano(200_000_000))
+		data.At(0).SetValue(value)
+		return SerializeDoubleCounterDataPoints("counter", data, []string{}, pdata.AggregationTemporalityCumulative, resource, counters)
+	}
+	host1 := newResource(map[string]string{"host.name": "host1"})
+	host2 := newResource(map[string]string{"host.name": "host2"})
+
+	serialize(host1, 10)
+	serialize(host2, 100)
+	if got, want := serialize(host1, 12), []string{"counter count,delta=2 200"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SerializeDoubleCounterDataPoints() = %#v, want %#v", got, want)
+	}
+	if got, want := serialize(host2, 101), []string{"counter count,delta=1 200"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SerializeDoubleCounterDataPoints() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_seriesKey(t *testing.T) {
+	labels := func(kv ...string) pdata.StringMap {
+		m := pdata.NewStringMap()
+		for i := 0; i < len(kv); i += 2 {
+			m.Insert(kv[i], kv[i+1])
+		}
+		return m
+	}
+	empty := pdata.NewResource()
+
+	if a, b := seriesKey(empty, "counter", labels("a", "1", "b", "2")), seriesKey(empty, "counter", labels("b", "2", "a", "1")); a != b {
+		t.Errorf("seriesKey() = %q and %q, want the same key regardless of the label order", a, b)
+	}
+	distinct := []string{
+		seriesKey(empty, "counter", labels("a", "1", "b", "2")),
+		seriesKey(empty, "counter", labels("a", `1","b"="2`)),
+		seriesKey(empty, "counter", labels("a", "1,b=2")),
+		seriesKey(empty, "counter a", labels()),
+		seriesKey(empty, "counter", labels("a", "")),
+		seriesKey(newResource(map[string]string{"a": "1"}), "counter", labels()),
+		seriesKey(newResource(map[string]string{"a": "1", "b": "2"}), "counter", labels()),
+		seriesKey(newResource(map[string]string{"a": `1","b"="2`}), "counter", labels()),
+	}
+	for i := range distinct {
+		for j := i + 1; j < len(distinct); j++ {
+			if distinct[i] == distinct[j] {
+				t.Errorf("seriesKey() = %q for two different series", distinct[i])
+			}
+		}
+	}
+}
@@ -16,6 +16,7 @@ package serialization
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -31,80 +32,208 @@ const (
 	maxDimKeyLen = 100
 )
 
-// SerializeIntDataPoints serializes a slice of integer datapoints to a Dynatrace gauge.
-func SerializeIntDataPoints(name string, data pdata.IntDataPointSlice, tags []string) string {
+// SerializeIntDataPoints serializes a slice of integer datapoints to Dynatrace gauge lines.
+func SerializeIntDataPoints(name string, data pdata.IntDataPointSlice, tags []string) []string {
 	// {name} {value} {timestamp}
-	output := ""
+	var output []string
 	for i := 0; i < data.Len(); i++ {
 		p := data.At(i)
 		tagline := serializeTags(p.LabelsMap(), tags)
 		valueLine := strconv.FormatInt(p.Value(), 10)
 
-		output += serializeLine(name, tagline, valueLine, p.Timestamp())
+		output = append(output, serializeLine(name, tagline, valueLine, p.Timestamp()))
 	}
 
 	return output
 }
 
-// SerializeDoubleDataPoints serializes a slice of double datapoints to a Dynatrace gauge.
-func SerializeDoubleDataPoints(name string, data pdata.DoubleDataPointSlice, tags []string) string {
+// SerializeDoubleDataPoints serializes a slice of double datapoints to Dynatrace gauge lines.
+func SerializeDoubleDataPoints(name string, data pdata.DoubleDataPointSlice, tags []string) []string {
 	// {name} {value} {timestamp}
-	output := ""
+	var output []string
 	for i := 0; i < data.Len(); i++ {
 		p := data.At(i)
-		output += serializeLine(name, serializeTags(p.LabelsMap(), tags), serializeFloat64(p.Value()), p.Timestamp())
+		output = append(output, serializeLine(name, serializeTags(p.LabelsMap(), tags), serializeFloat64(p.Value()), p.Timestamp()))
 	}
 
 	return output
 }
 
-// SerializeDoubleHistogramMetrics serializes a slice of double histogram datapoints to a Dynatrace gauge.
+// SerializeIntCounterDataPoints serializes a slice of monotonic integer sum datapoints to Dynatrace count lines.
 //
-// IMPORTANT: Min and max are required by Dynatrace but not provided by histogram so they are assumed to be the average.
-func SerializeDoubleHistogramMetrics(name string, data pdata.DoubleHistogramDataPointSlice, tags []string) string {
+// Dynatrace only accepts deltas, so cumulative points are converted using the previous point of their series
+// from the resource, stored in counters. The first cumulative point of a series only initializes the cache and
+// is not serialized, and a point whose start time changed is a reset of the counter.
+func SerializeIntCounterDataPoints(name string, data pdata.IntDataPointSlice, tags []string, temporality pdata.AggregationTemporality, resource pdata.Resource, counters *CounterCache) []string {
+	// {name} count,delta={value} {timestamp}
+	var output []string
+	for i := 0; i < data.Len(); i++ {
+		p := data.At(i)
+		tagline := serializeTags(p.LabelsMap(), tags)
+		delta := p.Value()
+
+		if temporality != pdata.AggregationTemporalityDelta {
+			var ok bool
+			delta, ok = counters.intDelta(seriesKey(resource, name, p.LabelsMap()), p.StartTime(), p.Value())
+			if !ok {
+				continue
+			}
+		}
+
+		output = append(output, serializeLine(name, tagline, "count,delta="+strconv.FormatInt(delta, 10), p.Timestamp()))
+	}
+
+	return output
+}
+
+// SerializeDoubleCounterDataPoints serializes a slice of monotonic double sum datapoints to Dynatrace count lines.
+//
+// Dynatrace only accepts deltas, so cumulative points are converted using the previous point of their series
+// from the resource, stored in counters. The first cumulative point of a series only initializes the cache and
+// is not serialized, and a point whose start time changed is a reset of the counter.
+func SerializeDoubleCounterDataPoints(name string, data pdata.DoubleDataPointSlice, tags []string, temporality pdata.AggregationTemporality, resource pdata.Resource, counters *CounterCache) []string {
+	// {name} count,delta={value} {timestamp}
+	var output []string
+	for i := 0; i < data.Len(); i++ {
+		p := data.At(i)
+		tagline := serializeTags(p.LabelsMap(), tags)
+		delta := p.Value()
+
+		if temporality != pdata.AggregationTemporalityDelta {
+			var ok bool
+			delta, ok = counters.doubleDelta(seriesKey(resource, name, p.LabelsMap()), p.StartTime(), p.Value())
+			if !ok {
+				continue
+			}
+		}
+
+		output = append(output, serializeLine(name, tagline, "count,delta="+serializeFloat64(delta), p.Timestamp()))
+	}
+
+	return output
+}
+
+// SerializeDoubleHistogramMetrics serializes a slice of double histogram datapoints to Dynatrace gauge lines.
+//
+// IMPORTANT: Min and max are required by Dynatrace but not provided by histogram so they are estimated from the
+// bounds of the populated buckets.
+func SerializeDoubleHistogramMetrics(name string, data pdata.DoubleHistogramDataPointSlice, tags []string) []string {
 	// {name} gauge,min=9.75,max=9.75,sum=19.5,count=2 {timestamp_unix_ms}
-	output := ""
+	var output []string
 	for i := 0; i < data.Len(); i++ {
 		p := data.At(i)
 		tagline := serializeTags(p.LabelsMap(), tags)
 		if p.Count() == 0 {
-			return ""
+			continue
 		}
 		avg := p.Sum() / float64(p.Count())
+		min, max := estimateHistMinMax(p.ExplicitBounds(), p.BucketCounts(), avg)
 
-		valueLine := fmt.Sprintf("gauge,min=%[1]s,max=%[1]s,sum=%s,count=%d", serializeFloat64(avg), serializeFloat64(p.Sum()), p.Count())
+		valueLine := fmt.Sprintf("gauge,min=%s,max=%s,sum=%s,count=%d", serializeFloat64(min), serializeFloat64(max), serializeFloat64(p.Sum()), p.Count())
 
-		output += serializeLine(name, tagline, valueLine, p.Timestamp())
+		output = append(output, serializeLine(name, tagline, valueLine, p.Timestamp()))
 	}
 
 	return output
 }
 
-// SerializeIntHistogramMetrics serializes a slice of integer histogram datapoints to a Dynatrace gauge.
+// SerializeIntHistogramMetrics serializes a slice of integer histogram datapoints to Dynatrace gauge lines.
 //
-// IMPORTANT: Min and max are required by Dynatrace but not provided by histogram so they are assumed to be the average.
-func SerializeIntHistogramMetrics(name string, data pdata.IntHistogramDataPointSlice, tags []string) string {
+// IMPORTANT: Min and max are required by Dynatrace but not provided by histogram so they are estimated from the
+// bounds of the populated buckets.
+func SerializeIntHistogramMetrics(name string, data pdata.IntHistogramDataPointSlice, tags []string) []string {
 	// {name} gauge,min=9.5,max=9.5,sum=19,count=2 {timestamp_unix_ms}
-	output := ""
+	var output []string
 	for i := 0; i < data.Len(); i++ {
 		p := data.At(i)
 		tagline := serializeTags(p.LabelsMap(), tags)
 		count := p.Count()
 
 		if count == 0 {
-			return ""
+			continue
 		}
 
 		avg := float64(p.Sum()) / float64(count)
+		min, max := estimateHistMinMax(p.ExplicitBounds(), p.BucketCounts(), avg)
+
+		valueLine := fmt.Sprintf("gauge,min=%s,max=%s,sum=%d,count=%d", serializeFloat64(min), serializeFloat64(max), p.Sum(), count)
+
+		output = append(output, serializeLine(name, tagline, valueLine, p.Timestamp()))
+	}
 
-		valueLine := fmt.Sprintf("gauge,min=%[1]s,max=%[1]s,sum=%d,count=%d", serializeFloat64(avg), p.Sum(), count)
+	return output
+}
 
-		output += serializeLine(name, tagline, valueLine, p.Timestamp())
+// SerializeDoubleSummaryMetrics serializes a slice of double summary datapoints to Dynatrace gauge lines.
+//
+// Min and max are taken from the 0 and 1 quantiles. If those are not present they are assumed to be the average.
+func SerializeDoubleSummaryMetrics(name string, data pdata.DoubleSummaryDataPointSlice, tags []string) []string {
+	// {name} gauge,min=1,max=18,sum=19.5,count=2 {timestamp_unix_ms}
+	var output []string
+	for i := 0; i < data.Len(); i++ {
+		p := data.At(i)
+		tagline := serializeTags(p.LabelsMap(), tags)
+		if p.Count() == 0 {
+			continue
+		}
+		avg := p.Sum() / float64(p.Count())
+		min, max := avg, avg
+
+		quantiles := p.QuantileValues()
+		for j := 0; j < quantiles.Len(); j++ {
+			q := quantiles.At(j)
+			switch q.Quantile() {
+			case 0:
+				min = q.Value()
+			case 1:
+				max = q.Value()
+			}
+		}
+
+		valueLine := fmt.Sprintf("gauge,min=%s,max=%s,sum=%s,count=%d", serializeFloat64(min), serializeFloat64(max), serializeFloat64(p.Sum()), p.Count())
+
+		output = append(output, serializeLine(name, tagline, valueLine, p.Timestamp()))
 	}
 
 	return output
 }
 
+// estimateHistMinMax estimates the min and max of a histogram from the bounds of
+// its lowest and highest populated buckets. Bucket i covers (bounds[i-1], bounds[i]],
+// so the open-ended first and last buckets only contribute their finite bound.
+// The estimates are clamped so that min <= avg <= max always holds.
+func estimateHistMinMax(bounds []float64, counts []uint64, avg float64) (float64, float64) {
+	if len(bounds) == 0 || len(counts) != len(bounds)+1 {
+		return avg, avg
+	}
+
+	first, last := -1, -1
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first < 0 {
+		return avg, avg
+	}
+
+	min := bounds[0]
+	if first > 0 {
+		min = bounds[first-1]
+	}
+
+	max := bounds[len(bounds)-1]
+	if last < len(bounds) {
+		max = bounds[last]
+	}
+
+	return math.Min(min, avg), math.Max(max, avg)
+}
+
 func serializeLine(name, tagline, valueline string, timestamp pdata.TimestampUnixNano) string {
 	// {metric_name} {tags} {value_line} {timestamp}
 	output := name
@@ -171,9 +300,10 @@ func NormalizeString(str string, max int) (normalizedString string, err error) {
 }
 
 func serializeFloat64(n float64) string {
-	str := strings.TrimRight(strconv.FormatFloat(n, 'f', 6, 64), "0.")
-	if str == "" {
-		// if everything was trimmed away, number was 0.000000
+	// trim trailing zeros of the fraction only, so that 10.000000 becomes 10 and not 1
+	str := strings.TrimRight(strings.TrimRight(strconv.FormatFloat(n, 'f', 6, 64), "0"), ".")
+	if str == "-0" {
+		// number was a tiny negative value rounded to -0.000000
 		return "0"
 	}
 	return str
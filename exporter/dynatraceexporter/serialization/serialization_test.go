@@ -15,6 +15,7 @@
 package serialization
 
 import (
+	"reflect"
 	"testing"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -43,7 +44,7 @@ func TestSerializeIntDataPoints(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want string
+		want []string
 	}{
 		{
 			name: "Serialize integer data points",
@@ -52,7 +53,7 @@ func TestSerializeIntDataPoints(t *testing.T) {
 				data: intSlice,
 				tags: []string{},
 			},
-			want: "my_int_gauge 13 100",
+			want: []string{"my_int_gauge 13 100"},
 		},
 		{
 			name: "Serialize integer data points with tags",
@@ -61,7 +62,7 @@ func TestSerializeIntDataPoints(t *testing.T) {
 				data: intSlice,
 				tags: []string{"test_key=testval"},
 			},
-			want: "my_int_gauge_with_tags,test_key=testval 13 100",
+			want: []string{"my_int_gauge_with_tags,test_key=testval 13 100"},
 		},
 		{
 			name: "Serialize integer data points with labels",
@@ -70,12 +71,12 @@ func TestSerializeIntDataPoints(t *testing.T) {
 				data: labelIntSlice,
 				tags: []string{},
 			},
-			want: "my_int_gauge_with_labels,labelkey=\"labelValue\" 13 100",
+			want: []string{"my_int_gauge_with_labels,labelkey=\"labelValue\" 13 100"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SerializeIntDataPoints(tt.args.name, tt.args.data, tt.args.tags); got != tt.want {
+			if got := SerializeIntDataPoints(tt.args.name, tt.args.data, tt.args.tags); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SerializeIntDataPoints() = %#v, want %#v", got, tt.want)
 			}
 		})
@@ -104,7 +105,7 @@ func TestSerializeDoubleDataPoints(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want string
+		want []string
 	}{
 		{
 			name: "Serialize double data points",
@@ -113,7 +114,7 @@ func TestSerializeDoubleDataPoints(t *testing.T) {
 				data: doubleSlice,
 				tags: []string{},
 			},
-			want: "my_double_gauge 13.1 100",
+			want: []string{"my_double_gauge 13.1 100"},
 		},
 		{
 			name: "Serialize double data points with tags",
@@ -122,7 +123,7 @@ func TestSerializeDoubleDataPoints(t *testing.T) {
 				data: doubleSlice,
 				tags: []string{"test_key=testval"},
 			},
-			want: "my_double_gauge_with_tags,test_key=testval 13.1 100",
+			want: []string{"my_double_gauge_with_tags,test_key=testval 13.1 100"},
 		},
 		{
 			name: "Serialize double data points with labels",
@@ -131,12 +132,12 @@ func TestSerializeDoubleDataPoints(t *testing.T) {
 				data: labelDoubleSlice,
 				tags: []string{},
 			},
-			want: "my_double_gauge_with_labels,labelkey=\"labelValue\" 13.1 100",
+			want: []string{"my_double_gauge_with_labels,labelkey=\"labelValue\" 13.1 100"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SerializeDoubleDataPoints(tt.args.name, tt.args.data, tt.args.tags); got != tt.want {
+			if got := SerializeDoubleDataPoints(tt.args.name, tt.args.data, tt.args.tags); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SerializeDoubleDataPoints() = %v, want %v", got, tt.want)
 			}
 		})
@@ -159,6 +160,15 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 	labelDoubleHistPoint.SetTimestamp(pdata.TimestampUnixNano(100_000_000))
 	labelDoubleHistPoint.LabelsMap().Insert("labelKey", "labelValue")
 
+	bucketDoubleHistSlice := pdata.NewDoubleHistogramDataPointSlice()
+	bucketDoubleHistSlice.Resize(1)
+	bucketDoubleHistPoint := bucketDoubleHistSlice.At(0)
+	bucketDoubleHistPoint.SetCount(10)
+	bucketDoubleHistPoint.SetSum(101.0)
+	bucketDoubleHistPoint.SetExplicitBounds([]float64{1, 5, 10, 20, 50})
+	bucketDoubleHistPoint.SetBucketCounts([]uint64{0, 2, 5, 3, 0, 0})
+	bucketDoubleHistPoint.SetTimestamp(pdata.TimestampUnixNano(100_000_000))
+
 	zeroDoubleHistogramSlice := pdata.NewDoubleHistogramDataPointSlice()
 	zeroDoubleHistogramSlice.Resize(1)
 	zeroDoubleHistogramDataPoint := zeroDoubleHistogramSlice.At(0)
@@ -174,7 +184,7 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want string
+		want []string
 	}{
 		{
 			name: "Serialize double histogram data points",
@@ -183,7 +193,7 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 				data: doubleHistSlice,
 				tags: []string{},
 			},
-			want: "my_double_hist gauge,min=10.1,max=10.1,sum=101,count=10 100",
+			want: []string{"my_double_hist gauge,min=10.1,max=10.1,sum=101,count=10 100"},
 		},
 		{
 			name: "Serialize double histogram data points with tags",
@@ -192,7 +202,7 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 				data: doubleHistSlice,
 				tags: []string{"test_key=testval"},
 			},
-			want: "my_double_hist_with_tags,test_key=testval gauge,min=10.1,max=10.1,sum=101,count=10 100",
+			want: []string{"my_double_hist_with_tags,test_key=testval gauge,min=10.1,max=10.1,sum=101,count=10 100"},
 		},
 		{
 			name: "Serialize double histogram data points with labels",
@@ -201,7 +211,16 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 				data: labelDoubleHistSlice,
 				tags: []string{},
 			},
-			want: "my_double_hist_with_labels,labelkey=\"labelValue\" gauge,min=10.1,max=10.1,sum=101,count=10 100",
+			want: []string{"my_double_hist_with_labels,labelkey=\"labelValue\" gauge,min=10.1,max=10.1,sum=101,count=10 100"},
+		},
+		{
+			name: "Serialize double histogram data points with buckets",
+			args: args{
+				name: "my_double_hist_with_buckets",
+				data: bucketDoubleHistSlice,
+				tags: []string{},
+			},
+			want: []string{"my_double_hist_with_buckets gauge,min=1,max=20,sum=101,count=10 100"},
 		},
 		{
 			name: "Serialize zero double histogram",
@@ -210,12 +229,12 @@ func TestSerializeDoubleHistogramMetrics(t *testing.T) {
 				data: zeroDoubleHistogramSlice,
 				tags: []string{},
 			},
-			want: "",
+			want: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SerializeDoubleHistogramMetrics(tt.args.name, tt.args.data, tt.args.tags); got != tt.want {
+			if got := SerializeDoubleHistogramMetrics(tt.args.name, tt.args.data, tt.args.tags); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SerializeDoubleHistogramMetrics() = %v, want %v", got, tt.want)
 			}
 		})
@@ -253,7 +272,7 @@ func TestSerializeIntHistogramMetrics(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want string
+		want []string
 	}{
 		{
 			name: "Serialize integer histogram data points",
@@ -262,7 +281,7 @@ func TestSerializeIntHistogramMetrics(t *testing.T) {
 				data: intHistSlice,
 				tags: []string{},
 			},
-			want: "my_int_hist gauge,min=11,max=11,sum=110,count=10 100",
+			want: []string{"my_int_hist gauge,min=11,max=11,sum=110,count=10 100"},
 		},
 		{
 			name: "Serialize integer histogram data points with tags",
@@ -271,7 +290,7 @@ func TestSerializeIntHistogramMetrics(t *testing.T) {
 				data: intHistSlice,
 				tags: []string{"test_key=testval"},
 			},
-			want: "my_int_hist_with_tags,test_key=testval gauge,min=11,max=11,sum=110,count=10 100",
+			want: []string{"my_int_hist_with_tags,test_key=testval gauge,min=11,max=11,sum=110,count=10 100"},
 		},
 		{
 			name: "Serialize integer histogram data points with labels",
@@ -280,7 +299,7 @@ func TestSerializeIntHistogramMetrics(t *testing.T) {
 				data: labelIntHistSlice,
 				tags: []string{},
 			},
-			want: "my_int_hist_with_labels,labelkey=\"labelValue\" gauge,min=11,max=11,sum=110,count=10 100",
+			want: []string{"my_int_hist_with_labels,labelkey=\"labelValue\" gauge,min=11,max=11,sum=110,count=10 100"},
 		},
 		{
 			name: "Serialize zero integer histogram",
@@ -289,18 +308,225 @@ func TestSerializeIntHistogramMetrics(t *testing.T) {
 				data: zeroIntHistogramSlice,
 				tags: []string{},
 			},
-			want: "",
+			want: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SerializeIntHistogramMetrics(tt.args.name, tt.args.data, tt.args.tags); got != tt.want {
+			if got := SerializeIntHistogramMetrics(tt.args.name, tt.args.data, tt.args.tags); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SerializeIntHistogramMetrics() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestSerializeIntCounterDataPoints(t *testing.T) {
+	intSlice := pdata.NewIntDataPointSlice()
+	intSlice.Resize(3)
+	for i, v := range []int64{5, 8, 2} {
+		p := intSlice.At(i)
+		p.SetValue(v)
+		p.SetTimestamp(pdata.TimestampUnixNano(uint64(i+1) * 100_000_000))
+		p.LabelsMap().Insert("labelKey", "labelValue")
+	}
+
+	type args struct {
+		name        string
+		data        pdata.IntDataPointSlice
+		tags        []string
+		temporality pdata.AggregationTemporality
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Serialize cumulative integer counter data points as deltas",
+			args: args{
+				name:        "my_int_counter",
+				data:        intSlice,
+				tags:        []string{},
+				temporality: pdata.AggregationTemporalityCumulative,
+			},
+			want: []string{
+				"my_int_counter,labelkey=\"labelValue\" count,delta=3 200",
+				"my_int_counter,labelkey=\"labelValue\" count,delta=2 300",
+			},
+		},
+		{
+			name: "Serialize delta integer counter data points",
+			args: args{
+				name:        "my_int_counter",
+				data:        intSlice,
+				tags:        []string{"test_key=testval"},
+				temporality: pdata.AggregationTemporalityDelta,
+			},
+			want: []string{
+				"my_int_counter,test_key=testval,labelkey=\"labelValue\" count,delta=5 100",
+				"my_int_counter,test_key=testval,labelkey=\"labelValue\" count,delta=8 200",
+				"my_int_counter,test_key=testval,labelkey=\"labelValue\" count,delta=2 300",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counters := NewCounterCache(1, 10)
+			if got := SerializeIntCounterDataPoints(tt.args.name, tt.args.data, tt.args.tags, tt.args.temporality, pdata.NewResource(), counters); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SerializeIntCounterDataPoints() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerializeDoubleCounterDataPoints(t *testing.T) {
+	counters := NewCounterCache(1, 10)
+
+	firstSlice := pdata.NewDoubleDataPointSlice()
+	firstSlice.Resize(1)
+	firstSlice.At(0).SetValue(10.5)
+	firstSlice.At(0).SetTimestamp(pdata.TimestampUnixNano(100_000_000))
+
+	if got := SerializeDoubleCounterDataPoints("my_double_counter", firstSlice, []string{}, pdata.AggregationTemporalityCumulative, pdata.NewResource(), counters); got != nil {
+		t.Errorf("SerializeDoubleCounterDataPoints() = %#v, want nil for the first cumulative point", got)
+	}
+
+	secondSlice := pdata.NewDoubleDataPointSlice()
+	secondSlice.Resize(1)
+	secondSlice.At(0).SetValue(12)
+	secondSlice.At(0).SetTimestamp(pdata.TimestampUnixNano(200_000_000))
+
+	want := []string{"my_double_counter count,delta=1.5 200"}
+	if got := SerializeDoubleCounterDataPoints("my_double_counter", secondSlice, []string{}, pdata.AggregationTemporalityCumulative, pdata.NewResource(), counters); !reflect.DeepEqual(got, want) {
+		t.Errorf("SerializeDoubleCounterDataPoints() = %#v, want %#v", got, want)
+	}
+}
+
+func TestSerializeDoubleSummaryMetrics(t *testing.T) {
+	summarySlice := pdata.NewDoubleSummaryDataPointSlice()
+	summarySlice.Resize(1)
+	summaryPoint := summarySlice.At(0)
+	summaryPoint.SetCount(10)
+	summaryPoint.SetSum(101.0)
+	summaryPoint.SetTimestamp(pdata.TimestampUnixNano(100_000_000))
+	quantiles := summaryPoint.QuantileValues()
+	quantiles.Resize(3)
+	for i, q := range [][2]float64{{0, 2.5}, {0.5, 9}, {1, 30}} {
+		quantiles.At(i).SetQuantile(q[0])
+		quantiles.At(i).SetValue(q[1])
+	}
+
+	noQuantileSummarySlice := pdata.NewDoubleSummaryDataPointSlice()
+	noQuantileSummarySlice.Resize(1)
+	noQuantileSummaryPoint := noQuantileSummarySlice.At(0)
+	noQuantileSummaryPoint.SetCount(10)
+	noQuantileSummaryPoint.SetSum(101.0)
+	noQuantileSummaryPoint.SetTimestamp(pdata.TimestampUnixNano(100_000_000))
+
+	zeroSummarySlice := pdata.NewDoubleSummaryDataPointSlice()
+	zeroSummarySlice.Resize(1)
+	zeroSummarySlice.At(0).SetTimestamp(pdata.TimestampUnixNano(100_000_000))
+
+	type args struct {
+		name string
+		data pdata.DoubleSummaryDataPointSlice
+		tags []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Serialize summary data points",
+			args: args{
+				name: "my_summary",
+				data: summarySlice,
+				tags: []string{"test_key=testval"},
+			},
+			want: []string{"my_summary,test_key=testval gauge,min=2.5,max=30,sum=101,count=10 100"},
+		},
+		{
+			name: "Serialize summary data points without min and max quantiles",
+			args: args{
+				name: "my_summary",
+				data: noQuantileSummarySlice,
+				tags: []string{},
+			},
+			want: []string{"my_summary gauge,min=10.1,max=10.1,sum=101,count=10 100"},
+		},
+		{
+			name: "Serialize zero summary",
+			args: args{
+				name: "zero_summary",
+				data: zeroSummarySlice,
+				tags: []string{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SerializeDoubleSummaryMetrics(tt.args.name, tt.args.data, tt.args.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SerializeDoubleSummaryMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_estimateHistMinMax(t *testing.T) {
+	type args struct {
+		bounds []float64
+		counts []uint64
+		avg    float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			name:    "No buckets",
+			args:    args{bounds: nil, counts: nil, avg: 4},
+			wantMin: 4,
+			wantMax: 4,
+		},
+		{
+			name:    "Inner buckets",
+			args:    args{bounds: []float64{1, 5, 10}, counts: []uint64{0, 3, 1, 0}, avg: 4},
+			wantMin: 1,
+			wantMax: 10,
+		},
+		{
+			name:    "Open-ended buckets",
+			args:    args{bounds: []float64{1, 5, 10}, counts: []uint64{2, 0, 0, 1}, avg: 4},
+			wantMin: 1,
+			wantMax: 10,
+		},
+		{
+			name:    "Clamped to average",
+			args:    args{bounds: []float64{1, 5, 10}, counts: []uint64{0, 0, 0, 4}, avg: 40},
+			wantMin: 10,
+			wantMax: 40,
+		},
+		{
+			name:    "Mismatched bucket counts",
+			args:    args{bounds: []float64{1, 5, 10}, counts: []uint64{1}, avg: 4},
+			wantMin: 4,
+			wantMax: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, gotMax := estimateHistMinMax(tt.args.bounds, tt.args.counts, tt.args.avg)
+			if gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("estimateHistMinMax() = (%v, %v), want (%v, %v)", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
 func Test_serializeLine(t *testing.T) {
 	type args struct {
 		name      string
@@ -465,6 +691,11 @@ func Test_serializeFloat64(t *testing.T) {
 			args: args{n: 1.0},
 			want: "1",
 		},
+		{
+			name: "Serialize 10.0 to 10",
+			args: args{n: 10.0},
+			want: "10",
+		},
 		{
 			name: "Serialize 1.1 to 1.1",
 			args: args{n: 1.1},
@@ -63,6 +63,22 @@ func TestCreateMetricsExporter(t *testing.T) {
 
 	params := component.ExporterCreateParams{Logger: zap.NewNop()}
 	exporter, err := factory.CreateMetricsExporter(context.Background(), params, cfg.Exporters["honeycomb/customname"])
-	assert.NotNil(t, err)
-	assert.Nil(t, exporter)
+	assert.Nil(t, err)
+	assert.NotNil(t, exporter)
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+	require.NoError(t, err)
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := factory.CreateLogsExporter(context.Background(), params, cfg.Exporters["honeycomb/customname"])
+	assert.Nil(t, err)
+	assert.NotNil(t, exporter)
 }
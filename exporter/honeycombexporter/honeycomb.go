@@ -32,9 +32,11 @@ const (
 
 // honeycombExporter is the object that sends events to honeycomb.
 type honeycombExporter struct {
+	client              *libhoney.Client
 	builder             *libhoney.Builder
 	onError             func(error)
 	logger              *zap.Logger
+	sampleRate          uint
 	sampleRateAttribute string
 }
 
@@ -68,13 +70,14 @@ type link struct {
 	AnnotationType string `json:"meta.annotation_type"`
 }
 
-// newHoneycombTraceExporter creates and returns a new honeycombExporter. It
-// wraps the exporter in the component.TraceExporterOld helper method.
-func newHoneycombTraceExporter(cfg *Config, logger *zap.Logger) (*honeycombExporter, error) {
-	libhoneyConfig := libhoney.Config{
-		WriteKey: cfg.APIKey,
-		Dataset:  cfg.Dataset,
-		APIHost:  cfg.APIURL,
+// newHoneycombExporter creates and returns a new honeycombExporter, with its
+// own libhoney client so that the exporters of each pipeline can be shut down
+// independently.
+func newHoneycombExporter(cfg *Config, logger *zap.Logger) (*honeycombExporter, error) {
+	libhoneyConfig := libhoney.ClientConfig{
+		APIKey:  cfg.APIKey,
+		Dataset: cfg.Dataset,
+		APIHost: cfg.APIURL,
 	}
 	userAgent := oTelCollectorUserAgentStr
 	libhoney.UserAgentAddition = userAgent
@@ -83,16 +86,18 @@ func newHoneycombTraceExporter(cfg *Config, logger *zap.Logger) (*honeycombExpor
 		libhoneyConfig.Logger = &libhoney.DefaultLogger{}
 	}
 
-	if err := libhoney.Init(libhoneyConfig); err != nil {
+	client, err := libhoney.NewClient(libhoneyConfig)
+	if err != nil {
 		return nil, err
 	}
-	builder := libhoney.NewBuilder()
 	exporter := &honeycombExporter{
-		builder: builder,
+		client:  client,
+		builder: client.NewBuilder(),
 		logger:  logger,
 		onError: func(err error) {
 			logger.Warn(err.Error())
 		},
+		sampleRate:          cfg.SampleRate,
 		sampleRateAttribute: cfg.SampleRateAttribute,
 	}
 
@@ -108,7 +113,7 @@ func (e *honeycombExporter) pushTraceData(ctx context.Context, td pdata.Traces)
 	// Run the error logger. This just listens for messages in the error
 	// response queue and writes them out using the logger.
 	ctx, cancel := context.WithCancel(ctx)
-	go e.RunErrorLogger(ctx, libhoney.TxResponses())
+	go e.RunErrorLogger(ctx, e.client.TxResponses())
 	defer cancel()
 
 	rs := td.ResourceSpans()
@@ -130,13 +135,7 @@ func (e *honeycombExporter) pushTraceData(ctx context.Context, td pdata.Traces)
 					ev.AddField(k, v)
 				}
 
-				lib := ilsSpan.InstrumentationLibrary()
-				if name := lib.Name(); name != "" {
-					ev.AddField("library.name", name)
-				}
-				if version := lib.Version(); version != "" {
-					ev.AddField("library.version", version)
-				}
+				addLibraryFields(ev, ilsSpan.InstrumentationLibrary())
 
 				if attrs := spanAttributesToMap(span.Attributes()); attrs != nil {
 					for k, v := range attrs {
@@ -262,10 +261,10 @@ func (e *honeycombExporter) sendMessageEvents(span pdata.Span, resourceAttrs map
 }
 
 // Shutdown takes care of any cleanup tasks that need to be carried out. In
-// this case, we close the honeycomb sdk which flushes any events still in the
+// this case, we close the honeycomb client which flushes any events still in the
 // queue and closes any open channels between queues.
 func (e *honeycombExporter) Shutdown(context.Context) error {
-	libhoney.Close()
+	e.client.Close()
 	return nil
 }
 
@@ -290,15 +289,41 @@ func (e *honeycombExporter) RunErrorLogger(ctx context.Context, responses chan t
 	}
 }
 
-func (e *honeycombExporter) addSampleRate(event *libhoney.Event, attrs map[string]interface{}) {
+// addLibraryFields adds the name and version of the instrumentation library to the event.
+func addLibraryFields(ev *libhoney.Event, lib pdata.InstrumentationLibrary) {
+	if name := lib.Name(); name != "" {
+		ev.AddField("library.name", name)
+	}
+	if version := lib.Version(); version != "" {
+		ev.AddField("library.version", version)
+	}
+}
+
+// addSampleRate sets the sample rate of the event to the value of the sample rate
+// attribute, it returns whether the attribute was found.
+func (e *honeycombExporter) addSampleRate(event *libhoney.Event, attrs map[string]interface{}) bool {
 	if e.sampleRateAttribute != "" && attrs != nil {
 		if value, ok := attrs[e.sampleRateAttribute]; ok {
 			switch v := value.(type) {
 			case int64:
 				event.SampleRate = uint(v)
+				return true
 			default:
-				return
+				return false
 			}
 		}
 	}
+	return false
+}
+
+// sendSampled sends the event as presampled if its sample rate is set by the sample
+// rate attribute of attrs, and samples it at the configured sample rate otherwise.
+func (e *honeycombExporter) sendSampled(event *libhoney.Event, attrs map[string]interface{}) error {
+	if e.addSampleRate(event, attrs) {
+		return event.SendPresampled()
+	}
+	if e.sampleRate > 1 {
+		event.SampleRate = e.sampleRate
+	}
+	return event.Send()
 }
@@ -478,6 +478,30 @@ func TestSampleRateAttribute(t *testing.T) {
 	}
 }
 
+func TestSendSampled(t *testing.T) {
+	server := testingServer(func(data []honeycombData) {})
+	defer server.Close()
+
+	cfg := baseConfig()
+	cfg.APIURL = server.URL
+	cfg.SampleRate = 5
+	cfg.SampleRateAttribute = "hc.sample.rate"
+	exporter, err := newHoneycombExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer exporter.Shutdown(context.Background())
+
+	// The sample rate attribute takes precedence over the configured sample rate
+	ev := exporter.builder.NewEvent()
+	ev.AddField("name", "presampled")
+	require.NoError(t, exporter.sendSampled(ev, map[string]interface{}{"hc.sample.rate": int64(13)}))
+	assert.Equal(t, uint(13), ev.SampleRate)
+
+	ev = exporter.builder.NewEvent()
+	ev.AddField("name", "sampled")
+	require.NoError(t, exporter.sendSampled(ev, map[string]interface{}{"hc.sample.rate": "wrong_type"}))
+	assert.Equal(t, uint(5), ev.SampleRate)
+}
+
 func TestEmptyNode(t *testing.T) {
 	td := consumerdata.TraceData{
 		Node: nil,
@@ -634,7 +658,7 @@ func TestRunErrorLogger_OnError(t *testing.T) {
 	logger := zap.New(obs)
 
 	cfg := createDefaultConfig().(*Config)
-	exporter, err := newHoneycombTraceExporter(cfg, logger)
+	exporter, err := newHoneycombExporter(cfg, logger)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -663,6 +687,6 @@ func TestRunErrorLogger_OnError(t *testing.T) {
 func TestDebugUsesDebugLogger(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.Debug = true
-	_, err := newHoneycombTraceExporter(cfg, zap.NewNop())
+	_, err := newHoneycombExporter(cfg, zap.NewNop())
 	require.NoError(t, err)
 }
@@ -0,0 +1,97 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// logEvent represents a log record sent as a honeycomb event. The span of the
+// record is its parent, so that it is shown along the span in the trace view.
+type logEvent struct {
+	Name           string `json:"name,omitempty"`
+	TraceID        string `json:"trace.trace_id,omitempty"`
+	ParentID       string `json:"trace.parent_id,omitempty"`
+	Body           string `json:"body,omitempty"`
+	SeverityText   string `json:"severity,omitempty"`
+	SeverityNumber int32  `json:"severity_number,omitempty"`
+}
+
+// pushLogsData is the method called when logs data is available. It sends
+// each log record as an event, with the attributes of its resource and its own.
+func (e *honeycombExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var errs []error
+	goodLogs := 0
+
+	ctx, cancel := context.WithCancel(ctx)
+	go e.RunErrorLogger(ctx, e.client.TxResponses())
+	defer cancel()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+
+		// Extract Resource attributes, they will be added to every log record.
+		resourceAttrs := spanAttributesToMap(rl.Resource().Attributes())
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				ev := e.builder.NewEvent()
+
+				for k, v := range resourceAttrs {
+					ev.AddField(k, v)
+				}
+
+				addLibraryFields(ev, ill.InstrumentationLibrary())
+
+				attrs := spanAttributesToMap(lr.Attributes())
+				for k, v := range attrs {
+					ev.AddField(k, v)
+				}
+
+				if lr.Timestamp() != 0 {
+					ev.Timestamp = timestampToTime(lr.Timestamp())
+				}
+				le := logEvent{
+					Name:           lr.Name(),
+					ParentID:       getHoneycombSpanID(lr.SpanID()),
+					Body:           tracetranslator.AttributeValueToString(lr.Body(), false),
+					SeverityText:   lr.SeverityText(),
+					SeverityNumber: int32(lr.SeverityNumber()),
+				}
+				if !lr.TraceID().IsEmpty() {
+					le.TraceID = getHoneycombTraceID(lr.TraceID())
+				}
+				ev.Add(le)
+
+				if err := e.sendSampled(ev, attrs); err != nil {
+					errs = append(errs, err)
+				} else {
+					goodLogs++
+				}
+			}
+		}
+	}
+
+	return ld.LogRecordCount() - goodLogs, componenterror.CombineErrors(errs)
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLogsExporter(ld pdata.Logs, t *testing.T, cfg *Config) []honeycombData {
+	var got []honeycombData
+	server := testingServer(func(data []honeycombData) {
+		got = append(got, data...)
+	})
+	defer server.Close()
+
+	cfg.APIURL = server.URL
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := createLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = exporter.ConsumeLogs(ctx, ld)
+	require.NoError(t, err)
+	exporter.Shutdown(context.Background())
+
+	return got
+}
+
+func TestLogsExporter(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"service.name": pdata.NewAttributeValueString("test_service"),
+		"A":            pdata.NewAttributeValueString("B"),
+	})
+	rl.InstrumentationLibraryLogs().Resize(1)
+	ill := rl.InstrumentationLibraryLogs().At(0)
+	ill.InstrumentationLibrary().SetName("test_library")
+	ill.Logs().Resize(2)
+
+	lr := ill.Logs().At(0)
+	lr.SetName("log_name")
+	lr.SetTimestamp(pdata.TimestampUnixNano(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()))
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	lr.SetSeverityText("Warning")
+	lr.Body().SetStringVal("log message")
+	lr.SetTraceID(pdata.NewTraceID([16]byte{0x01}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{0x02}))
+	lr.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"log_attr": pdata.NewAttributeValueInt(12345),
+		// Record attributes take precedence over the resource ones
+		"A": pdata.NewAttributeValueString("C"),
+	})
+
+	ill.Logs().At(1).Body().SetStringVal("other message")
+
+	got := testLogsExporter(ld, t, baseConfig())
+	want := []honeycombData{
+		{
+			Data: map[string]interface{}{
+				"A":               "C",
+				"body":            "log message",
+				"library.name":    "test_library",
+				"log_attr":        float64(12345),
+				"name":            "log_name",
+				"service.name":    "test_service",
+				"severity":        "Warning",
+				"severity_number": float64(13),
+				"trace.parent_id": "0200000000000000",
+				"trace.trace_id":  "01000000000000000000000000000000",
+			},
+		},
+		{
+			Data: map[string]interface{}{
+				"A":            "B",
+				"body":         "other message",
+				"library.name": "test_library",
+				"service.name": "test_service",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("otel log: (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombexporter
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// pointGroup holds the values of the data points of the metrics of an instrumentation
+// library with the same timestamp and labels, which are sent as a single event.
+type pointGroup struct {
+	timestamp pdata.TimestampUnixNano
+	labels    pdata.StringMap
+	fields    map[string]interface{}
+	points    int
+}
+
+// pointGroups are the groups of data points of an instrumentation library, in the order they are first seen.
+type pointGroups struct {
+	groups []*pointGroup
+	index  map[string]*pointGroup
+}
+
+func newPointGroups() *pointGroups {
+	return &pointGroups{index: map[string]*pointGroup{}}
+}
+
+// add adds the fields of a data point to the group of its timestamp and labels.
+func (pg *pointGroups) add(timestamp pdata.TimestampUnixNano, labels pdata.StringMap, fields map[string]interface{}) {
+	key := groupKey(timestamp, labels)
+	g, ok := pg.index[key]
+	if !ok {
+		g = &pointGroup{
+			timestamp: timestamp,
+			labels:    labels,
+			fields:    make(map[string]interface{}, len(fields)),
+		}
+		pg.index[key] = g
+		pg.groups = append(pg.groups, g)
+	}
+	for k, v := range fields {
+		g.fields[k] = v
+	}
+	g.points++
+}
+
+// groupKey returns a key identifying the timestamp and labels. The labels are sorted by key,
+// and each key and value is prefixed with its length, so that distinct labels never share a key.
+func groupKey(timestamp pdata.TimestampUnixNano, labels pdata.StringMap) string {
+	keys := make([]string, 0, labels.Len())
+	labels.ForEach(func(k string, _ string) {
+		keys = append(keys, k)
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(uint64(timestamp), 10))
+	for _, k := range keys {
+		v, _ := labels.Get(k)
+		for _, s := range []string{k, v} {
+			b.WriteByte(' ')
+			b.WriteString(strconv.Itoa(len(s)))
+			b.WriteByte(':')
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// pushMetricsData is the method called when metrics data is available. It sends
+// an event per group of data points with the same timestamp and labels, with a
+// field per metric, and the attributes of their resource.
+func (e *honeycombExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	var errs []error
+	dropped := 0
+
+	ctx, cancel := context.WithCancel(ctx)
+	go e.RunErrorLogger(ctx, e.client.TxResponses())
+	defer cancel()
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+
+		// Extract Resource attributes, they will be added to every event.
+		resourceAttrs := spanAttributesToMap(rm.Resource().Attributes())
+
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			groups := newPointGroups()
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				addMetricPoints(groups, metrics.At(k))
+			}
+
+			for _, g := range groups.groups {
+				ev := e.builder.NewEvent()
+
+				for k, v := range resourceAttrs {
+					ev.AddField(k, v)
+				}
+
+				addLibraryFields(ev, ilm.InstrumentationLibrary())
+
+				attrs := make(map[string]interface{}, g.labels.Len())
+				g.labels.ForEach(func(k string, v string) {
+					ev.AddField(k, v)
+					attrs[k] = v
+				})
+				// Labels are strings, the sample rate is expected to be an integer.
+				if v, ok := attrs[e.sampleRateAttribute].(string); ok {
+					if rate, err := strconv.ParseInt(v, 10, 64); err == nil {
+						attrs[e.sampleRateAttribute] = rate
+					}
+				}
+
+				for k, v := range g.fields {
+					ev.AddField(k, v)
+				}
+
+				if g.timestamp != 0 {
+					ev.Timestamp = timestampToTime(g.timestamp)
+				}
+
+				if err := e.sendSampled(ev, attrs); err != nil {
+					errs = append(errs, err)
+					dropped += g.points
+				}
+			}
+		}
+	}
+
+	return dropped, componenterror.CombineErrors(errs)
+}
+
+// addMetricPoints adds the values of the data points of the metric to their groups, in a field
+// named after the metric, or in fields with the metric name as prefix for histograms and summaries.
+func addMetricPoints(groups *pointGroups, metric pdata.Metric) {
+	name := metric.Name()
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		addIntPoints(groups, name, metric.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		addDoublePoints(groups, name, metric.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		addIntPoints(groups, name, metric.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		addDoublePoints(groups, name, metric.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		points := metric.IntHistogram().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			groups.add(p.Timestamp(), p.LabelsMap(), map[string]interface{}{
+				name + ".count": p.Count(),
+				name + ".sum":   p.Sum(),
+			})
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		points := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			groups.add(p.Timestamp(), p.LabelsMap(), map[string]interface{}{
+				name + ".count": p.Count(),
+				name + ".sum":   p.Sum(),
+			})
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		points := metric.DoubleSummary().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			fields := map[string]interface{}{
+				name + ".count": p.Count(),
+				name + ".sum":   p.Sum(),
+			}
+			quantiles := p.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				q := quantiles.At(j)
+				fields[name+".p"+strconv.FormatFloat(q.Quantile()*100, 'f', -1, 64)] = q.Value()
+			}
+			groups.add(p.Timestamp(), p.LabelsMap(), fields)
+		}
+	}
+}
+
+func addIntPoints(groups *pointGroups, name string, points pdata.IntDataPointSlice) {
+	for i := 0; i < points.Len(); i++ {
+		p := points.At(i)
+		groups.add(p.Timestamp(), p.LabelsMap(), map[string]interface{}{name: p.Value()})
+	}
+}
+
+func addDoublePoints(groups *pointGroups, name string, points pdata.DoubleDataPointSlice) {
+	for i := 0; i < points.Len(); i++ {
+		p := points.At(i)
+		groups.add(p.Timestamp(), p.LabelsMap(), map[string]interface{}{name: p.Value()})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testMetricsExporter(md pdata.Metrics, t *testing.T, cfg *Config) []honeycombData {
+	var got []honeycombData
+	server := testingServer(func(data []honeycombData) {
+		got = append(got, data...)
+	})
+	defer server.Close()
+
+	cfg.APIURL = server.URL
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := createMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = exporter.ConsumeMetrics(ctx, md)
+	require.NoError(t, err)
+	exporter.Shutdown(context.Background())
+
+	return got
+}
+
+func TestMetricsExporter(t *testing.T) {
+	ts := pdata.TimestampUnixNano(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("service.name", "test_service")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	ilm.InstrumentationLibrary().SetName("test_library")
+	metrics := ilm.Metrics()
+	metrics.Resize(4)
+
+	gauge := metrics.At(0)
+	gauge.SetName("gauge")
+	gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	gauge.DoubleGauge().DataPoints().Resize(2)
+	for i := 0; i < 2; i++ {
+		p := gauge.DoubleGauge().DataPoints().At(i)
+		p.SetTimestamp(ts)
+		p.LabelsMap().InitFromMap(map[string]string{"host": "host" + string(rune('a'+i))})
+		p.SetValue(float64(i) + 0.5)
+	}
+
+	sum := metrics.At(1)
+	sum.SetName("sum")
+	sum.SetDataType(pdata.MetricDataTypeIntSum)
+	sum.IntSum().DataPoints().Resize(1)
+	sumPoint := sum.IntSum().DataPoints().At(0)
+	sumPoint.SetTimestamp(ts)
+	sumPoint.LabelsMap().InitFromMap(map[string]string{"host": "hosta"})
+	sumPoint.SetValue(10)
+
+	histogram := metrics.At(2)
+	histogram.SetName("histogram")
+	histogram.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	histogram.DoubleHistogram().DataPoints().Resize(1)
+	histogramPoint := histogram.DoubleHistogram().DataPoints().At(0)
+	histogramPoint.SetTimestamp(ts)
+	histogramPoint.LabelsMap().InitFromMap(map[string]string{"host": "hostb"})
+	histogramPoint.SetCount(4)
+	histogramPoint.SetSum(12.5)
+
+	summary := metrics.At(3)
+	summary.SetName("summary")
+	summary.SetDataType(pdata.MetricDataTypeDoubleSummary)
+	summary.DoubleSummary().DataPoints().Resize(1)
+	summaryPoint := summary.DoubleSummary().DataPoints().At(0)
+	summaryPoint.SetTimestamp(ts + 1)
+	summaryPoint.SetCount(3)
+	summaryPoint.SetSum(6)
+	summaryPoint.QuantileValues().Resize(2)
+	summaryPoint.QuantileValues().At(0).SetQuantile(0.5)
+	summaryPoint.QuantileValues().At(0).SetValue(2)
+	summaryPoint.QuantileValues().At(1).SetQuantile(0.99)
+	summaryPoint.QuantileValues().At(1).SetValue(3)
+
+	got := testMetricsExporter(md, t, baseConfig())
+	assert.ElementsMatch(t, []honeycombData{
+		{
+			Data: map[string]interface{}{
+				"service.name": "test_service",
+				"library.name": "test_library",
+				"host":         "hosta",
+				"gauge":        0.5,
+				"sum":          float64(10),
+			},
+		},
+		{
+			Data: map[string]interface{}{
+				"service.name":    "test_service",
+				"library.name":    "test_library",
+				"host":            "hostb",
+				"gauge":           1.5,
+				"histogram.count": float64(4),
+				"histogram.sum":   12.5,
+			},
+		},
+		{
+			Data: map[string]interface{}{
+				"service.name":  "test_service",
+				"library.name":  "test_library",
+				"summary.count": float64(3),
+				"summary.sum":   float64(6),
+				"summary.p50":   float64(2),
+				"summary.p99":   float64(3),
+			},
+		},
+	}, got)
+}
+
+func TestGroupKey(t *testing.T) {
+	labels := func(m map[string]string) pdata.StringMap {
+		sm := pdata.NewStringMap()
+		sm.InitFromMap(m)
+		return sm
+	}
+
+	assert.Equal(t,
+		groupKey(1, labels(map[string]string{"a": "1", "b": "2"})),
+		groupKey(1, labels(map[string]string{"b": "2", "a": "1"})))
+	assert.NotEqual(t,
+		groupKey(1, labels(map[string]string{"a": "1"})),
+		groupKey(2, labels(map[string]string{"a": "1"})))
+	// Keys and values holding the separators of the other labels must not collide.
+	assert.NotEqual(t,
+		groupKey(1, labels(map[string]string{"a": "1,b=2"})),
+		groupKey(1, labels(map[string]string{"a": "1", "b": "2"})))
+	assert.NotEqual(t,
+		groupKey(1, labels(map[string]string{"a": "1 1:b"})),
+		groupKey(1, labels(map[string]string{"a": "1", "b": ""})))
+	assert.NotEqual(t,
+		groupKey(1, labels(map[string]string{"a=1": ""})),
+		groupKey(1, labels(map[string]string{"a": "1"})))
+}
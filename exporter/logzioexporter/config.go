@@ -16,6 +16,7 @@ package logzioexporter
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/config/configmodels"
 )
@@ -23,10 +24,13 @@ import (
 // Config contains Logz.io specific configuration such as Account TracesToken, Region, etc.
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"`
-	TracesToken                   string `mapstructure:"account_token"`   // Your Logz.io Account Token, can be found at https://app.logz.io/#/dashboard/settings/general
-	MetricsToken                  string `mapstructure:"metrics_token"`   // Your Logz.io Metrics Token, can be found at https://docs.logz.io/user-guide/accounts/finding-your-metrics-account-token/
-	Region                        string `mapstructure:"region"`          // Your Logz.io 2-letter region code, can be found at https://docs.logz.io/user-guide/accounts/account-region.html#available-regions
-	CustomEndpoint                string `mapstructure:"custom_endpoint"` // Custom endpoint to ship traces to. Use only for dev and tests.
+	TracesToken                   string `mapstructure:"account_token"`           // Your Logz.io Account Token, can be found at https://app.logz.io/#/dashboard/settings/general
+	MetricsToken                  string `mapstructure:"metrics_token"`           // Your Logz.io Metrics Token, can be found at https://docs.logz.io/user-guide/accounts/finding-your-metrics-account-token/
+	LogsToken                     string `mapstructure:"logs_token"`              // Your Logz.io Logs Token, can be found at https://app.logz.io/#/dashboard/settings/manage-tokens/data-shipping
+	Region                        string `mapstructure:"region"`                  // Your Logz.io 2-letter region code, can be found at https://docs.logz.io/user-guide/accounts/account-region.html#available-regions
+	CustomEndpoint                string `mapstructure:"custom_endpoint"`         // Custom endpoint to ship traces to. Use only for dev and tests.
+	CustomMetricsEndpoint         string `mapstructure:"custom_metrics_endpoint"` // Custom endpoint to ship metrics to. Use only for dev and tests.
+	CustomLogsEndpoint            string `mapstructure:"custom_logs_endpoint"`    // Custom endpoint to ship logs to. Use only for dev and tests.
 }
 
 func (c *Config) validate() error {
@@ -35,3 +39,41 @@ func (c *Config) validate() error {
 	}
 	return nil
 }
+
+func (c *Config) validateMetrics() error {
+	if c.MetricsToken == "" {
+		return errors.New("`metrics_token` not specified")
+	}
+	return nil
+}
+
+func (c *Config) validateLogs() error {
+	if c.LogsToken == "" {
+		return errors.New("`logs_token` not specified")
+	}
+	return nil
+}
+
+// metricsEndpoint returns the Prometheus remote write endpoint of the listener of the region.
+func (c *Config) metricsEndpoint() string {
+	if c.CustomMetricsEndpoint != "" {
+		return c.CustomMetricsEndpoint
+	}
+	return fmt.Sprintf("https://%s:8053", listenerHost(c.Region))
+}
+
+// logsEndpoint returns the bulk endpoint of the listener of the region.
+func (c *Config) logsEndpoint() string {
+	if c.CustomLogsEndpoint != "" {
+		return c.CustomLogsEndpoint
+	}
+	return fmt.Sprintf("https://%s:8071", listenerHost(c.Region))
+}
+
+// listenerHost returns the host of the Logz.io listener of region, the US one by default.
+func listenerHost(region string) string {
+	if region == "" || region == "us" {
+		return "listener.logz.io"
+	}
+	return fmt.Sprintf("listener-%s.logz.io", region)
+}
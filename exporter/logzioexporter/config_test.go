@@ -44,7 +44,24 @@ func TestLoadConfig(tester *testing.T) {
 	assert.Equal(tester, &Config{
 		ExporterSettings: configmodels.ExporterSettings{TypeVal: typeStr, NameVal: "logzio/2"},
 		TracesToken:      "logzioTESTtoken",
+		MetricsToken:     "logzioTESTmetricsToken",
+		LogsToken:        "logzioTESTlogsToken",
 		Region:           "eu",
 		CustomEndpoint:   "https://some-url.com:8888",
 	}, config)
 }
+
+func TestListenerEndpoints(tester *testing.T) {
+	config := &Config{}
+	assert.Equal(tester, "https://listener.logz.io:8053", config.metricsEndpoint())
+	assert.Equal(tester, "https://listener.logz.io:8071", config.logsEndpoint())
+
+	config.Region = "eu"
+	assert.Equal(tester, "https://listener-eu.logz.io:8053", config.metricsEndpoint())
+	assert.Equal(tester, "https://listener-eu.logz.io:8071", config.logsEndpoint())
+
+	config.CustomMetricsEndpoint = "http://localhost:8053"
+	config.CustomLogsEndpoint = "http://localhost:8071"
+	assert.Equal(tester, "http://localhost:8053", config.metricsEndpoint())
+	assert.Equal(tester, "http://localhost:8071", config.logsEndpoint())
+}
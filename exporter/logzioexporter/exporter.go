@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/jaegertracing/jaeger/model"
@@ -25,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	prw "go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter"
 	"go.opentelemetry.io/collector/translator/trace/jaeger"
 )
 
@@ -87,11 +89,29 @@ func newLogzioTraceExporter(config *Config, params component.ExporterCreateParam
 }
 
 func newLogzioMetricsExporter(config *Config, params component.ExporterCreateParams) (component.MetricsExporter, error) {
-	exporter, _ := newLogzioExporter(config, params)
+	if config == nil {
+		return nil, errors.New("exporter config can't be null")
+	}
+	if err := config.validateMetrics(); err != nil {
+		return nil, err
+	}
+
+	// Logz.io receives metrics through a Prometheus remote write listener, authenticated by the metrics token.
+	client := &http.Client{
+		Transport: &bearerTokenRoundTripper{
+			token: config.MetricsToken,
+			next:  http.DefaultTransport,
+		},
+	}
+	exporter, err := prw.NewPrwExporter("", config.metricsEndpoint(), client, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	return exporterhelper.NewMetricsExporter(
 		config,
 		params.Logger,
-		exporter.pushMetricsData,
+		exporter.PushMetrics,
 		exporterhelper.WithShutdown(exporter.Shutdown))
 }
 
@@ -113,12 +133,21 @@ func (exporter *logzioExporter) pushTraceData(ctx context.Context, traces pdata.
 	return droppedSpans, nil
 }
 
-func (exporter *logzioExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
-	return 0, nil
-}
-
 func (exporter *logzioExporter) Shutdown(ctx context.Context) error {
 	exporter.logger.Info("Closing logzio exporter..")
 	exporter.writer.Close()
 	return nil
 }
+
+// bearerTokenRoundTripper authenticates the requests it sends with a bearer token.
+type bearerTokenRoundTripper struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (rt *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+rt.token)
+	return rt.next.RoundTrip(req)
+}
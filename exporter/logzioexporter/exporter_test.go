@@ -187,12 +187,41 @@ func TestPushTraceData(tester *testing.T) {
 }
 
 func TestPushMetricsData(tester *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(tester, "Bearer test", r.Header.Get("Authorization"))
+		assert.Equal(tester, "snappy", r.Header.Get("Content-Encoding"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
 	cfg := Config{
-		MetricsToken:   "test",
-		Region:         "eu",
-		CustomEndpoint: "url",
+		MetricsToken:          "test",
+		Region:                "eu",
+		CustomMetricsEndpoint: server.URL,
 	}
 	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(1)
+	metrics.At(0).SetName("test_gauge")
+	metrics.At(0).SetDataType(pdata.MetricDataTypeDoubleGauge)
+	metrics.At(0).DoubleGauge().DataPoints().Resize(1)
+	metrics.At(0).DoubleGauge().DataPoints().At(0).SetValue(1.5)
+	metrics.At(0).DoubleGauge().DataPoints().At(0).SetTimestamp(pdata.TimestampUnixNano(1e9))
 
 	testMetricsExporter(md, tester, &cfg)
+	assert.Equal(tester, 1, requests)
+}
+
+func TestNullMetricsTokenConfig(tester *testing.T) {
+	cfg := Config{
+		TracesToken: "test",
+		Region:      "eu",
+	}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, err := createMetricsExporter(context.Background(), params, &cfg)
+	assert.Error(tester, err, "Empty metrics token should produce error")
 }
@@ -29,7 +29,9 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(createTraceExporter))
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 func createDefaultConfig() configmodels.Exporter {
@@ -52,3 +54,8 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 	config := cfg.(*Config)
 	return newLogzioMetricsExporter(config, params)
 }
+
+func createLogsExporter(_ context.Context, params component.ExporterCreateParams, cfg configmodels.Exporter) (component.LogsExporter, error) {
+	config := cfg.(*Config)
+	return newLogzioLogsExporter(config, params)
+}
@@ -51,3 +51,23 @@ func TestCreateTraceExporter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, exporter)
 }
+
+func TestCreateMetricsAndLogsExporters(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+	require.NoError(t, err)
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	metricsExporter, err := factory.CreateMetricsExporter(context.Background(), params, cfg.Exporters["logzio/2"])
+	assert.Nil(t, err)
+	assert.NotNil(t, metricsExporter)
+
+	logsExporter, err := factory.CreateLogsExporter(context.Background(), params, cfg.Exporters["logzio/2"])
+	assert.Nil(t, err)
+	assert.NotNil(t, logsExporter)
+}
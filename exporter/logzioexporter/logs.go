@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logzioexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	logsType = "otel"
+
+	// maxLogSize is the limit of the listener for the size of a log line, larger logs are dropped.
+	maxLogSize = 500 * 1000
+	// maxBulkSize is the size from which the log lines are sent in another bulk.
+	maxBulkSize = 3 * 1000 * 1000
+
+	timestampField      = "@timestamp"
+	messageField        = "message"
+	nameField           = "name"
+	severityField       = "severity"
+	severityNumberField = "severity_number"
+	traceIDField        = "trace_id"
+	spanIDField         = "span_id"
+)
+
+// logzioLogsExporter exports logs to the bulk HTTP listener of Logz.io, as lines of JSON.
+type logzioLogsExporter struct {
+	url    string
+	client *http.Client
+	logger *zap.Logger
+}
+
+func newLogzioLogsExporter(config *Config, params component.ExporterCreateParams) (component.LogsExporter, error) {
+	exporter, err := newLogsExporter(config, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		config,
+		params.Logger,
+		exporter.pushLogsData)
+}
+
+func newLogsExporter(config *Config, logger *zap.Logger) (*logzioLogsExporter, error) {
+	if config == nil {
+		return nil, errors.New("exporter config can't be null")
+	}
+	if err := config.validateLogs(); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.Parse(config.logsEndpoint())
+	if err != nil {
+		return nil, fmt.Errorf("invalid logs endpoint: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("token", config.LogsToken)
+	query.Set("type", logsType)
+	endpoint.RawQuery = query.Encode()
+
+	return &logzioLogsExporter{
+		url:    endpoint.String(),
+		client: &http.Client{},
+		logger: logger,
+	}, nil
+}
+
+func (exporter *logzioLogsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var (
+		bulk        bytes.Buffer
+		bulkCount   int
+		droppedLogs int
+		errs        []error
+	)
+
+	flush := func() {
+		if bulkCount == 0 {
+			return
+		}
+		if err := exporter.send(ctx, bulk.Bytes()); err != nil {
+			errs = append(errs, err)
+			droppedLogs += bulkCount
+		}
+		bulk.Reset()
+		bulkCount = 0
+	}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs := rl.Resource().Attributes()
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				line, err := json.Marshal(convertLogRecord(resourceAttrs, logs.At(k)))
+				if err != nil || len(line) > maxLogSize {
+					exporter.logger.Debug("Dropping log that cannot be sent", zap.Int("size", len(line)), zap.Error(err))
+					droppedLogs++
+					continue
+				}
+
+				if bulk.Len()+len(line) > maxBulkSize {
+					flush()
+				}
+				bulk.Write(line)
+				bulk.WriteByte('\n')
+				bulkCount++
+			}
+		}
+	}
+	flush()
+
+	return droppedLogs, componenterror.CombineErrors(errs)
+}
+
+// send posts bulk to the listener. Only failed requests and 408, 429 and 5xx
+// responses are worth retrying, the listener rejects the bulk otherwise.
+func (exporter *logzioLogsExporter) send(ctx context.Context, bulk []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exporter.url, bytes.NewReader(bulk))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := exporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	err = fmt.Errorf("the listener responded with status %d: %s", resp.StatusCode, body)
+	if resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return err
+	}
+	return consumererror.Permanent(err)
+}
+
+// convertLogRecord converts a log record to a Logz.io log, with the resource attributes
+// and the attributes of the record as fields.
+func convertLogRecord(resourceAttrs pdata.AttributeMap, record pdata.LogRecord) map[string]interface{} {
+	fields := tracetranslator.AttributeMapToMap(resourceAttrs)
+	for k, v := range tracetranslator.AttributeMapToMap(record.Attributes()) {
+		fields[k] = v
+	}
+
+	fields[messageField] = tracetranslator.AttributeValueToString(record.Body(), false)
+	if ts := record.Timestamp(); ts != 0 {
+		fields[timestampField] = pdata.UnixNanoToTime(ts).UTC().Format(time.RFC3339Nano)
+	}
+	if name := record.Name(); name != "" {
+		fields[nameField] = name
+	}
+	if severityText := record.SeverityText(); severityText != "" {
+		fields[severityField] = severityText
+	}
+	if severityNumber := record.SeverityNumber(); severityNumber != pdata.SeverityNumberUNDEFINED {
+		fields[severityNumberField] = int32(severityNumber)
+	}
+	// the IDs of records logged outside of a span are left out
+	if traceID := record.TraceID().HexString(); traceID != "" {
+		fields[traceIDField] = traceID
+	}
+	if spanID := record.SpanID().HexString(); spanID != "" {
+		fields[spanIDField] = spanID
+	}
+	return fields
+}
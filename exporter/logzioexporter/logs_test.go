@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logzioexporter
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLogs(count int, body string) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().Attributes().InsertString("service.name", testService)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	records := rl.InstrumentationLibraryLogs().At(0).Logs()
+	records.Resize(count)
+	for i := 0; i < count; i++ {
+		records.At(i).Body().SetStringVal(body)
+	}
+	return ld
+}
+
+// bulkLines splits a bulk request of the logs exporter into its log lines.
+func bulkLines(tester *testing.T, bulk string) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSuffix(bulk, "\n"), "\n") {
+		var log map[string]interface{}
+		require.NoError(tester, json.Unmarshal([]byte(line), &log))
+		lines = append(lines, log)
+	}
+	return lines
+}
+
+func TestConvertLogRecord(tester *testing.T) {
+	resourceAttrs := pdata.NewAttributeMap()
+	resourceAttrs.InsertString("service.name", testService)
+	resourceAttrs.InsertString("overridden", "resource")
+
+	record := pdata.NewLogRecord()
+	record.SetName("testName")
+	record.Body().SetStringVal("log message")
+	record.SetTimestamp(pdata.TimestampUnixNano(1609459200000000000))
+	record.SetSeverityText("ERROR")
+	record.SetSeverityNumber(pdata.SeverityNumberERROR)
+	record.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}))
+	record.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 2}))
+	record.Attributes().InsertString("overridden", "record")
+	record.Attributes().InsertInt("http.status_code", 500)
+
+	assert.Equal(tester, map[string]interface{}{
+		"service.name":      testService,
+		"overridden":        "record",
+		"http.status_code":  int64(500),
+		messageField:        "log message",
+		timestampField:      "2021-01-01T00:00:00Z",
+		nameField:           "testName",
+		severityField:       "ERROR",
+		severityNumberField: int32(pdata.SeverityNumberERROR),
+		traceIDField:        "00000000000000000000000000000001",
+		spanIDField:         "0000000000000002",
+	}, convertLogRecord(resourceAttrs, record))
+}
+
+func TestPushLogsData(tester *testing.T) {
+	var recordedRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(tester, "test", req.URL.Query().Get("token"))
+		assert.Equal(tester, logsType, req.URL.Query().Get("type"))
+		body, _ := ioutil.ReadAll(req.Body)
+		recordedRequests = append(recordedRequests, string(body))
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		LogsToken:          "test",
+		CustomLogsEndpoint: server.URL,
+	}
+	exporter, err := newLogsExporter(&cfg, zap.NewNop())
+	require.NoError(tester, err)
+
+	droppedLogs, err := exporter.pushLogsData(context.Background(), testLogs(2, "log message"))
+	require.NoError(tester, err)
+	assert.Equal(tester, 0, droppedLogs)
+	require.Len(tester, recordedRequests, 1)
+	assert.Equal(tester, []map[string]interface{}{
+		{"service.name": testService, messageField: "log message"},
+		{"service.name": testService, messageField: "log message"},
+	}, bulkLines(tester, recordedRequests[0]))
+}
+
+func TestPushLogsDataInBulks(tester *testing.T) {
+	var recordedRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		recordedRequests = append(recordedRequests, string(body))
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		LogsToken:          "test",
+		CustomLogsEndpoint: server.URL,
+	}
+	exporter, err := newLogsExporter(&cfg, zap.NewNop())
+	require.NoError(tester, err)
+
+	// six of these logs fill up a bulk, and the fourth one is over the size limit of a log line
+	ld := testLogs(8, strings.Repeat("a", maxLogSize*9/10))
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(3).Body().SetStringVal(strings.Repeat("a", maxLogSize))
+
+	droppedLogs, err := exporter.pushLogsData(context.Background(), ld)
+	require.NoError(tester, err)
+	assert.Equal(tester, 1, droppedLogs)
+	require.Len(tester, recordedRequests, 2)
+	assert.Len(tester, bulkLines(tester, recordedRequests[0]), 6)
+	assert.Len(tester, bulkLines(tester, recordedRequests[1]), 1)
+}
+
+func TestPushLogsDataFailures(tester *testing.T) {
+	for status, permanent := range map[int]bool{
+		http.StatusBadRequest:         true,
+		http.StatusUnauthorized:       true,
+		http.StatusRequestTimeout:     false,
+		http.StatusTooManyRequests:    false,
+		http.StatusServiceUnavailable: false,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(status)
+		}))
+		cfg := Config{
+			LogsToken:          "test",
+			CustomLogsEndpoint: server.URL,
+		}
+		exporter, err := newLogsExporter(&cfg, zap.NewNop())
+		require.NoError(tester, err)
+
+		droppedLogs, err := exporter.pushLogsData(context.Background(), testLogs(2, "log message"))
+		assert.Error(tester, err, "status %d should fail the push", status)
+		assert.Equal(tester, 2, droppedLogs)
+		assert.Equal(tester, permanent, consumererror.IsPermanent(err), "status %d", status)
+		server.Close()
+	}
+}
+
+func TestNullLogsTokenConfig(tester *testing.T) {
+	cfg := Config{
+		TracesToken: "test",
+		Region:      "eu",
+	}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, err := createLogsExporter(context.Background(), params, &cfg)
+	assert.Error(tester, err, "Empty logs token should produce error")
+}
+
+func TestLogsExporter(tester *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		LogsToken:          "test",
+		CustomLogsEndpoint: server.URL,
+	}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exporter, err := createLogsExporter(context.Background(), params, &cfg)
+	require.NoError(tester, err)
+	assert.NoError(tester, exporter.ConsumeLogs(context.Background(), testLogs(1, "log message")))
+	assert.Equal(tester, 1, requests)
+}
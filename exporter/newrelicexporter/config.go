@@ -42,6 +42,9 @@ type Config struct {
 
 	// SpansURLOverride overrides the spans endpoint.
 	SpansURLOverride string `mapstructure:"spans_url_override"`
+
+	// LogsURLOverride overrides the logs endpoint.
+	LogsURLOverride string `mapstructure:"logs_url_override"`
 }
 
 // HarvestOption sets all relevant Config values when instantiating a New
@@ -49,6 +49,10 @@ func TestCreateExporter(t *testing.T) {
 	me, err := createMetricsExporter(context.Background(), params, nrConfig)
 	assert.Nil(t, err)
 	assert.NotNil(t, me, "failed to create metrics exporter")
+
+	le, err := createLogsExporter(context.Background(), params, nrConfig)
+	assert.Nil(t, err)
+	assert.NotNil(t, le, "failed to create logs exporter")
 }
 
 func TestCreateTraceExporterError(t *testing.T) {
@@ -62,3 +66,9 @@ func TestCreateMetricsExporterError(t *testing.T) {
 	_, err := createMetricsExporter(context.Background(), params, nil)
 	assert.Error(t, err)
 }
+
+func TestCreateLogsExporterError(t *testing.T) {
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, err := createLogsExporter(context.Background(), params, nil)
+	assert.Error(t, err)
+}
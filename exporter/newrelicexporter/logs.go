@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package newrelicexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLogsURL = "https://log-api.newrelic.com/log/v1"
+
+	// maxCompressedSizeBytes is the limit of the Log API for the size of a
+	// compressed payload, larger payloads are split.
+	maxCompressedSizeBytes = 1e6
+)
+
+// nrLog is a log of the New Relic Log API.
+type nrLog struct {
+	Timestamp  int64                  `json:"timestamp,omitempty"`
+	Message    string                 `json:"message"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// nrLogCommon holds the attributes shared by the logs of a nrLogBatch.
+type nrLogCommon struct {
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// nrLogBatch is an element of the payloads of the New Relic Log API.
+type nrLogBatch struct {
+	Common nrLogCommon `json:"common"`
+	Logs   []nrLog     `json:"logs"`
+}
+
+// logsExporter exports OpenTelemetry Collector logs to the New Relic Log API,
+// which is not supported by the harvester of the telemetry SDK.
+type logsExporter struct {
+	client           *http.Client
+	logger           *zap.Logger
+	url              string
+	apiKey           string
+	timeout          time.Duration
+	commonAttributes map[string]interface{}
+}
+
+func newLogsExporter(l *zap.Logger, c configmodels.Exporter) (*logsExporter, error) {
+	nrConfig, ok := c.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config: %#v", c)
+	}
+
+	url := defaultLogsURL
+	if nrConfig.LogsURLOverride != "" {
+		url = nrConfig.LogsURLOverride
+	}
+
+	return &logsExporter{
+		client:           &http.Client{},
+		logger:           l,
+		url:              url,
+		apiKey:           nrConfig.APIKey,
+		timeout:          nrConfig.Timeout,
+		commonAttributes: nrConfig.CommonAttributes,
+	}, nil
+}
+
+func (e *logsExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var batches []nrLogBatch
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rlogs := ld.ResourceLogs().At(i)
+		resource := rlogs.Resource()
+		for j := 0; j < rlogs.InstrumentationLibraryLogs().Len(); j++ {
+			ilogs := rlogs.InstrumentationLibraryLogs().At(j)
+			if ilogs.Logs().Len() == 0 {
+				continue
+			}
+
+			transform := newLogTransformer(resource, ilogs.InstrumentationLibrary())
+			batch := nrLogBatch{
+				Common: nrLogCommon{Attributes: e.batchAttributes(transform)},
+				Logs:   make([]nrLog, 0, ilogs.Logs().Len()),
+			}
+			for k := 0; k < ilogs.Logs().Len(); k++ {
+				batch.Logs = append(batch.Logs, transform.Log(ilogs.Logs().At(k)))
+			}
+			batches = append(batches, batch)
+		}
+	}
+
+	if len(batches) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	return e.send(ctx, batches)
+}
+
+// batchAttributes returns the common attributes of the logs of a resource
+// and instrumentation library, they override the configured ones.
+func (e *logsExporter) batchAttributes(transform *logTransformer) map[string]interface{} {
+	attrs := make(map[string]interface{}, len(e.commonAttributes)+len(transform.ResourceAttributes)+2)
+	for k, v := range e.commonAttributes {
+		attrs[k] = v
+	}
+	for k, v := range transform.ResourceAttributes {
+		attrs[k] = v
+	}
+
+	// Default attributes to tell New Relic about this collector.
+	// (overrides any existing)
+	attrs[collectorNameKey] = name
+	attrs[collectorVersionKey] = version
+
+	return attrs
+}
+
+// send compresses and sends batches in a single payload, unless it is too
+// large, in which case it is split in halves that are sent separately. It
+// returns the number of logs that could not be sent.
+func (e *logsExporter) send(ctx context.Context, batches []nrLogBatch) (int, error) {
+	count := logCount(batches)
+
+	body, err := compressBatches(batches)
+	if err != nil {
+		return count, consumererror.Permanent(err)
+	}
+
+	if body.Len() >= maxCompressedSizeBytes {
+		if count == 1 {
+			return count, consumererror.Permanent(fmt.Errorf("log of %d compressed bytes exceeds the payload size limit", body.Len()))
+		}
+
+		first, second := splitBatches(batches, count/2)
+		firstDropped, firstErr := e.send(ctx, first)
+		secondDropped, secondErr := e.send(ctx, second)
+		var errs []error
+		if firstErr != nil {
+			errs = append(errs, firstErr)
+		}
+		if secondErr != nil {
+			errs = append(errs, secondErr)
+		}
+		return firstDropped + secondDropped, componenterror.CombineErrors(errs)
+	}
+
+	if err := e.post(ctx, body); err != nil {
+		return count, err
+	}
+	return 0, nil
+}
+
+// post sends a compressed payload to the Log API. Errors are permanent,
+// unless the request timed out, was throttled, or failed on the server side.
+func (e *logsExporter) post(ctx context.Context, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Api-Key", e.apiKey)
+	req.Header.Set("User-Agent", product+"/"+version)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	e.logger.Debug("Log API response", zap.Int("status", resp.StatusCode), zap.ByteString("body", respBody))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("the Log API responded with status %d: %s", resp.StatusCode, respBody)
+	switch {
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return err
+	default:
+		return consumererror.Permanent(err)
+	}
+}
+
+func compressBatches(batches []nrLogBatch) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(batches); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+func logCount(batches []nrLogBatch) int {
+	count := 0
+	for _, batch := range batches {
+		count += len(batch.Logs)
+	}
+	return count
+}
+
+// splitBatches splits batches after the first n logs, the batch that holds
+// the nth log is split in two sharing the same common attributes.
+func splitBatches(batches []nrLogBatch, n int) ([]nrLogBatch, []nrLogBatch) {
+	var first []nrLogBatch
+	for i, batch := range batches {
+		if n >= len(batch.Logs) {
+			first = append(first, batch)
+			n -= len(batch.Logs)
+			continue
+		}
+		if n == 0 {
+			return first, batches[i:]
+		}
+
+		first = append(first, nrLogBatch{Common: batch.Common, Logs: batch.Logs[:n]})
+		second := []nrLogBatch{{Common: batch.Common, Logs: batch.Logs[n:]}}
+		return first, append(second, batches[i+1:]...)
+	}
+	return first, nil
+}
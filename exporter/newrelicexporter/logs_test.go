@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package newrelicexporter
+
+import (
+	"context"
+	"encoding/hex"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+func testLogBatches(counts ...int) []nrLogBatch {
+	batches := make([]nrLogBatch, len(counts))
+	for i, count := range counts {
+		batches[i].Common.Attributes = map[string]interface{}{"batch": i}
+		for j := 0; j < count; j++ {
+			batches[i].Logs = append(batches[i].Logs, nrLog{Message: strings.Repeat("a", j+1)})
+		}
+	}
+	return batches
+}
+
+func TestSplitBatches(t *testing.T) {
+	batches := testLogBatches(2, 3)
+
+	first, second := splitBatches(batches, 2)
+	assert.Equal(t, batches[:1], first)
+	assert.Equal(t, batches[1:], second)
+
+	first, second = splitBatches(batches, 3)
+	require.Len(t, first, 2)
+	require.Len(t, second, 1)
+	assert.Equal(t, batches[0], first[0])
+	assert.Equal(t, batches[1].Logs[:1], first[1].Logs)
+	assert.Equal(t, batches[1].Common, first[1].Common)
+	assert.Equal(t, batches[1].Logs[1:], second[0].Logs)
+	assert.Equal(t, batches[1].Common, second[0].Common)
+
+	first, second = splitBatches(batches, 5)
+	assert.Equal(t, batches, first)
+	assert.Empty(t, second)
+}
+
+func newTestLogsExporter(t *testing.T, handler http.HandlerFunc) (*logsExporter, func()) {
+	srv := httptest.NewServer(handler)
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIKey, cfg.LogsURLOverride = "a1b2c3d4", srv.URL
+	exp, err := newLogsExporter(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	return exp, srv.Close
+}
+
+func TestSendLogsHeaders(t *testing.T) {
+	exp, closeServer := newTestLogsExporter(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "a1b2c3d4", r.Header.Get("Api-Key"))
+		assert.Equal(t, product+"/"+version, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer closeServer()
+
+	dropped, err := exp.send(context.Background(), testLogBatches(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestSendLogsErrors(t *testing.T) {
+	tests := []struct {
+		status    int
+		permanent bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusForbidden, true},
+		{http.StatusRequestTimeout, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusServiceUnavailable, false},
+	}
+
+	for _, test := range tests {
+		t.Run(http.StatusText(test.status), func(t *testing.T) {
+			exp, closeServer := newTestLogsExporter(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+			})
+			defer closeServer()
+
+			dropped, err := exp.send(context.Background(), testLogBatches(2, 1))
+			require.Error(t, err)
+			assert.Equal(t, 3, dropped)
+			assert.Equal(t, test.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestSendLogsSplitsLargePayloads(t *testing.T) {
+	var requests int
+	exp, closeServer := newTestLogsExporter(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer closeServer()
+
+	// Random messages barely compress, so that the payload exceeds the size limit
+	rnd := rand.New(rand.NewSource(1))
+	batches := testLogBatches(4)
+	for i := range batches[0].Logs {
+		message := make([]byte, maxCompressedSizeBytes/4)
+		rnd.Read(message)
+		batches[0].Logs[i].Message = hex.EncodeToString(message)
+	}
+
+	dropped, err := exp.send(context.Background(), batches)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 2, requests)
+}
@@ -450,3 +450,48 @@ func TestExportMetricDataFull(t *testing.T) {
 
 	testExportMetricData(t, expected, md)
 }
+
+func TestExportLogData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &Mock{make([]Data, 0, 1)}
+	srv := m.Server()
+	defer srv.Close()
+
+	f := NewFactory()
+	c := f.CreateDefaultConfig().(*Config)
+	c.APIKey, c.LogsURLOverride = "1", srv.URL
+	c.CommonAttributes = map[string]interface{}{"server": "test-server", "resource": "overridden"}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp, err := f.CreateLogsExporter(context.Background(), params, c)
+	require.NoError(t, err)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().Attributes().InsertString("resource", "R1")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	ill := rl.InstrumentationLibraryLogs().At(0)
+	ill.InstrumentationLibrary().SetName("test-library")
+	ill.Logs().Resize(2)
+	ill.Logs().At(0).Body().SetStringVal("first")
+	ill.Logs().At(1).Body().SetStringVal("second")
+	ill.Logs().At(1).SetTraceID(pdata.NewTraceID([...]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}))
+
+	require.NoError(t, exp.ConsumeLogs(ctx, ld))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	require.Len(t, m.Data, 1)
+	assert.Equal(t, map[string]interface{}{
+		"server":               "test-server",
+		"resource":             "R1",
+		instrumentationNameKey: "test-library",
+		collectorNameKey:       name,
+		collectorVersionKey:    version,
+	}, m.Data[0].Common.Attributes)
+	assert.Equal(t, []Log{
+		{Message: "first"},
+		{Message: "second", Attributes: map[string]interface{}{logTraceIDKey: "01010101010101010101010101010101"}},
+	}, m.Logs())
+}
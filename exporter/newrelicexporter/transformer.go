@@ -41,6 +41,11 @@ const (
 	statusDescriptionKey      = "otel.status_description"
 	spanKindKey               = "span.kind"
 	serviceNameKey            = "service.name"
+	logNameKey                = "name"
+	logTraceIDKey             = "trace.id"
+	logSpanIDKey              = "span.id"
+	logLevelKey               = "log.level"
+	logLevelNumberKey         = "log.levelNum"
 )
 
 // TODO (MrAlias): unify this with the traceTransformer when the metric data
@@ -56,19 +61,33 @@ type traceTransformer struct {
 }
 
 func newTraceTransformer(resource pdata.Resource, lib pdata.InstrumentationLibrary) *traceTransformer {
-	t := &traceTransformer{
-		ResourceAttributes: tracetranslator.AttributeMapToMap(
-			resource.Attributes(),
-		),
+	return &traceTransformer{
+		ResourceAttributes: resourceAttributes(resource, lib),
 	}
+}
+
+type logTransformer struct {
+	ResourceAttributes map[string]interface{}
+}
+
+func newLogTransformer(resource pdata.Resource, lib pdata.InstrumentationLibrary) *logTransformer {
+	return &logTransformer{
+		ResourceAttributes: resourceAttributes(resource, lib),
+	}
+}
+
+// resourceAttributes returns the attributes of resource along with the name
+// and version of the instrumentation library.
+func resourceAttributes(resource pdata.Resource, lib pdata.InstrumentationLibrary) map[string]interface{} {
+	attrs := tracetranslator.AttributeMapToMap(resource.Attributes())
 
 	if n := lib.Name(); n != "" {
-		t.ResourceAttributes[instrumentationNameKey] = n
+		attrs[instrumentationNameKey] = n
 		if v := lib.Version(); v != "" {
-			t.ResourceAttributes[instrumentationVersionKey] = v
+			attrs[instrumentationVersionKey] = v
 		}
 	}
-	return t
+	return attrs
 }
 
 var (
@@ -172,6 +191,42 @@ func (t *traceTransformer) SpanEvents(span pdata.Span) []telemetry.Event {
 	return events
 }
 
+// Log transforms a log record into a New Relic log, its message is the body
+// of the record, or its name if it has no body.
+func (t *logTransformer) Log(log pdata.LogRecord) nrLog {
+	message := tracetranslator.AttributeValueToString(log.Body(), false)
+	if message == "" {
+		message = log.Name()
+	}
+
+	attrs := tracetranslator.AttributeMapToMap(log.Attributes())
+	if n := log.Name(); n != "" {
+		attrs[logNameKey] = n
+	}
+	// HexString returns an empty string if the ID is invalid.
+	if traceID := log.TraceID().HexString(); traceID != "" {
+		attrs[logTraceIDKey] = traceID
+	}
+	if spanID := log.SpanID().HexString(); spanID != "" {
+		attrs[logSpanIDKey] = spanID
+	}
+	if st := log.SeverityText(); st != "" {
+		attrs[logLevelKey] = st
+	}
+	if sn := log.SeverityNumber(); sn != pdata.SeverityNumberUNDEFINED {
+		attrs[logLevelNumberKey] = int32(sn)
+	}
+
+	l := nrLog{
+		Message:    message,
+		Attributes: attrs,
+	}
+	if ts := log.Timestamp(); ts != 0 {
+		l.Timestamp = int64(ts) / int64(time.Millisecond)
+	}
+	return l
+}
+
 func (t *metricTransformer) Timestamp(ts *timestamppb.Timestamp) time.Time {
 	if ts == nil {
 		return time.Time{}
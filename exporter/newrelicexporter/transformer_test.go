@@ -336,6 +336,76 @@ func TestTransformSpan(t *testing.T) {
 	}
 }
 
+func TestTransformLog(t *testing.T) {
+	transform := newLogTransformer(pdata.NewResource(), pdata.NewInstrumentationLibrary())
+
+	tests := []struct {
+		name    string
+		logFunc func() pdata.LogRecord
+		want    nrLog
+	}{
+		{
+			name: "minimal",
+			logFunc: func() pdata.LogRecord {
+				l := pdata.NewLogRecord()
+				l.Body().SetStringVal("minimal")
+				return l
+			},
+			want: nrLog{
+				Message:    "minimal",
+				Attributes: map[string]interface{}{},
+			},
+		},
+		{
+			name: "name without body",
+			logFunc: func() pdata.LogRecord {
+				l := pdata.NewLogRecord()
+				l.SetName("nameless body")
+				return l
+			},
+			want: nrLog{
+				Message:    "nameless body",
+				Attributes: map[string]interface{}{logNameKey: "nameless body"},
+			},
+		},
+		{
+			name: "full",
+			logFunc: func() pdata.LogRecord {
+				l := pdata.NewLogRecord()
+				l.SetName("full")
+				l.Body().SetStringVal("full log")
+				l.SetTimestamp(pdata.TimestampUnixNano(time.Unix(100, 5e6).UnixNano()))
+				l.SetTraceID(pdata.NewTraceID([...]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}))
+				l.SetSpanID(pdata.NewSpanID([...]byte{0, 0, 0, 0, 0, 0, 0, 1}))
+				l.SetSeverityText("WARN")
+				l.SetSeverityNumber(pdata.SeverityNumberWARN)
+				l.Attributes().InsertString("prod", "true")
+				l.Attributes().InsertInt("weight", 3)
+				return l
+			},
+			want: nrLog{
+				Timestamp: 100005,
+				Message:   "full log",
+				Attributes: map[string]interface{}{
+					logNameKey:        "full",
+					logTraceIDKey:     "01010101010101010101010101010101",
+					logSpanIDKey:      "0000000000000001",
+					logLevelKey:       "WARN",
+					logLevelNumberKey: int32(pdata.SeverityNumberWARN),
+					"prod":            "true",
+					"weight":          int64(3),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, transform.Log(test.logFunc()))
+		})
+	}
+}
+
 func TestMergeAttributesIncompatibleLenghts(t *testing.T) {
 	transform := &metricTransformer{}
 	lk := make([]*metricspb.LabelKey, 2)
@@ -61,6 +61,8 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 	idMap := make(map[string]string)
 	// Maps root span id to a transaction.
 	transactionMap := make(map[string]*sentry.Event)
+	// Error events created from the exception events of the spans.
+	var exceptionEvents []*sentry.Event
 
 	for i := 0; i < resourceSpans.Len(); i++ {
 		rs := resourceSpans.At(i)
@@ -73,7 +75,9 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 
 			spans := ils.Spans()
 			for k := 0; k < spans.Len(); k++ {
-				sentrySpan := convertToSentrySpan(spans.At(k), library, resourceTags)
+				otelSpan := spans.At(k)
+				sentrySpan := convertToSentrySpan(otelSpan, library, resourceTags)
+				exceptionEvents = append(exceptionEvents, convertEventsToSentryExceptions(otelSpan.Events(), sentrySpan)...)
 
 				// If a span is a root span, we consider it the start of a Sentry transaction.
 				// We should then create a new transaction for that root span, and keep track of it.
@@ -95,17 +99,21 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 		}
 	}
 
-	if len(transactionMap) == 0 {
-		return 0, nil
-	}
+	var events []*sentry.Event
+	if len(transactionMap) > 0 {
+		// After the first pass through, we can't necessarily make the assumption we have not associated all
+		// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
+		orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
 
-	// After the first pass through, we can't necessarily make the assumption we have not associated all
-	// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
-	orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
+		events = generateTransactions(transactionMap, orphanSpans)
+	}
+	events = append(events, exceptionEvents...)
 
-	transactions := generateTransactions(transactionMap, orphanSpans)
+	if len(events) == 0 {
+		return 0, nil
+	}
 
-	s.transport.SendTransactions(transactions)
+	s.transport.SendEvents(events)
 
 	return 0, nil
 }
@@ -295,6 +303,73 @@ func statusFromSpanStatus(spanStatus pdata.SpanStatus) (status string, message s
 	return canonicalCodes[code], spanStatus.Message()
 }
 
+// convertEventsToSentryExceptions creates a Sentry error event for each exception event of a span.
+// The error events are associated with the transaction of the span through its trace context.
+//
+// See https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/exceptions.md
+// for more details about exception events.
+func convertEventsToSentryExceptions(events pdata.SpanEventSlice, sentrySpan *sentry.Span) []*sentry.Event {
+	var errorEvents []*sentry.Event
+
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() != conventions.AttributeExceptionEventName {
+			continue
+		}
+
+		var exceptionType, message, stacktrace string
+		event.Attributes().ForEach(func(key string, attr pdata.AttributeValue) {
+			switch key {
+			case conventions.AttributeExceptionType:
+				exceptionType = attr.StringVal()
+			case conventions.AttributeExceptionMessage:
+				message = attr.StringVal()
+			case conventions.AttributeExceptionStacktrace:
+				stacktrace = attr.StringVal()
+			}
+		})
+		// Either the type or the message of the exception is required by the semantic conventions.
+		if exceptionType == "" && message == "" {
+			continue
+		}
+
+		errorEvent := sentry.NewEvent()
+		errorEvent.EventID = generateEventID()
+		errorEvent.Level = sentry.LevelError
+		errorEvent.Exception = []sentry.Exception{{
+			Type:       exceptionType,
+			Value:      message,
+			Stacktrace: parseStacktrace(stacktrace),
+		}}
+
+		errorEvent.Contexts["trace"] = sentry.TraceContext{
+			TraceID: sentrySpan.TraceID,
+			SpanID:  sentrySpan.SpanID,
+			Op:      sentrySpan.Op,
+			Status:  sentrySpan.Status,
+		}
+
+		errorEvent.Sdk.Name = otelSentryExporterName
+		errorEvent.Sdk.Version = otelSentryExporterVersion
+
+		for k, v := range sentrySpan.Tags {
+			errorEvent.Tags[k] = v
+		}
+		errorEvent.Environment, errorEvent.Release = environmentAndRelease(sentrySpan)
+		errorEvent.Timestamp = unixNanoToTime(event.Timestamp())
+
+		errorEvents = append(errorEvents, errorEvent)
+	}
+
+	return errorEvents
+}
+
+// environmentAndRelease returns the Sentry environment and release of a span, from the
+// deployment.environment and service.version resource attributes.
+func environmentAndRelease(span *sentry.Span) (environment string, release string) {
+	return span.Tags[conventions.AttributeDeploymentEnvironment], span.Tags[conventions.AttributeServiceVersion]
+}
+
 // isRootSpan determines if a span is a root span.
 // If parent span id is empty, then the span is a root span.
 func isRootSpan(s *sentry.Span) bool {
@@ -317,6 +392,7 @@ func transactionFromSpan(span *sentry.Span) *sentry.Event {
 	transaction.Sdk.Name = otelSentryExporterName
 	transaction.Sdk.Version = otelSentryExporterVersion
 
+	transaction.Environment, transaction.Release = environmentAndRelease(span)
 	transaction.StartTimestamp = span.StartTimestamp
 	transaction.Tags = span.Tags
 	transaction.Timestamp = span.EndTimestamp
@@ -475,13 +475,86 @@ func TestGenerateTransactions(t *testing.T) {
 	assert.Len(t, transactions, 4)
 }
 
+func TestConvertEventsToSentryExceptions(t *testing.T) {
+	sentrySpan := &sentry.Span{
+		TraceID: "d6c4f03650bd47699ec65c84352b6208",
+		SpanID:  "1cc4b26ab9094ef0",
+		Op:      "http.server",
+		Status:  "unknown",
+		Tags: map[string]string{
+			"span_kind":                                "server",
+			conventions.AttributeDeploymentEnvironment: "production",
+			conventions.AttributeServiceVersion:        "1.2.3",
+		},
+	}
+
+	events := pdata.NewSpanEventSlice()
+	events.Resize(3)
+	exception := events.At(0)
+	exception.SetName(conventions.AttributeExceptionEventName)
+	exception.SetTimestamp(123)
+	exception.Attributes().InsertString(conventions.AttributeExceptionType, "ValueError")
+	exception.Attributes().InsertString(conventions.AttributeExceptionMessage, "invalid user id")
+	exception.Attributes().InsertString(conventions.AttributeExceptionStacktrace,
+		"Traceback (most recent call last):\n  File \"/app/main.py\", line 5, in get_user\nValueError: invalid user id")
+	// Events other than exceptions are ignored
+	events.At(1).SetName("message")
+	// So are exceptions without type and message
+	events.At(2).SetName(conventions.AttributeExceptionEventName)
+
+	errorEvents := convertEventsToSentryExceptions(events, sentrySpan)
+	assert.Len(t, errorEvents, 1)
+
+	errorEvent := errorEvents[0]
+	assert.Len(t, errorEvent.EventID, 32)
+	assert.Equal(t, sentry.LevelError, errorEvent.Level)
+	assert.Equal(t, []sentry.Exception{{
+		Type:  "ValueError",
+		Value: "invalid user id",
+		Stacktrace: &sentry.Stacktrace{Frames: []sentry.Frame{
+			{Function: "get_user", Filename: "/app/main.py", AbsPath: "/app/main.py", Lineno: 5},
+		}},
+	}}, errorEvent.Exception)
+	assert.Equal(t, sentry.TraceContext{
+		TraceID: "d6c4f03650bd47699ec65c84352b6208",
+		SpanID:  "1cc4b26ab9094ef0",
+		Op:      "http.server",
+		Status:  "unknown",
+	}, errorEvent.Contexts["trace"])
+	assert.Equal(t, sentrySpan.Tags, errorEvent.Tags)
+	assert.Equal(t, "production", errorEvent.Environment)
+	assert.Equal(t, "1.2.3", errorEvent.Release)
+	assert.Equal(t, otelSentryExporterName, errorEvent.Sdk.Name)
+	assert.Equal(t, unixNanoToTime(123), errorEvent.Timestamp)
+	assert.Empty(t, errorEvent.Type)
+}
+
+func TestTransactionFromSpanEnvironmentAndRelease(t *testing.T) {
+	span := &sentry.Span{
+		TraceID: "d6c4f03650bd47699ec65c84352b6208",
+		SpanID:  "1cc4b26ab9094ef0",
+		Tags: map[string]string{
+			conventions.AttributeDeploymentEnvironment: "staging",
+			conventions.AttributeServiceVersion:        "2.0.0",
+		},
+	}
+
+	transaction := transactionFromSpan(span)
+	assert.Equal(t, "staging", transaction.Environment)
+	assert.Equal(t, "2.0.0", transaction.Release)
+
+	transaction = transactionFromSpan(orphanSpan1)
+	assert.Empty(t, transaction.Environment)
+	assert.Empty(t, transaction.Release)
+}
+
 type mockTransport struct {
-	called       bool
-	transactions []*sentry.Event
+	called bool
+	events []*sentry.Event
 }
 
-func (t *mockTransport) SendTransactions(transactions []*sentry.Event) {
-	t.transactions = transactions
+func (t *mockTransport) SendEvents(events []*sentry.Event) {
+	t.events = events
 	t.called = true
 }
 
@@ -538,6 +611,24 @@ func TestPushTraceData(t *testing.T) {
 			}(),
 			called: true,
 		},
+		{
+			testName: "with exception event of a child span",
+			td: func() pdata.Traces {
+				traces := pdata.NewTraces()
+				resourceSpans := traces.ResourceSpans()
+				resourceSpans.Resize(1)
+				resourceSpans.At(0).InstrumentationLibrarySpans().Resize(1)
+				spans := resourceSpans.At(0).InstrumentationLibrarySpans().At(0).Spans()
+				spans.Resize(1)
+				spans.At(0).SetParentSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+				spans.At(0).Events().Resize(1)
+				event := spans.At(0).Events().At(0)
+				event.SetName(conventions.AttributeExceptionEventName)
+				event.Attributes().InsertString(conventions.AttributeExceptionMessage, "boom")
+				return traces
+			}(),
+			called: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentryexporter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+)
+
+var (
+	// javaFrameRegexp matches a frame of a Java stacktrace, ex. "at com.example.Main.run(Main.java:42)".
+	javaFrameRegexp = regexp.MustCompile(`^\s*at ([^\s(]+)\.([^\s.(]+)\(([^:)]*)(?::(\d+))?\)\s*$`)
+	// javascriptFrameRegexp matches a frame of a JavaScript stacktrace, ex. "at handler (/app/index.js:10:5)"
+	// or "at /app/index.js:10:5".
+	javascriptFrameRegexp = regexp.MustCompile(`^\s*at (?:(.+) \()?([^()\s]+):(\d+):(\d+)\)?\s*$`)
+	// pythonFrameRegexp matches a frame of a Python traceback, ex. `File "/app/main.py", line 10, in handler`.
+	pythonFrameRegexp = regexp.MustCompile(`^\s*File "([^"]+)", line (\d+)(?:, in (.+))?\s*$`)
+	// goFunctionRegexp and goFileRegexp match the two lines of a frame of a Go stacktrace,
+	// ex. "main.handler(0x1, 0x2)" followed by "\t/app/main.go:10 +0x1d".
+	goFunctionRegexp = regexp.MustCompile(`^(\S+)\(.*\)$`)
+	goFileRegexp     = regexp.MustCompile(`^\t(\S+):(\d+)(?: \+0x[0-9a-f]+)?$`)
+)
+
+// parseStacktrace parses the frames of a stacktrace from the exception.stacktrace attribute
+// of an exception span event. Java, JavaScript, Python and Go stacktraces are supported.
+// It returns nil if no frame can be parsed.
+func parseStacktrace(stacktrace string) *sentry.Stacktrace {
+	var frames []sentry.Frame
+	// Sentry frames are ordered from the oldest call to the most recent one, as Python
+	// frames are. The frames of the other languages are in the opposite order.
+	mostRecentFirst := true
+	lines := strings.Split(strings.ReplaceAll(stacktrace, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if m := javaFrameRegexp.FindStringSubmatch(line); m != nil {
+			frame := sentry.Frame{
+				Module:   m[1],
+				Function: m[2],
+				Filename: m[3],
+			}
+			frame.Lineno, _ = strconv.Atoi(m[4])
+			frames = append(frames, frame)
+		} else if m := javascriptFrameRegexp.FindStringSubmatch(line); m != nil {
+			frame := sentry.Frame{
+				Function: m[1],
+				Filename: m[2],
+				AbsPath:  m[2],
+			}
+			frame.Lineno, _ = strconv.Atoi(m[3])
+			frame.Colno, _ = strconv.Atoi(m[4])
+			frames = append(frames, frame)
+		} else if m := pythonFrameRegexp.FindStringSubmatch(line); m != nil {
+			frame := sentry.Frame{
+				Function: m[3],
+				Filename: m[1],
+				AbsPath:  m[1],
+			}
+			frame.Lineno, _ = strconv.Atoi(m[2])
+			frames = append(frames, frame)
+			mostRecentFirst = false
+		} else if m := goFileRegexp.FindStringSubmatch(line); m != nil && i > 0 {
+			function := goFunctionRegexp.FindStringSubmatch(lines[i-1])
+			if function == nil {
+				continue
+			}
+			frame := sentry.Frame{
+				Function: function[1],
+				Filename: m[1],
+				AbsPath:  m[1],
+			}
+			frame.Lineno, _ = strconv.Atoi(m[2])
+			frames = append(frames, frame)
+		}
+	}
+
+	if len(frames) == 0 {
+		return nil
+	}
+
+	if mostRecentFirst {
+		for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+			frames[i], frames[j] = frames[j], frames[i]
+		}
+	}
+
+	return &sentry.Stacktrace{Frames: frames}
+}
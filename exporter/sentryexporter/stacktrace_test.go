@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentryexporter
+
+import (
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStacktrace(t *testing.T) {
+	testCases := []struct {
+		testName   string
+		stacktrace string
+		expected   *sentry.Stacktrace
+	}{
+		{
+			testName: "java",
+			stacktrace: "java.lang.IllegalStateException: boom\n" +
+				"\tat com.example.Service.handle(Service.java:42)\n" +
+				"\tat java.base/java.lang.Thread.run(Thread.java:834)\n" +
+				"\tat sun.reflect.NativeMethodAccessorImpl.invoke0(Native Method)\n",
+			expected: &sentry.Stacktrace{Frames: []sentry.Frame{
+				{Module: "sun.reflect.NativeMethodAccessorImpl", Function: "invoke0", Filename: "Native Method"},
+				{Module: "java.base/java.lang.Thread", Function: "run", Filename: "Thread.java", Lineno: 834},
+				{Module: "com.example.Service", Function: "handle", Filename: "Service.java", Lineno: 42},
+			}},
+		},
+		{
+			testName: "javascript",
+			stacktrace: "Error: boom\n" +
+				"    at handler (/app/routes/users.js:10:5)\n" +
+				"    at /app/index.js:3:12",
+			expected: &sentry.Stacktrace{Frames: []sentry.Frame{
+				{Filename: "/app/index.js", AbsPath: "/app/index.js", Lineno: 3, Colno: 12},
+				{Function: "handler", Filename: "/app/routes/users.js", AbsPath: "/app/routes/users.js", Lineno: 10, Colno: 5},
+			}},
+		},
+		{
+			testName: "python",
+			stacktrace: "Traceback (most recent call last):\n" +
+				"  File \"/app/main.py\", line 10, in <module>\n" +
+				"    main()\n" +
+				"  File \"/app/main.py\", line 5, in main\n" +
+				"    raise ValueError(\"boom\")\n" +
+				"ValueError: boom",
+			expected: &sentry.Stacktrace{Frames: []sentry.Frame{
+				{Function: "<module>", Filename: "/app/main.py", AbsPath: "/app/main.py", Lineno: 10},
+				{Function: "main", Filename: "/app/main.py", AbsPath: "/app/main.py", Lineno: 5},
+			}},
+		},
+		{
+			testName: "go",
+			stacktrace: "goroutine 1 [running]:\n" +
+				"main.handler(0x1, 0x2)\n" +
+				"\t/app/main.go:10 +0x1d\n" +
+				"main.main()\n" +
+				"\t/app/main.go:20 +0x25\n",
+			expected: &sentry.Stacktrace{Frames: []sentry.Frame{
+				{Function: "main.main", Filename: "/app/main.go", AbsPath: "/app/main.go", Lineno: 20},
+				{Function: "main.handler", Filename: "/app/main.go", AbsPath: "/app/main.go", Lineno: 10},
+			}},
+		},
+		{
+			testName:   "unknown format",
+			stacktrace: "something went wrong",
+			expected:   nil,
+		},
+		{
+			testName:   "empty",
+			stacktrace: "",
+			expected:   nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseStacktrace(test.stacktrace))
+		})
+	}
+}
@@ -15,8 +15,11 @@
 package sentryexporter
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
+	"github.com/getsentry/sentry-go"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -25,3 +28,14 @@ import (
 func unixNanoToTime(u pdata.TimestampUnixNano) time.Time {
 	return time.Unix(0, int64(u)).UTC()
 }
+
+// generateEventID generates a random Sentry event ID, a 32 characters long
+// lowercase hexadecimal string. It returns an empty ID, which lets Sentry
+// generate one, if no random bytes can be read.
+func generateEventID() sentry.EventID {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return ""
+	}
+	return sentry.EventID(hex.EncodeToString(id[:]))
+}
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/propertysync"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -86,6 +87,11 @@ type Config struct {
 	//            And keep `override=true` in resourcedetection config.
 	SyncHostMetadata bool `mapstructure:"sync_host_metadata"`
 
+	// DimensionPropertySync defines resource attributes of the metrics to be synced as
+	// properties and tags of SignalFx dimensions, e.g. labels of the pods added by
+	// `k8s_tagger` processor to the "k8s.pod.uid" dimension.
+	DimensionPropertySync propertysync.Config `mapstructure:"dimension_property_sync"`
+
 	// ExcludeMetrics defines dpfilter.MetricFilters that will determine metrics to be
 	// excluded from sending to SignalFx backend. If translations enabled with
 	// SendCompatibleMetrics or TranslationRules options, the exclusion will be applied
@@ -152,6 +158,10 @@ func (cfg *Config) validateConfig() error {
 		return errors.New("cannot have a negative \"timeout\"")
 	}
 
+	if err := cfg.DimensionPropertySync.Validate(); err != nil {
+		return fmt.Errorf("invalid \"dimension_property_sync\": %v", err)
+	}
+
 	return nil
 }
 
@@ -33,6 +33,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/propertysync"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -136,6 +137,22 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		DeltaTranslationTTL: 3600,
+		DimensionPropertySync: propertysync.Config{
+			Rules: []propertysync.Rule{
+				{
+					DimensionAttribute: "k8s.pod.uid",
+					Properties:         map[string]string{"k8s.deployment.name": "deployment"},
+					PropertyPrefixes:   map[string]string{"k8s.pod.labels.": "pod_label_"},
+				},
+				{
+					DimensionAttribute: "host.id",
+					DimensionName:      "AWSUniqueId",
+					Tags:               []string{"cloud.zone"},
+				},
+			},
+			CacheTTL:  30 * time.Minute,
+			CacheFile: "/var/lib/otelcol/signalfx_properties.json",
+		},
 		Correlation: &correlation.Config{
 			HTTPClientSettings: confighttp.HTTPClientSettings{
 				Endpoint: "",
@@ -178,6 +195,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		SendCompatibleMetrics bool
 		TranslationRules      []translation.Rule
 		SyncHostMetadata      bool
+		DimensionPropertySync propertysync.Config
 	}
 	tests := []struct {
 		name    string
@@ -282,6 +300,22 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test invalid dimension property sync",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				DimensionPropertySync: propertysync.Config{
+					Rules: []propertysync.Rule{
+						{
+							DimensionAttribute: "k8s.pod.uid",
+						},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -298,6 +332,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				SendCompatibleMetrics: tt.fields.SendCompatibleMetrics,
 				TranslationRules:      tt.fields.TranslationRules,
 				SyncHostMetadata:      tt.fields.SyncHostMetadata,
+				DimensionPropertySync: tt.fields.DimensionPropertySync,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
@@ -138,13 +138,16 @@ func (dc *DimensionClient) acceptDimension(dimUpdate *DimensionUpdate) error {
 	defer dc.Unlock()
 
 	if delayedDimUpdate := dc.delayedSet[dimUpdate.Key()]; delayedDimUpdate != nil {
-		if !reflect.DeepEqual(delayedDimUpdate, dimUpdate) {
+		if !reflect.DeepEqual(delayedDimUpdate.Properties, dimUpdate.Properties) ||
+			!reflect.DeepEqual(delayedDimUpdate.Tags, dimUpdate.Tags) {
 			dc.TotalFlappyUpdates++
 
 			// Merge the latest updates into existing one.
 			delayedDimUpdate.Properties = mergeProperties(delayedDimUpdate.Properties, dimUpdate.Properties)
 			delayedDimUpdate.Tags = mergeTags(delayedDimUpdate.Tags, dimUpdate.Tags)
 		}
+		// The result of the merged update is the result of both
+		delayedDimUpdate.callbacks = append(delayedDimUpdate.callbacks, dimUpdate.callbacks...)
 	} else {
 		atomic.AddInt64(&dc.DimensionsCurrentlyDelayed, int64(1))
 
@@ -158,7 +161,10 @@ func (dc *DimensionClient) acceptDimension(dimUpdate *DimensionUpdate) error {
 		default:
 			dc.TotalDimensionsDropped++
 			atomic.AddInt64(&dc.DimensionsCurrentlyDelayed, int64(-1))
-			return errors.New("dropped dimension update, propertiesMaxBuffered exceeded")
+			delete(dc.delayedSet, dimUpdate.Key())
+			err := errors.New("dropped dimension update, propertiesMaxBuffered exceeded")
+			dimUpdate.done(err)
+			return err
 		}
 	}
 
@@ -211,6 +217,7 @@ func (dc *DimensionClient) processQueue() {
 			dc.Unlock()
 
 			if err := dc.handleDimensionUpdate(delayedDimUpdate.DimensionUpdate); err != nil {
+				delayedDimUpdate.done(err)
 				dc.logger.Error(
 					"Could not send dimension update",
 					zap.Error(err),
@@ -250,6 +257,7 @@ func (dc *DimensionClient) handleDimensionUpdate(dimUpdate *DimensionUpdate) err
 				// by retrying.
 				// 404 errors are special because they can occur due to races
 				// within the dimension patch endpoint.
+				dimUpdate.done(err)
 				return
 			}
 
@@ -276,6 +284,7 @@ func (dc *DimensionClient) handleDimensionUpdate(dimUpdate *DimensionUpdate) err
 
 	req = req.WithContext(
 		context.WithValue(req.Context(), RequestSuccessCallbackKey, RequestSuccessCallback(func([]byte) {
+			dimUpdate.done(nil)
 			if dc.logUpdates {
 				dc.logger.Info(
 					"Updated dimension",
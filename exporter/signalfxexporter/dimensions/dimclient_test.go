@@ -366,6 +366,43 @@ func TestFlappyUpdates(t *testing.T) {
 	require.Equal(t, int64(0), atomic.LoadInt64(&client.requestSender.TotalRequestsFailed))
 }
 
+func TestUpdateCallbacks(t *testing.T) {
+	client, dimCh, forcedResp, cancel := setup(t)
+	defer cancel()
+
+	results := make(chan error, 10)
+	newUpdate := func(value string) *DimensionUpdate {
+		return &DimensionUpdate{
+			Name:       "pod_uid",
+			Value:      value,
+			Properties: map[string]*string{"a": newString(value)},
+			callbacks:  []func(error){func(err error) { results <- err }},
+		}
+	}
+	waitForResult := func() error {
+		select {
+		case err := <-results:
+			return err
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "no result for the dimension update")
+			return nil
+		}
+	}
+
+	// The callbacks of merged updates are all called once the update is sent
+	require.NoError(t, client.acceptDimension(newUpdate("abcd")))
+	require.NoError(t, client.acceptDimension(newUpdate("abcd")))
+	require.Len(t, waitForDims(dimCh, 1, 3), 1)
+	require.NoError(t, waitForResult())
+	require.NoError(t, waitForResult())
+
+	// Updates that are not retried fail
+	forcedResp.Store(400)
+	require.NoError(t, client.acceptDimension(newUpdate("efgh")))
+	require.Error(t, waitForResult())
+	require.Len(t, results, 0)
+}
+
 func TestInvalidUpdatesNotSent(t *testing.T) {
 	client, dimCh, _, cancel := setup(t)
 	defer cancel()
@@ -24,6 +24,8 @@ type DimensionUpdate struct {
 	Value      string
 	Properties map[string]*string
 	Tags       map[string]bool
+	// callbacks are called with the result of sending the update
+	callbacks []func(error)
 }
 
 func (d *DimensionUpdate) String() string {
@@ -39,6 +41,13 @@ func (d *DimensionUpdate) String() string {
 	return fmt.Sprintf("{name: %q; value: %q; props: %v; tags: %v}", d.Name, d.Value, props, d.Tags)
 }
 
+// done calls the callbacks of the update with the result of sending it.
+func (d *DimensionUpdate) done(err error) {
+	for _, cb := range d.callbacks {
+		cb(err)
+	}
+}
+
 func (d *DimensionUpdate) Key() DimensionKey {
 	return DimensionKey{
 		Name:  d.Name,
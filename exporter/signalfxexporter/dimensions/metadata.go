@@ -30,6 +30,17 @@ type MetadataUpdateClient interface {
 	PushMetadata([]*metrics.MetadataUpdate) error
 }
 
+// MetadataUpdateCallback is called with the result of sending a metadata update,
+// a nil error if the update was applied.
+type MetadataUpdateCallback func(update *metrics.MetadataUpdate, err error)
+
+// ConfirmingMetadataUpdateClient is a MetadataUpdateClient that also reports the
+// result of sending each of the updates it accepted.
+type ConfirmingMetadataUpdateClient interface {
+	MetadataUpdateClient
+	PushMetadataWithCallback([]*metrics.MetadataUpdate, MetadataUpdateCallback) error
+}
+
 var propNameSanitizer = strings.NewReplacer(
 	".", "_",
 	"/", "_")
@@ -111,6 +122,14 @@ func getPropertiesAndTags(
 }
 
 func (dc *DimensionClient) PushMetadata(metadata []*metrics.MetadataUpdate) error {
+	return dc.PushMetadataWithCallback(metadata, nil)
+}
+
+// PushMetadataWithCallback queues the metadata updates like PushMetadata, and
+// calls done once each of the updates that were accepted has been sent to the
+// API, or could not be. Updates are sent asynchronously and retried, so a nil
+// error returned does not mean the updates were applied.
+func (dc *DimensionClient) PushMetadataWithCallback(metadata []*metrics.MetadataUpdate, done MetadataUpdateCallback) error {
 	var errs []error
 	for _, m := range metadata {
 		dimensionUpdate := getDimensionUpdateFromMetadata(*m, dc.metricTranslator)
@@ -120,6 +139,11 @@ func (dc *DimensionClient) PushMetadata(metadata []*metrics.MetadataUpdate) erro
 			return fmt.Errorf("dimensionUpdate %v is missing Name or value, cannot send", dimensionUpdate)
 		}
 
+		if done != nil {
+			m := m
+			dimensionUpdate.callbacks = []func(error){func(err error) { done(m, err) }}
+		}
+
 		if err := dc.acceptDimension(dimensionUpdate); err != nil {
 			errs = append(errs, err)
 		}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/dimensions"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/hostmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/propertysync"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/metrics"
 )
@@ -62,6 +63,7 @@ type signalfxExporter struct {
 	pushMetadata       func(metadata []*metrics.MetadataUpdate) error
 	pushLogsData       func(ctx context.Context, ld pdata.Logs) (droppedLogRecords int, err error)
 	hostMetadataSyncer *hostmetadata.Syncer
+	propertySyncer     *propertysync.Syncer
 }
 
 type exporterOptions struct {
@@ -134,11 +136,17 @@ func newSignalFxExporter(
 		hms = hostmetadata.NewSyncer(logger, dimClient)
 	}
 
+	var ps *propertysync.Syncer
+	if config.DimensionPropertySync.Enabled() {
+		ps = propertysync.NewSyncer(logger, config.DimensionPropertySync, dimClient)
+	}
+
 	return &signalfxExporter{
 		logger:             logger,
 		pushMetricsData:    dpClient.pushMetricsData,
 		pushMetadata:       dimClient.PushMetadata,
 		hostMetadataSyncer: hms,
+		propertySyncer:     ps,
 	}, nil
 }
 
@@ -187,9 +195,19 @@ func (se *signalfxExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (
 	if err == nil && se.hostMetadataSyncer != nil {
 		se.hostMetadataSyncer.Sync(md)
 	}
+	if err == nil && se.propertySyncer != nil {
+		se.propertySyncer.Sync(md)
+	}
 	return numDroppedTimeSeries, err
 }
 
+func (se *signalfxExporter) shutdown(ctx context.Context) error {
+	if se.propertySyncer != nil {
+		return se.propertySyncer.Shutdown(ctx)
+	}
+	return nil
+}
+
 func (se *signalfxExporter) pushLogs(ctx context.Context, ld pdata.Logs) (int, error) {
 	return se.pushLogsData(ctx, ld)
 }
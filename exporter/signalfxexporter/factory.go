@@ -28,6 +28,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/propertysync"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -68,6 +69,7 @@ func createDefaultConfig() configmodels.Exporter {
 		TranslationRules:      nil,
 		DeltaTranslationTTL:   3600,
 		Correlation:           correlation.DefaultConfig(),
+		DimensionPropertySync: propertysync.DefaultConfig(),
 	}
 }
 
@@ -128,7 +130,8 @@ func createMetricsExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(expCfg.RetrySettings),
-		exporterhelper.WithQueue(expCfg.QueueSettings))
+		exporterhelper.WithQueue(expCfg.QueueSettings),
+		exporterhelper.WithShutdown(exp.shutdown))
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propertysync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cache remembers the properties and tags synced to each dimension, so that
+// only the ones that changed are sent again.
+type cache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[dimension]*cacheEntry
+	// lastSweep is the last time expired entries were removed
+	lastSweep time.Time
+}
+
+type dimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cacheEntry struct {
+	Properties map[string]string `json:"properties"`
+	Tags       map[string]bool   `json:"tags"`
+	SyncedAt   time.Time         `json:"synced_at"`
+}
+
+// persistedEntry is the representation of an entry in the cache file.
+type persistedEntry struct {
+	dimension
+	cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:       ttl,
+		entries:   make(map[dimension]*cacheEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// delta returns the properties and tags of the update that were not synced to the dimension
+// with the same values, and records them as synced.
+func (c *cache) delta(dim dimension, properties map[string]string, tags map[string]bool, now time.Time) (map[string]string, map[string]bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if now.Sub(c.lastSweep) > c.ttl {
+		c.sweep(now)
+	}
+
+	entry, ok := c.entries[dim]
+	if !ok || c.expired(entry, now) {
+		entry = &cacheEntry{
+			Properties: make(map[string]string),
+			Tags:       make(map[string]bool),
+			SyncedAt:   now,
+		}
+		c.entries[dim] = entry
+	}
+
+	newProperties := make(map[string]string)
+	for k, v := range properties {
+		if synced, ok := entry.Properties[k]; !ok || synced != v {
+			newProperties[k] = v
+			entry.Properties[k] = v
+		}
+	}
+	newTags := make(map[string]bool)
+	for tag := range tags {
+		if !entry.Tags[tag] {
+			newTags[tag] = true
+			entry.Tags[tag] = true
+		}
+	}
+	return newProperties, newTags
+}
+
+// forget removes the dimension from the cache, so that all its properties and tags are sent again.
+func (c *cache) forget(dim dimension) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, dim)
+}
+
+func (c *cache) expired(entry *cacheEntry, now time.Time) bool {
+	return now.Sub(entry.SyncedAt) > c.ttl
+}
+
+func (c *cache) sweep(now time.Time) {
+	for dim, entry := range c.entries {
+		if c.expired(entry, now) {
+			delete(c.entries, dim)
+		}
+	}
+	c.lastSweep = now
+}
+
+// load reads the entries of the cache file, a missing file is ignored.
+func (c *cache) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var persisted []persistedEntry
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := time.Now()
+	for i := range persisted {
+		entry := persisted[i].cacheEntry
+		if c.expired(&entry, now) {
+			continue
+		}
+		if entry.Properties == nil {
+			entry.Properties = make(map[string]string)
+		}
+		if entry.Tags == nil {
+			entry.Tags = make(map[string]bool)
+		}
+		c.entries[persisted[i].dimension] = &entry
+	}
+	return nil
+}
+
+// save writes the entries that did not expire to the cache file.
+func (c *cache) save(path string) error {
+	c.mutex.Lock()
+	c.sweep(time.Now())
+	persisted := make([]persistedEntry, 0, len(c.entries))
+	for dim, entry := range c.entries {
+		persisted = append(persisted, persistedEntry{dimension: dim, cacheEntry: *entry})
+	}
+	data, err := json.Marshal(persisted)
+	c.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that the cache file is never partially written
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propertysync
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultConfig returns the default configuration of the dimension property sync.
+func DefaultConfig() Config {
+	return Config{
+		CacheTTL: time.Hour,
+	}
+}
+
+// Config defines configuration for syncing resource attributes of the metrics
+// as properties and tags of SignalFx dimensions. The sync is disabled if no rule is set.
+type Config struct {
+	// Rules defines the dimensions to update and the resource attributes to sync to them.
+	Rules []Rule `mapstructure:"rules"`
+
+	// CacheTTL is how long the synced properties and tags are remembered. They are not
+	// sent again during this time unless their values change. Default is 1h.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+
+	// CacheFile is the path of a file the synced properties and tags are persisted to
+	// on shutdown, and loaded from on startup, so they are not sent again after a restart.
+	// They are not persisted if empty.
+	CacheFile string `mapstructure:"cache_file"`
+}
+
+// Rule defines the resource attributes synced to the dimension identified by a resource attribute.
+type Rule struct {
+	// DimensionAttribute is the resource attribute whose value identifies the dimension
+	// to update, e.g. "k8s.pod.uid". The rule is skipped for resources without it.
+	DimensionAttribute string `mapstructure:"dimension_attribute"`
+
+	// DimensionName is the name of the dimension to update, the key of DimensionAttribute by default.
+	DimensionName string `mapstructure:"dimension_name"`
+
+	// Properties maps resource attributes to the names of the properties they are synced to.
+	// The key of the attribute is used if the name is empty.
+	Properties map[string]string `mapstructure:"properties"`
+
+	// PropertyPrefixes maps prefixes of resource attributes to the prefixes of the names of the
+	// properties they are synced to, e.g. "k8s.pod.labels." to "pod_label_" syncs all the
+	// attributes starting with "k8s.pod.labels." as properties starting with "pod_label_".
+	PropertyPrefixes map[string]string `mapstructure:"property_prefixes"`
+
+	// Tags are the resource attributes whose values are synced as tags of the dimension.
+	Tags []string `mapstructure:"tags"`
+}
+
+// Enabled returns whether the dimension property sync is enabled.
+func (c *Config) Enabled() bool {
+	return len(c.Rules) > 0
+}
+
+// Validate checks if the configuration is valid.
+func (c *Config) Validate() error {
+	if c.CacheTTL < 0 {
+		return errors.New("cannot have a negative \"cache_ttl\"")
+	}
+	for i, rule := range c.Rules {
+		if rule.DimensionAttribute == "" {
+			return fmt.Errorf("rule %d: requires a non-empty \"dimension_attribute\"", i+1)
+		}
+		if len(rule.Properties) == 0 && len(rule.PropertyPrefixes) == 0 && len(rule.Tags) == 0 {
+			return fmt.Errorf("rule %d: requires one of \"properties\", \"property_prefixes\" or \"tags\"", i+1)
+		}
+		for prefix := range rule.PropertyPrefixes {
+			if prefix == "" {
+				return fmt.Errorf("rule %d: \"property_prefixes\" cannot contain an empty prefix", i+1)
+			}
+		}
+	}
+	return nil
+}
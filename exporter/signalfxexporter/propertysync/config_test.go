@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propertysync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		wantError string
+	}{
+		{
+			name: "valid",
+			cfg:  testConfig(),
+		},
+		{
+			name:      "negative_cache_ttl",
+			cfg:       Config{CacheTTL: -time.Second},
+			wantError: `cannot have a negative "cache_ttl"`,
+		},
+		{
+			name:      "no_dimension_attribute",
+			cfg:       Config{Rules: []Rule{{Tags: []string{"cloud.zone"}}}},
+			wantError: `rule 1: requires a non-empty "dimension_attribute"`,
+		},
+		{
+			name:      "nothing_to_sync",
+			cfg:       Config{Rules: []Rule{{DimensionAttribute: "host.id"}}},
+			wantError: `rule 1: requires one of "properties", "property_prefixes" or "tags"`,
+		},
+		{
+			name:      "empty_prefix",
+			cfg:       Config{Rules: []Rule{{DimensionAttribute: "host.id", PropertyPrefixes: map[string]string{"": "attr_"}}}},
+			wantError: `rule 1: "property_prefixes" cannot contain an empty prefix`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantError)
+			}
+		})
+	}
+}
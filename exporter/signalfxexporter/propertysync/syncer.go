@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propertysync
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/dimensions"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/metrics"
+)
+
+// Syncer syncs resource attributes of the metrics as properties and tags of
+// SignalFx dimensions. Properties and tags are only added or updated, a
+// resource attribute that is no longer present does not remove them.
+type Syncer struct {
+	logger    *zap.Logger
+	cfg       Config
+	dimClient dimensions.ConfirmingMetadataUpdateClient
+	cache     *cache
+}
+
+// NewSyncer creates a new dimension property syncer, with the cache loaded
+// from the cache file if any.
+func NewSyncer(logger *zap.Logger, cfg Config, dimClient dimensions.ConfirmingMetadataUpdateClient) *Syncer {
+	s := &Syncer{
+		logger:    logger,
+		cfg:       cfg,
+		dimClient: dimClient,
+		cache:     newCache(cfg.CacheTTL),
+	}
+	if cfg.CacheFile != "" {
+		if err := s.cache.load(cfg.CacheFile); err != nil {
+			logger.Warn("Failed to load the dimension property cache, all properties will be synced",
+				zap.String("cache_file", cfg.CacheFile), zap.Error(err))
+		}
+	}
+	return s
+}
+
+// Sync sends the properties and tags of the resources of the metrics that were
+// not synced yet to their dimensions.
+func (s *Syncer) Sync(md pdata.Metrics) {
+	now := time.Now()
+	var updates []*metrics.MetadataUpdate
+	var dims []dimension
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		attrs := rms.At(i).Resource().Attributes()
+		for _, rule := range s.cfg.Rules {
+			dim, properties, tags, ok := ruleUpdate(rule, attrs)
+			if !ok {
+				continue
+			}
+			properties, tags = s.cache.delta(dim, properties, tags, now)
+			if len(properties) == 0 && len(tags) == 0 {
+				continue
+			}
+			updates = append(updates, newMetadataUpdate(dim, properties, tags))
+			dims = append(dims, dim)
+		}
+	}
+	if len(updates) == 0 {
+		return
+	}
+
+	// The updates are recorded as synced when they are queued, so that they are not queued
+	// again while being sent. An update that could not be sent is forgotten, so that all
+	// the properties of its dimension are synced again with the next metrics.
+	if err := s.dimClient.PushMetadataWithCallback(updates, s.updateSent); err != nil {
+		for _, dim := range dims {
+			s.cache.forget(dim)
+		}
+		s.logger.Error("Failed to push dimension property updates", zap.Error(err))
+	}
+}
+
+// updateSent forgets the dimension of an update that could not be sent.
+func (s *Syncer) updateSent(update *metrics.MetadataUpdate, err error) {
+	if err == nil {
+		return
+	}
+	s.cache.forget(dimension{Name: update.ResourceIDKey, Value: string(update.ResourceID)})
+	s.logger.Debug("Failed to send dimension property update, it will be synced again",
+		zap.String("dimension", update.ResourceIDKey), zap.Error(err))
+}
+
+// Shutdown persists the cache to the cache file if any.
+func (s *Syncer) Shutdown(context.Context) error {
+	if s.cfg.CacheFile == "" {
+		return nil
+	}
+	if err := s.cache.save(s.cfg.CacheFile); err != nil {
+		s.logger.Warn("Failed to save the dimension property cache",
+			zap.String("cache_file", s.cfg.CacheFile), zap.Error(err))
+	}
+	return nil
+}
+
+// ruleUpdate returns the dimension and the properties and tags the rule syncs for
+// the resource attributes. It returns false if the resource has no such dimension.
+func ruleUpdate(rule Rule, attrs pdata.AttributeMap) (dimension, map[string]string, map[string]bool, bool) {
+	dimValue, ok := attrs.Get(rule.DimensionAttribute)
+	if !ok {
+		return dimension{}, nil, nil, false
+	}
+	dim := dimension{
+		Name:  rule.DimensionName,
+		Value: tracetranslator.AttributeValueToString(dimValue, false),
+	}
+	if dim.Name == "" {
+		dim.Name = rule.DimensionAttribute
+	}
+	if dim.Value == "" {
+		return dimension{}, nil, nil, false
+	}
+
+	properties := make(map[string]string)
+	tags := make(map[string]bool)
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		value := tracetranslator.AttributeValueToString(v, false)
+		if value == "" {
+			return
+		}
+		if name, ok := rule.Properties[k]; ok {
+			if name == "" {
+				name = k
+			}
+			properties[name] = value
+		}
+		for prefix, namePrefix := range rule.PropertyPrefixes {
+			if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+				properties[namePrefix+strings.TrimPrefix(k, prefix)] = value
+			}
+		}
+	})
+	for _, k := range rule.Tags {
+		if v, ok := attrs.Get(k); ok {
+			if tag := tracetranslator.AttributeValueToString(v, false); tag != "" {
+				tags[tag] = true
+			}
+		}
+	}
+	return dim, properties, tags, true
+}
+
+func newMetadataUpdate(dim dimension, properties map[string]string, tags map[string]bool) *metrics.MetadataUpdate {
+	update := &metrics.MetadataUpdate{
+		ResourceIDKey: dim.Name,
+		ResourceID:    metrics.ResourceID(dim.Value),
+		MetadataDelta: metrics.MetadataDelta{
+			MetadataToAdd:    make(map[string]string),
+			MetadataToUpdate: properties,
+		},
+	}
+	// Tags are added as metadata without value
+	for tag := range tags {
+		update.MetadataToAdd[tag] = ""
+	}
+	return update
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propertysync
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/dimensions"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/metrics"
+)
+
+type fakeDimClient struct {
+	updates []*metrics.MetadataUpdate
+	err     error
+	// sendErr is the result of sending the updates
+	sendErr error
+}
+
+func (c *fakeDimClient) PushMetadata(updates []*metrics.MetadataUpdate) error {
+	return c.PushMetadataWithCallback(updates, nil)
+}
+
+func (c *fakeDimClient) PushMetadataWithCallback(updates []*metrics.MetadataUpdate, done dimensions.MetadataUpdateCallback) error {
+	c.updates = append(c.updates, updates...)
+	if c.err != nil {
+		return c.err
+	}
+	if done != nil {
+		for _, update := range updates {
+			done(update, c.sendErr)
+		}
+	}
+	return nil
+}
+
+func newTestMetrics(resourceAttrs ...map[string]pdata.AttributeValue) pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(len(resourceAttrs))
+	for i, attrs := range resourceAttrs {
+		md.ResourceMetrics().At(i).Resource().Attributes().InitFromMap(attrs)
+	}
+	return md
+}
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{
+			DimensionAttribute: "k8s.pod.uid",
+			Properties:         map[string]string{"k8s.deployment.name": "deployment", "k8s.namespace.name": ""},
+			PropertyPrefixes:   map[string]string{"k8s.pod.labels.": "pod_label_"},
+		},
+		{
+			DimensionAttribute: "host.id",
+			DimensionName:      "AWSUniqueId",
+			Tags:               []string{"cloud.zone"},
+		},
+	}
+	return cfg
+}
+
+func podAttributes(deployment string) map[string]pdata.AttributeValue {
+	return map[string]pdata.AttributeValue{
+		"k8s.pod.uid":          pdata.NewAttributeValueString("pod-uid"),
+		"k8s.deployment.name":  pdata.NewAttributeValueString(deployment),
+		"k8s.namespace.name":   pdata.NewAttributeValueString("default"),
+		"k8s.pod.labels.app":   pdata.NewAttributeValueString("nginx"),
+		"k8s.pod.labels.":      pdata.NewAttributeValueString("empty label name"),
+		"k8s.pod.labels.empty": pdata.NewAttributeValueString(""),
+		"other":                pdata.NewAttributeValueString("ignored"),
+	}
+}
+
+func TestSync(t *testing.T) {
+	client := &fakeDimClient{}
+	s := NewSyncer(zap.NewNop(), testConfig(), client)
+
+	s.Sync(newTestMetrics(
+		podAttributes("nginx-deployment"),
+		map[string]pdata.AttributeValue{
+			"host.id":    pdata.NewAttributeValueString("i-1234"),
+			"cloud.zone": pdata.NewAttributeValueString("us-east-1a"),
+		},
+		map[string]pdata.AttributeValue{
+			"host.name": pdata.NewAttributeValueString("no dimension"),
+		},
+	))
+	assert.Equal(t, []*metrics.MetadataUpdate{
+		{
+			ResourceIDKey: "k8s.pod.uid",
+			ResourceID:    "pod-uid",
+			MetadataDelta: metrics.MetadataDelta{
+				MetadataToAdd: map[string]string{},
+				MetadataToUpdate: map[string]string{
+					"deployment":         "nginx-deployment",
+					"k8s.namespace.name": "default",
+					"pod_label_app":      "nginx",
+				},
+			},
+		},
+		{
+			ResourceIDKey: "AWSUniqueId",
+			ResourceID:    "i-1234",
+			MetadataDelta: metrics.MetadataDelta{
+				MetadataToAdd:    map[string]string{"us-east-1a": ""},
+				MetadataToUpdate: map[string]string{},
+			},
+		},
+	}, client.updates)
+
+	// Only the properties that changed are sent again
+	client.updates = nil
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	assert.Empty(t, client.updates)
+
+	s.Sync(newTestMetrics(podAttributes("other-deployment")))
+	assert.Equal(t, []*metrics.MetadataUpdate{
+		{
+			ResourceIDKey: "k8s.pod.uid",
+			ResourceID:    "pod-uid",
+			MetadataDelta: metrics.MetadataDelta{
+				MetadataToAdd:    map[string]string{},
+				MetadataToUpdate: map[string]string{"deployment": "other-deployment"},
+			},
+		},
+	}, client.updates)
+}
+
+func TestSyncPushError(t *testing.T) {
+	client := &fakeDimClient{err: errors.New("invalid dimension")}
+	s := NewSyncer(zap.NewNop(), testConfig(), client)
+
+	// The properties are sent again after an error
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 1)
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 2)
+	assert.Equal(t, client.updates[0], client.updates[1])
+}
+
+func TestSyncSendError(t *testing.T) {
+	client := &fakeDimClient{sendErr: errors.New("bad request")}
+	s := NewSyncer(zap.NewNop(), testConfig(), client)
+
+	// The properties are sent again after they failed to be sent, and not after they were sent
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 1)
+	client.sendErr = nil
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 2)
+	assert.Equal(t, client.updates[0], client.updates[1])
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	assert.Len(t, client.updates, 2)
+}
+
+func TestSyncCacheExpiration(t *testing.T) {
+	client := &fakeDimClient{}
+	cfg := testConfig()
+	cfg.CacheTTL = time.Millisecond
+	s := NewSyncer(zap.NewNop(), cfg, client)
+
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	time.Sleep(10 * time.Millisecond)
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 2)
+	assert.Equal(t, client.updates[0], client.updates[1])
+}
+
+func TestSyncCacheFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "propertysync")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := testConfig()
+	cfg.CacheFile = filepath.Join(dir, "cache.json")
+
+	client := &fakeDimClient{}
+	s := NewSyncer(zap.NewNop(), cfg, client)
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	require.Len(t, client.updates, 1)
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	// The properties synced before the restart are not sent again
+	client = &fakeDimClient{}
+	s = NewSyncer(zap.NewNop(), cfg, client)
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	assert.Empty(t, client.updates)
+
+	// An invalid cache file is ignored
+	require.NoError(t, ioutil.WriteFile(cfg.CacheFile, []byte("invalid"), 0600))
+	s = NewSyncer(zap.NewNop(), cfg, client)
+	s.Sync(newTestMetrics(podAttributes("nginx-deployment")))
+	assert.Len(t, client.updates, 1)
+}
@@ -18,21 +18,15 @@ import (
 	"github.com/gogo/protobuf/proto"
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
 )
 
 type deltaTranslator struct {
-	prevPts *ttlmap.TTLMap
+	tracker *translationrules.DeltaTracker
 }
 
 func newDeltaTranslator(ttl int64) *deltaTranslator {
-	sweepIntervalSeconds := ttl / 2
-	if sweepIntervalSeconds == 0 {
-		sweepIntervalSeconds = 1
-	}
-	m := ttlmap.New(sweepIntervalSeconds, ttl)
-	m.Start()
-	return &deltaTranslator{prevPts: m}
+	return &deltaTranslator{tracker: translationrules.NewDeltaTracker(ttl)}
 }
 
 func (t *deltaTranslator) translate(pts []*sfxpb.DataPoint, tr Rule) []*sfxpb.DataPoint {
@@ -52,55 +46,30 @@ func (t *deltaTranslator) translate(pts []*sfxpb.DataPoint, tr Rule) []*sfxpb.Da
 }
 
 func (t *deltaTranslator) deltaPt(deltaMetricName string, currPt *sfxpb.DataPoint) *sfxpb.DataPoint {
-	// check if we have a previous point for this metric + dimensions
-	dimKey := stringifyDimensions(currPt.Dimensions, nil)
-	fullKey := currPt.Metric + ":" + dimKey
-	v := t.prevPts.Get(fullKey)
-	// without proto.Clone here, points' DoubleValue are converted into IntValues, presumably by other translators
-	t.prevPts.Put(fullKey, proto.Clone(currPt))
-	if v == nil {
-		// no previous point, so we can't calculate a delta
+	pt := translationrules.Point{Timestamp: currPt.Timestamp}
+	switch {
+	case currPt.Value.DoubleValue != nil:
+		pt.IsDouble = true
+		pt.DoubleValue = *currPt.Value.DoubleValue
+	case currPt.Value.IntValue != nil:
+		pt.IntValue = *currPt.Value.IntValue
+	default:
 		return nil
 	}
-	prevPt := v.(*sfxpb.DataPoint)
-	var deltaPt *sfxpb.DataPoint
-	if currPt.Value.DoubleValue != nil && prevPt.Value.DoubleValue != nil {
-		deltaPt = doubleDeltaPt(currPt, prevPt, deltaMetricName)
-	} else if currPt.Value.IntValue != nil && prevPt.Value.IntValue != nil {
-		deltaPt = intDeltaPt(currPt, prevPt, deltaMetricName)
-	} else {
+	delta, ok := t.tracker.Delta(currPt.Metric+":"+stringifyDimensions(currPt.Dimensions, nil), pt)
+	if !ok {
 		return nil
 	}
-	return deltaPt
-}
-
-func doubleDeltaPt(currPt *sfxpb.DataPoint, prevPt *sfxpb.DataPoint, deltaMetricName string) *sfxpb.DataPoint {
-	delta := *currPt.Value.DoubleValue - *prevPt.Value.DoubleValue
-	if delta < 0 {
-		// assume a reset, emit the current value
-		delta = *currPt.Value.DoubleValue
-	}
-	deltaPt := basePt(currPt, deltaMetricName)
-	*deltaPt.Value.DoubleValue = delta
-	return deltaPt
-}
-
-func intDeltaPt(currPt *sfxpb.DataPoint, prevPt *sfxpb.DataPoint, deltaMetricName string) *sfxpb.DataPoint {
-	delta := *currPt.Value.IntValue - *prevPt.Value.IntValue
-	if delta < 0 {
-		// assume a reset, emit the current value
-		delta = *currPt.Value.IntValue
-	}
-	deltaPt := basePt(currPt, deltaMetricName)
-	*deltaPt.Value.IntValue = delta
-	return deltaPt
-}
-
-var metricTypeGauge = sfxpb.MetricType_GAUGE
 
-func basePt(currPt *sfxpb.DataPoint, deltaMetricName string) *sfxpb.DataPoint {
 	deltaPt := proto.Clone(currPt).(*sfxpb.DataPoint)
 	deltaPt.Metric = deltaMetricName
 	deltaPt.MetricType = &metricTypeGauge
+	if delta.IsDouble {
+		*deltaPt.Value.DoubleValue = delta.DoubleValue
+	} else {
+		*deltaPt.Value.IntValue = delta.IntValue
+	}
 	return deltaPt
 }
+
+var metricTypeGauge = sfxpb.MetricType_GAUGE
@@ -323,7 +323,7 @@ func TestNewMetricTranslator(t *testing.T) {
 				},
 			},
 			wantDimensionsMap: nil,
-			wantError: "\"dimension_values_filer\" has to be provided if \"dimension_key\" is set " +
+			wantError: "\"dimension_values\" has to be provided if \"dimension_key\" is set " +
 				"for \"copy_metrics\" translation rule",
 		},
 		{
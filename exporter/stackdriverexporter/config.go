@@ -30,6 +30,13 @@ type Config struct {
 	Endpoint                      string                   `mapstructure:"endpoint"`
 	// Only has effect if Endpoint is not ""
 	UseInsecure bool `mapstructure:"use_insecure"`
+	// ProjectAttribute is the resource attribute whose value, if present, is the project
+	// the data of the resource is sent to instead of ProjectID.
+	ProjectAttribute string `mapstructure:"project_attribute"`
+	// MaxProjects is the maximum number of projects, each with its own connection, data
+	// is sent to at once. The exporter of the least recently used project is shut down
+	// when it is exceeded. If not set, defaults to 10.
+	MaxProjects int `mapstructure:"max_projects"`
 	// Timeout for all API calls. If not set, defaults to 12 seconds.
 	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 	ResourceMappings               []ResourceMapping        `mapstructure:"resource_mappings"`
@@ -41,6 +48,7 @@ type Config struct {
 
 	TraceConfig  TraceConfig  `mapstructure:"trace"`
 	MetricConfig MetricConfig `mapstructure:"metric"`
+	LogConfig    LogConfig    `mapstructure:"log"`
 	NumOfWorkers int          `mapstructure:"number_of_workers"`
 }
 
@@ -57,6 +65,11 @@ type MetricConfig struct {
 	SkipCreateMetricDescriptor bool   `mapstructure:"skip_create_descriptor"`
 }
 
+type LogConfig struct {
+	// Name is the ID of the log the entries are written to, if not set, defaults to "opentelemetry-collector".
+	Name string `mapstructure:"name"`
+}
+
 // ResourceMapping defines mapping of resources from source (OpenCensus) to target (Stackdriver).
 type ResourceMapping struct {
 	SourceType string `mapstructure:"source_type"`
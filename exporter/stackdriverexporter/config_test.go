@@ -53,6 +53,8 @@ func TestLoadConfig(t *testing.T) {
 			UserAgent:        "opentelemetry-collector-contrib {{version}}",
 			Endpoint:         "test-endpoint",
 			UseInsecure:      true,
+			ProjectAttribute: "gcp.project.id",
+			MaxProjects:      5,
 			TimeoutSettings: exporterhelper.TimeoutSettings{
 				Timeout: 20 * time.Second,
 			},
@@ -90,5 +92,8 @@ func TestLoadConfig(t *testing.T) {
 				Prefix:                     "prefix",
 				SkipCreateMetricDescriptor: true,
 			},
+			LogConfig: LogConfig{
+				Name: "my-log",
+			},
 		})
 }
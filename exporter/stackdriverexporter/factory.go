@@ -45,6 +45,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithTraces(createTraceExporter),
 		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter),
 	)
 }
 
@@ -77,3 +78,12 @@ func createMetricsExporter(
 	eCfg := cfg.(*Config)
 	return newStackdriverMetricsExporter(eCfg, params)
 }
+
+// createLogsExporter creates a logs exporter based on this config.
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter) (component.LogsExporter, error) {
+	eCfg := cfg.(*Config)
+	return newStackdriverLogsExporter(eCfg, params)
+}
@@ -0,0 +1,255 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stackdriverexporter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opencensus.io/resource"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+	gtransport "google.golang.org/api/transport/grpc"
+	monitoredrespb "google.golang.org/genproto/googleapis/api/monitoredres"
+	logtypepb "google.golang.org/genproto/googleapis/logging/type"
+	loggingpb "google.golang.org/genproto/googleapis/logging/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	loggingEndpoint   = "logging.googleapis.com:443"
+	loggingWriteScope = "https://www.googleapis.com/auth/logging.write"
+	defaultLogName    = "opentelemetry-collector"
+	// The resource label the OpenCensus exporter sets to the project ID, used
+	// by the default resource mappings for the project_id label.
+	projectIDResourceLabel = "contrib.opencensus.io/exporter/stackdriver/project_id"
+	// Cloud Logging recommends writing at most 1000 entries per request.
+	maxEntriesPerRequest = 1000
+	// The trace flag of sampled spans.
+	sampledFlag = 0x1
+)
+
+// logsExporter writes logs to Cloud Logging, a single client is used for all
+// the projects since each entry names the log, and so the project, it belongs to.
+type logsExporter struct {
+	cfg       *Config
+	projectID string
+	logName   string
+	mapper    resourceMapper
+	conn      *grpc.ClientConn
+	client    loggingpb.LoggingServiceV2Client
+}
+
+func (*logsExporter) Name() string {
+	return name
+}
+
+func (le *logsExporter) Shutdown(context.Context) error {
+	return le.conn.Close()
+}
+
+func newStackdriverLogsExporter(cfg *Config, params component.ExporterCreateParams) (component.LogsExporter, error) {
+	setVersionInUserAgent(cfg, params.ApplicationStartInfo.Version)
+
+	copts, err := generateClientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	copts = append([]option.ClientOption{
+		option.WithEndpoint(loggingEndpoint),
+		option.WithScopes(loggingWriteScope),
+	}, copts...)
+
+	// Unlike the trace and metric exporters, the project of the entries
+	// is needed to name their log, so the default one is found here.
+	projectID := cfg.ProjectID
+	if projectID == "" {
+		creds, cerr := transport.Creds(context.Background(), copts...)
+		if cerr != nil {
+			return nil, fmt.Errorf("cannot find the default project: %w", cerr)
+		}
+		if creds.ProjectID == "" {
+			return nil, fmt.Errorf("no project found with application default credentials")
+		}
+		projectID = creds.ProjectID
+	}
+
+	conn, err := gtransport.Dial(context.Background(), copts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Cloud Logging client: %w", err)
+	}
+
+	logName := cfg.LogConfig.Name
+	if logName == "" {
+		logName = defaultLogName
+	}
+	lExp := &logsExporter{
+		cfg:       cfg,
+		projectID: projectID,
+		logName:   logName,
+		mapper:    resourceMapper{mappings: cfg.ResourceMappings},
+		conn:      conn,
+		client:    loggingpb.NewLoggingServiceV2Client(conn),
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		lExp.pushLogs,
+		exporterhelper.WithShutdown(lExp.Shutdown),
+		exporterhelper.WithTimeout(cfg.TimeoutSettings))
+}
+
+// pushLogs writes the log records of the given logs as entries of the log of the project of their resource
+func (le *logsExporter) pushLogs(ctx context.Context, ld pdata.Logs) (int, error) {
+	projectEntries := map[string][]*loggingpb.LogEntry{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		projectID := resourceProject(le.cfg, rl.Resource())
+		if projectID == "" {
+			projectID = le.projectID
+		}
+		logName := fmt.Sprintf("projects/%s/logs/%s", projectID, url.PathEscape(le.logName))
+		monitoredResource := le.mapper.mapResource(pdataResourceToOC(rl.Resource(), projectID))
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				entry := logRecordToEntry(logs.At(k), projectID, monitoredResource)
+				entry.LogName = logName
+				projectEntries[projectID] = append(projectEntries[projectID], entry)
+			}
+		}
+	}
+
+	var errs []error
+	dropped := 0
+	for _, entries := range projectEntries {
+		for start := 0; start < len(entries); start += maxEntriesPerRequest {
+			end := start + maxEntriesPerRequest
+			if end > len(entries) {
+				end = len(entries)
+			}
+			_, err := le.client.WriteLogEntries(ctx, &loggingpb.WriteLogEntriesRequest{
+				Entries: entries[start:end],
+				// Write the valid entries even if some of them are rejected.
+				PartialSuccess: true,
+			})
+			if err != nil {
+				if status.Code(err) == codes.InvalidArgument {
+					err = consumererror.Permanent(err)
+				}
+				errs = append(errs, err)
+				dropped += end - start
+			}
+		}
+	}
+	return dropped, componenterror.CombineErrors(errs)
+}
+
+// pdataResourceToOC converts the resource to an OpenCensus one, as expected by
+// the resource mappings, the same way as the metrics of the resource are.
+func pdataResourceToOC(res pdata.Resource, projectID string) *resource.Resource {
+	attrs := res.Attributes()
+	ocResource := &resource.Resource{
+		Labels: make(map[string]string, attrs.Len()+1),
+	}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		val := tracetranslator.AttributeValueToString(v, false)
+		if k == conventions.OCAttributeResourceType {
+			ocResource.Type = val
+			return
+		}
+		ocResource.Labels[k] = val
+	})
+	if _, ok := ocResource.Labels[projectIDResourceLabel]; !ok {
+		ocResource.Labels[projectIDResourceLabel] = projectID
+	}
+	return ocResource
+}
+
+// logRecordToEntry converts the log record to a log entry of the given project and monitored resource.
+func logRecordToEntry(lr pdata.LogRecord, projectID string, monitoredResource *monitoredrespb.MonitoredResource) *loggingpb.LogEntry {
+	entry := &loggingpb.LogEntry{
+		Resource: monitoredResource,
+		Severity: severityNumberToLogSeverity(lr.SeverityNumber()),
+	}
+	if lr.Timestamp() != 0 {
+		entry.Timestamp = timestamppb.New(time.Unix(0, int64(lr.Timestamp())))
+	}
+
+	body := lr.Body()
+	switch body.Type() {
+	case pdata.AttributeValueNULL:
+	case pdata.AttributeValueMAP:
+		payload, err := structpb.NewStruct(tracetranslator.AttributeMapToMap(body.MapVal()))
+		if err == nil {
+			entry.Payload = &loggingpb.LogEntry_JsonPayload{JsonPayload: payload}
+			break
+		}
+		entry.Payload = &loggingpb.LogEntry_TextPayload{TextPayload: tracetranslator.AttributeValueToString(body, false)}
+	default:
+		entry.Payload = &loggingpb.LogEntry_TextPayload{TextPayload: tracetranslator.AttributeValueToString(body, false)}
+	}
+
+	if attrs := lr.Attributes(); attrs.Len() > 0 {
+		entry.Labels = make(map[string]string, attrs.Len())
+		attrs.ForEach(func(k string, v pdata.AttributeValue) {
+			entry.Labels[k] = tracetranslator.AttributeValueToString(v, false)
+		})
+	}
+
+	if !lr.TraceID().IsEmpty() {
+		entry.Trace = fmt.Sprintf("projects/%s/traces/%s", projectID, lr.TraceID().HexString())
+		entry.TraceSampled = lr.Flags()&sampledFlag != 0
+	}
+	if !lr.SpanID().IsEmpty() {
+		entry.SpanId = lr.SpanID().HexString()
+	}
+	return entry
+}
+
+// severityNumberToLogSeverity maps the ranges of severity numbers to the Cloud Logging severity levels.
+func severityNumberToLogSeverity(sn pdata.SeverityNumber) logtypepb.LogSeverity {
+	switch {
+	case sn == pdata.SeverityNumberUNDEFINED:
+		return logtypepb.LogSeverity_DEFAULT
+	case sn <= pdata.SeverityNumberDEBUG4:
+		return logtypepb.LogSeverity_DEBUG
+	case sn <= pdata.SeverityNumberINFO4:
+		return logtypepb.LogSeverity_INFO
+	case sn <= pdata.SeverityNumberWARN4:
+		return logtypepb.LogSeverity_WARNING
+	case sn <= pdata.SeverityNumberERROR4:
+		return logtypepb.LogSeverity_ERROR
+	default:
+		return logtypepb.LogSeverity_CRITICAL
+	}
+}
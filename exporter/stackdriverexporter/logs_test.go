@@ -0,0 +1,194 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stackdriverexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/monitoredres"
+	logtypepb "google.golang.org/genproto/googleapis/logging/type"
+	loggingpb "google.golang.org/genproto/googleapis/logging/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockLoggingServer struct {
+	loggingpb.LoggingServiceV2Server
+
+	reqCh chan *loggingpb.WriteLogEntriesRequest
+	err   error
+}
+
+func (ls *mockLoggingServer) WriteLogEntries(ctx context.Context, req *loggingpb.WriteLogEntriesRequest) (*loggingpb.WriteLogEntriesResponse, error) {
+	go func() { ls.reqCh <- req }()
+	return &loggingpb.WriteLogEntriesResponse{}, ls.err
+}
+
+func newTestLogsExporter(t *testing.T, ls *mockLoggingServer) (component.LogsExporter, func()) {
+	srv := grpc.NewServer()
+	loggingpb.RegisterLoggingServiceV2Server(srv, ls)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go srv.Serve(lis)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ProjectID = "idk"
+	cfg.ProjectAttribute = "gcp.project.id"
+	cfg.Endpoint = lis.Addr().String()
+	cfg.UseInsecure = true
+	cfg.ResourceMappings = []ResourceMapping{
+		{
+			SourceType: "source.resource1",
+			TargetType: "target_resource_1",
+			LabelMappings: []LabelMapping{
+				{
+					SourceKey: "contrib.opencensus.io/exporter/stackdriver/project_id",
+					TargetKey: "project_id",
+				},
+				{
+					SourceKey: "source.label1",
+					TargetKey: "target_label_1",
+				},
+			},
+		},
+	}
+	createParams := component.ExporterCreateParams{Logger: zap.NewNop(), ApplicationStartInfo: component.ApplicationStartInfo{Version: "v0.0.1"}}
+	sde, err := newStackdriverLogsExporter(cfg, createParams)
+	require.NoError(t, err)
+	return sde, func() {
+		assert.NoError(t, sde.Shutdown(context.Background()))
+		srv.Stop()
+	}
+}
+
+func TestStackdriverLogsExport(t *testing.T) {
+	reqCh := make(chan *loggingpb.WriteLogEntriesRequest)
+	sde, stop := newTestLogsExporter(t, &mockLoggingServer{reqCh: reqCh})
+	defer stop()
+
+	testTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(2)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"opencensus.resourcetype": pdata.NewAttributeValueString("source.resource1"),
+		"source.label1":           pdata.NewAttributeValueString("value1"),
+	})
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	lr.SetTimestamp(pdata.TimestampUnixNano(testTime.UnixNano()))
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN2)
+	lr.Body().SetStringVal("log message")
+	lr.Attributes().InsertInt("http.status_code", 500)
+	lr.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	lr.SetFlags(1)
+
+	// The logs of the resources with the project attribute are written to that project
+	rl = ld.ResourceLogs().At(1)
+	rl.Resource().Attributes().InsertString("gcp.project.id", "other")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	lr = rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("key", "value")
+	body.MapVal().InsertInt("count", 2)
+	body.CopyTo(lr.Body())
+
+	require.NoError(t, sde.ConsumeLogs(context.Background(), ld))
+
+	entries := map[string]*loggingpb.LogEntry{}
+	for i := 0; i < 2; i++ {
+		r := <-reqCh
+		assert.True(t, r.PartialSuccess)
+		require.Len(t, r.Entries, 1)
+		entries[r.Entries[0].LogName] = r.Entries[0]
+	}
+
+	entry := entries["projects/idk/logs/opentelemetry-collector"]
+	require.NotNil(t, entry)
+	assert.Equal(t, &monitoredres.MonitoredResource{
+		Type:   "target_resource_1",
+		Labels: map[string]string{"project_id": "idk", "target_label_1": "value1"},
+	}, entry.Resource)
+	assert.Equal(t, timestamppb.New(testTime), entry.Timestamp)
+	assert.Equal(t, logtypepb.LogSeverity_WARNING, entry.Severity)
+	assert.Equal(t, "log message", entry.GetTextPayload())
+	assert.Equal(t, map[string]string{"http.status_code": "500"}, entry.Labels)
+	assert.Equal(t, "projects/idk/traces/00000000000000010000000000000002", entry.Trace)
+	assert.Equal(t, "0000000000000003", entry.SpanId)
+	assert.True(t, entry.TraceSampled)
+
+	entry = entries["projects/other/logs/opentelemetry-collector"]
+	require.NotNil(t, entry)
+	assert.Equal(t, "global", entry.Resource.Type)
+	assert.Nil(t, entry.Timestamp)
+	assert.Equal(t, logtypepb.LogSeverity_DEFAULT, entry.Severity)
+	payload := entry.GetJsonPayload().AsMap()
+	assert.Equal(t, map[string]interface{}{"key": "value", "count": float64(2)}, payload)
+	assert.Empty(t, entry.Trace)
+	assert.Empty(t, entry.SpanId)
+}
+
+func TestStackdriverLogsExportErrors(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Resize(maxEntriesPerRequest + 1)
+
+	reqCh := make(chan *loggingpb.WriteLogEntriesRequest, 2)
+	sde, stop := newTestLogsExporter(t, &mockLoggingServer{reqCh: reqCh, err: status.Error(codes.InvalidArgument, "invalid")})
+	defer stop()
+
+	// The entries are written in requests of limited size, and rejected ones are not retried
+	err := sde.ConsumeLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Len(t, (<-reqCh).Entries, maxEntriesPerRequest)
+	assert.Len(t, (<-reqCh).Entries, 1)
+}
+
+func TestSeverityNumberToLogSeverity(t *testing.T) {
+	tests := []struct {
+		number   pdata.SeverityNumber
+		severity logtypepb.LogSeverity
+	}{
+		{pdata.SeverityNumberUNDEFINED, logtypepb.LogSeverity_DEFAULT},
+		{pdata.SeverityNumberTRACE, logtypepb.LogSeverity_DEBUG},
+		{pdata.SeverityNumberDEBUG4, logtypepb.LogSeverity_DEBUG},
+		{pdata.SeverityNumberINFO, logtypepb.LogSeverity_INFO},
+		{pdata.SeverityNumberWARN3, logtypepb.LogSeverity_WARNING},
+		{pdata.SeverityNumberERROR, logtypepb.LogSeverity_ERROR},
+		{pdata.SeverityNumberFATAL4, logtypepb.LogSeverity_CRITICAL},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.severity, severityNumberToLogSeverity(tt.number))
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stackdriverexporter
+
+import (
+	"container/list"
+	"sync"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.uber.org/zap"
+)
+
+// defaultMaxProjects is the number of project exporters kept when MaxProjects is not set.
+const defaultMaxProjects = 10
+
+// projectExporters holds the exporters of the most recently used projects. Each
+// exporter holds its own connection, so once more than max projects are used, the
+// exporter of the least recently used project is shut down, as soon as the pushes
+// using it are done.
+type projectExporters struct {
+	logger   *zap.Logger
+	create   func(projectID string) (interface{}, error)
+	shutdown func(exporter interface{}) error
+	max      int
+
+	mutex sync.Mutex
+	// lru holds the *projectExporter entries, the most recently used first.
+	lru       *list.List
+	byProject map[string]*list.Element
+}
+
+type projectExporter struct {
+	projectID string
+	// ready is closed once the exporter is created, exporter and err are only
+	// read after it is closed.
+	ready    chan struct{}
+	exporter interface{}
+	err      error
+
+	// users is the number of callers of get that did not release the exporter yet,
+	// and evicted is set once the exporter is removed from the projects.
+	// They are guarded by the mutex of the projectExporters.
+	users   int
+	evicted bool
+}
+
+func newProjectExporters(logger *zap.Logger, max int, create func(string) (interface{}, error), shutdown func(interface{}) error) *projectExporters {
+	if max <= 0 {
+		max = defaultMaxProjects
+	}
+	return &projectExporters{
+		logger:    logger,
+		create:    create,
+		shutdown:  shutdown,
+		max:       max,
+		lru:       list.New(),
+		byProject: map[string]*list.Element{},
+	}
+}
+
+// get returns the exporter of the given project, creating it if needed, and the
+// function to call once the exporter is not used anymore.
+func (p *projectExporters) get(projectID string) (interface{}, func(), error) {
+	p.mutex.Lock()
+	if elem, ok := p.byProject[projectID]; ok {
+		p.lru.MoveToFront(elem)
+		entry := elem.Value.(*projectExporter)
+		entry.users++
+		p.mutex.Unlock()
+
+		<-entry.ready
+		if entry.err != nil {
+			p.release(entry)
+			return nil, nil, entry.err
+		}
+		return entry.exporter, func() { p.release(entry) }, nil
+	}
+
+	entry := &projectExporter{projectID: projectID, ready: make(chan struct{}), users: 1}
+	p.byProject[projectID] = p.lru.PushFront(entry)
+	var evicted []*projectExporter
+	for p.lru.Len() > p.max {
+		oldest := p.lru.Remove(p.lru.Back()).(*projectExporter)
+		delete(p.byProject, oldest.projectID)
+		oldest.evicted = true
+		if oldest.users == 0 {
+			evicted = append(evicted, oldest)
+		}
+	}
+	p.mutex.Unlock()
+
+	// The exporters are created and flushed outside of the lock, so that the
+	// other projects are not blocked meanwhile.
+	for _, e := range evicted {
+		p.shutdownEvicted(e)
+	}
+
+	entry.exporter, entry.err = p.create(projectID)
+	close(entry.ready)
+	if entry.err != nil {
+		p.mutex.Lock()
+		if elem, ok := p.byProject[projectID]; ok && elem.Value.(*projectExporter) == entry {
+			p.lru.Remove(elem)
+			delete(p.byProject, projectID)
+		}
+		p.mutex.Unlock()
+		p.release(entry)
+		return nil, nil, entry.err
+	}
+	return entry.exporter, func() { p.release(entry) }, nil
+}
+
+// release shuts down the exporter of an evicted project once its last user released it.
+func (p *projectExporters) release(entry *projectExporter) {
+	p.mutex.Lock()
+	entry.users--
+	last := entry.evicted && entry.users == 0
+	p.mutex.Unlock()
+
+	if last {
+		p.shutdownEvicted(entry)
+	}
+}
+
+func (p *projectExporters) shutdownEvicted(entry *projectExporter) {
+	if entry.err != nil {
+		return
+	}
+	p.logger.Debug("Shutting down the exporter of the least recently used project", zap.String("project", entry.projectID))
+	if err := p.shutdown(entry.exporter); err != nil {
+		p.logger.Warn("Failed to shut down the exporter of an evicted project", zap.String("project", entry.projectID), zap.Error(err))
+	}
+}
+
+// shutdownAll shuts down the exporters of all the projects, the exporters still
+// in use are shut down once released.
+func (p *projectExporters) shutdownAll() error {
+	p.mutex.Lock()
+	var unused []*projectExporter
+	for elem := p.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*projectExporter)
+		entry.evicted = true
+		if entry.users == 0 {
+			unused = append(unused, entry)
+		}
+	}
+	p.lru.Init()
+	p.byProject = map[string]*list.Element{}
+	p.mutex.Unlock()
+
+	var errs []error
+	for _, entry := range unused {
+		if entry.err != nil {
+			continue
+		}
+		if err := p.shutdown(entry.exporter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
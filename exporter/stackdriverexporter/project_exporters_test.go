@@ -0,0 +1,146 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stackdriverexporter
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestProjectExportersEvictsLeastRecentlyUsed(t *testing.T) {
+	created := map[string]int{}
+	var shutdown []string
+	pe := newProjectExporters(zap.NewNop(), 2,
+		func(projectID string) (interface{}, error) {
+			created[projectID]++
+			return projectID, nil
+		},
+		func(exporter interface{}) error {
+			shutdown = append(shutdown, exporter.(string))
+			return nil
+		})
+
+	for _, projectID := range []string{"a", "b", "a", "c"} {
+		exporter, release, err := pe.get(projectID)
+		require.NoError(t, err)
+		assert.Equal(t, projectID, exporter)
+		release()
+	}
+	// b is the least recently used project when c is added.
+	assert.Equal(t, []string{"b"}, shutdown)
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1}, created)
+
+	// An evicted project gets a new exporter.
+	_, release, err := pe.get("b")
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 2, created["b"])
+	assert.Equal(t, []string{"b", "a"}, shutdown)
+
+	require.NoError(t, pe.shutdownAll())
+	assert.ElementsMatch(t, []string{"b", "a", "c", "b"}, shutdown)
+}
+
+func TestProjectExportersCreateError(t *testing.T) {
+	pe := newProjectExporters(zap.NewNop(), 0,
+		func(string) (interface{}, error) {
+			return nil, errors.New("no credentials")
+		},
+		func(interface{}) error {
+			return nil
+		})
+
+	_, _, err := pe.get("a")
+	assert.EqualError(t, err, "no credentials")
+	assert.Equal(t, defaultMaxProjects, pe.max)
+	assert.Equal(t, 0, pe.lru.Len())
+}
+
+func TestProjectExportersShutsDownEvictedExporterOnceReleased(t *testing.T) {
+	var mutex sync.Mutex
+	var shutdown []string
+	pe := newProjectExporters(zap.NewNop(), 1,
+		func(projectID string) (interface{}, error) {
+			return projectID, nil
+		},
+		func(exporter interface{}) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			shutdown = append(shutdown, exporter.(string))
+			return nil
+		})
+
+	_, releaseA, err := pe.get("a")
+	require.NoError(t, err)
+	_, releaseB, err := pe.get("b")
+	require.NoError(t, err)
+	// a is evicted, but still used
+	assert.Empty(t, shutdown)
+	releaseA()
+	assert.Equal(t, []string{"a"}, shutdown)
+
+	// b is shut down on shutdown once released
+	require.NoError(t, pe.shutdownAll())
+	assert.Equal(t, []string{"a"}, shutdown)
+	releaseB()
+	assert.Equal(t, []string{"a", "b"}, shutdown)
+}
+
+func TestProjectExportersCreatesOutsideOfLock(t *testing.T) {
+	creating := make(chan struct{})
+	created := make(chan struct{})
+	pe := newProjectExporters(zap.NewNop(), 0,
+		func(projectID string) (interface{}, error) {
+			if projectID == "slow" {
+				close(creating)
+				<-created
+			}
+			return projectID, nil
+		},
+		func(interface{}) error {
+			return nil
+		})
+
+	done := make(chan interface{})
+	go func() {
+		exporter, release, err := pe.get("slow")
+		assert.NoError(t, err)
+		release()
+		done <- exporter
+	}()
+	<-creating
+
+	// The other projects are not blocked by the creation of an exporter
+	exporter, release, err := pe.get("fast")
+	require.NoError(t, err)
+	assert.Equal(t, "fast", exporter)
+	release()
+
+	// The callers getting an exporter being created wait for it
+	go func() {
+		exporter, release, err := pe.get("slow")
+		assert.NoError(t, err)
+		release()
+		done <- exporter
+	}()
+	close(created)
+	assert.Equal(t, "slow", <-done)
+	assert.Equal(t, "slow", <-done)
+}
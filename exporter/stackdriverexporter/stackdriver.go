@@ -39,12 +39,20 @@ const name = "stackdriver"
 
 // traceExporter is a wrapper struct of OT cloud trace exporter
 type traceExporter struct {
-	texporter *cloudtrace.Exporter
+	cfg   *Config
+	topts []cloudtrace.Option
+
+	// texporters are the *cloudtrace.Exporter of the projects spans were sent to.
+	texporters *projectExporters
 }
 
 // metricsExporter is a wrapper struct of OC stackdriver exporter
 type metricsExporter struct {
-	mexporter *stackdriver.Exporter
+	cfg     *Config
+	options stackdriver.Options
+
+	// mexporters are the *stackdriver.Exporter of the projects metrics were sent to.
+	mexporters *projectExporters
 }
 
 func (*traceExporter) Name() string {
@@ -55,16 +63,78 @@ func (*metricsExporter) Name() string {
 	return name
 }
 
-func (te *traceExporter) Shutdown(ctx context.Context) error {
-	return te.texporter.Shutdown(ctx)
+func (te *traceExporter) Shutdown(context.Context) error {
+	return te.texporters.shutdownAll()
 }
 
 func (me *metricsExporter) Shutdown(context.Context) error {
-	me.mexporter.Flush()
-	me.mexporter.StopMetricsExporter()
+	return me.mexporters.shutdownAll()
+}
+
+// exporter returns the exporter of the given project, creating it if needed, and
+// the function to call once it is not used anymore.
+func (te *traceExporter) exporter(projectID string) (*cloudtrace.Exporter, func(), error) {
+	texporter, release, err := te.texporters.get(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return texporter.(*cloudtrace.Exporter), release, nil
+}
+
+// exporter returns the exporter of the given project, creating it if needed, and
+// the function to call once it is not used anymore.
+func (me *metricsExporter) exporter(projectID string) (*stackdriver.Exporter, func(), error) {
+	mexporter, release, err := me.mexporters.get(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mexporter.(*stackdriver.Exporter), release, nil
+}
+
+func (te *traceExporter) newExporter(projectID string) (interface{}, error) {
+	topts := make([]cloudtrace.Option, 0, len(te.topts)+1)
+	topts = append(topts, te.topts...)
+	topts = append(topts, cloudtrace.WithProjectID(projectID))
+	texporter, err := cloudtrace.NewExporter(topts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Stackdriver Trace exporter: %w", err)
+	}
+	return texporter, nil
+}
+
+func shutdownTraceExporter(texporter interface{}) error {
+	return texporter.(*cloudtrace.Exporter).Shutdown(context.Background())
+}
+
+func (me *metricsExporter) newExporter(projectID string) (interface{}, error) {
+	options := me.options
+	// If the project ID is an empty string, it will be set by default based on
+	// the project this is running on in GCP.
+	options.ProjectID = projectID
+	mexporter, err := stackdriver.NewExporter(options)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Stackdriver metric exporter: %w", err)
+	}
+	return mexporter, nil
+}
+
+func shutdownMetricsExporter(mexporter interface{}) error {
+	mexporter.(*stackdriver.Exporter).Flush()
+	mexporter.(*stackdriver.Exporter).StopMetricsExporter()
 	return nil
 }
 
+// resourceProject returns the project the data of the resource is sent to, which is the
+// value of the ProjectAttribute resource attribute if set, or the configured project.
+func resourceProject(cfg *Config, resource pdata.Resource) string {
+	if cfg.ProjectAttribute != "" {
+		if v, ok := resource.Attributes().Get(cfg.ProjectAttribute); ok && v.StringVal() != "" {
+			return v.StringVal()
+		}
+	}
+	return cfg.ProjectID
+}
+
 func setVersionInUserAgent(cfg *Config, version string) {
 	cfg.UserAgent = strings.ReplaceAll(cfg.UserAgent, "{{version}}", version)
 }
@@ -102,7 +172,6 @@ func newStackdriverTraceExporter(cfg *Config, params component.ExporterCreatePar
 	setVersionInUserAgent(cfg, params.ApplicationStartInfo.Version)
 
 	topts := []cloudtrace.Option{
-		cloudtrace.WithProjectID(cfg.ProjectID),
 		cloudtrace.WithTimeout(cfg.Timeout),
 	}
 
@@ -120,12 +189,18 @@ func newStackdriverTraceExporter(cfg *Config, params component.ExporterCreatePar
 		return nil, err
 	}
 
-	exp, err := cloudtrace.NewExporter(topts...)
+	tExp := &traceExporter{
+		cfg:   cfg,
+		topts: topts,
+	}
+	tExp.texporters = newProjectExporters(params.Logger, cfg.MaxProjects, tExp.newExporter, shutdownTraceExporter)
+	// The exporter of the configured project is created eagerly to report
+	// configuration errors on startup, the others are created on first use.
+	_, release, err := tExp.exporter(cfg.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Stackdriver Trace exporter: %w", err)
+		return nil, err
 	}
-
-	tExp := &traceExporter{texporter: exp}
+	release()
 
 	return exporterhelper.NewTraceExporter(
 		cfg,
@@ -193,13 +268,8 @@ func validateAndAppendDurationOption(topts []cloudtrace.Option, name string, val
 func newStackdriverMetricsExporter(cfg *Config, params component.ExporterCreateParams) (component.MetricsExporter, error) {
 	setVersionInUserAgent(cfg, params.ApplicationStartInfo.Version)
 
-	// TODO:  For each ProjectID, create a different exporter
-	// or at least a unique Stackdriver client per ProjectID.
+	// The project ID is set by the exporter of each project.
 	options := stackdriver.Options{
-		// If the project ID is an empty string, it will be set by default based on
-		// the project this is running on in GCP.
-		ProjectID: cfg.ProjectID,
-
 		MetricPrefix: cfg.MetricConfig.Prefix,
 
 		// Set DefaultMonitoringLabels to an empty map to avoid getting the "opencensus_task" label
@@ -233,11 +303,16 @@ func newStackdriverMetricsExporter(cfg *Config, params component.ExporterCreateP
 		options.MapResource = rm.mapResource
 	}
 
-	sde, serr := stackdriver.NewExporter(options)
-	if serr != nil {
-		return nil, fmt.Errorf("cannot configure Stackdriver metric exporter: %w", serr)
+	mExp := &metricsExporter{
+		cfg:     cfg,
+		options: options,
+	}
+	mExp.mexporters = newProjectExporters(params.Logger, cfg.MaxProjects, mExp.newExporter, shutdownMetricsExporter)
+	_, release, err := mExp.exporter(cfg.ProjectID)
+	if err != nil {
+		return nil, err
 	}
-	mExp := &metricsExporter{mexporter: sde}
+	release()
 
 	return exporterhelper.NewMetricsExporter(
 		cfg,
@@ -249,53 +324,84 @@ func newStackdriverMetricsExporter(cfg *Config, params component.ExporterCreateP
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}))
 }
 
-// pushMetrics calls StackdriverExporter.PushMetricsProto on each element of the given metrics
+// pushMetrics calls StackdriverExporter.PushMetricsProto on each element of the given metrics,
+// with the exporter of the project of its resource
 func (me *metricsExporter) pushMetrics(ctx context.Context, m pdata.Metrics) (int, error) {
 	// PushMetricsProto doesn't bundle subsequent calls, so we need to
-	// combine the data here to avoid generating too many RPC calls.
+	// combine the data of each project here to avoid generating too many RPC calls.
 	mds := internaldata.MetricsToOC(m)
-	count := 0
-	for _, md := range mds {
-		count += len(md.Metrics)
-	}
-	metrics := make([]*metricspb.Metric, 0, count)
+	projectMetrics := map[string][]*metricspb.Metric{}
 	for _, md := range mds {
+		projectID := me.cfg.ProjectID
 		if md.Resource == nil {
-			metrics = append(metrics, md.Metrics...)
+			projectMetrics[projectID] = append(projectMetrics[projectID], md.Metrics...)
 			continue
 		}
+		if v := md.Resource.Labels[me.cfg.ProjectAttribute]; me.cfg.ProjectAttribute != "" && v != "" {
+			projectID = v
+		}
 		for _, metric := range md.Metrics {
 			if metric.Resource == nil {
 				metric.Resource = md.Resource
 			}
-			metrics = append(metrics, metric)
+			projectMetrics[projectID] = append(projectMetrics[projectID], metric)
+		}
+	}
+
+	var errs []error
+	totalDropped := 0
+	for projectID, metrics := range projectMetrics {
+		points := numPoints(metrics)
+		mexporter, release, err := me.exporter(projectID)
+		if err != nil {
+			recordPointCount(ctx, 0, points, err)
+			errs = append(errs, err)
+			totalDropped += points
+			continue
 		}
+		// The two nil args here are: node (which is ignored) and resource
+		// (which we just moved to individual metrics).
+		dropped, err := mexporter.PushMetricsProto(ctx, nil, nil, metrics)
+		release()
+		recordPointCount(ctx, points-dropped, dropped, err)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		totalDropped += dropped
 	}
-	points := numPoints(metrics)
-	// The two nil args here are: node (which is ignored) and resource
-	// (which we just moved to individual metrics).
-	dropped, err := me.mexporter.PushMetricsProto(ctx, nil, nil, metrics)
-	recordPointCount(ctx, points-dropped, dropped, err)
-	return dropped, err
+	return totalDropped, componenterror.CombineErrors(errs)
 }
 
-// pushTraces calls texporter.ExportSpan for each span in the given traces
+// pushTraces calls texporter.ExportSpan for each span in the given traces,
+// with the exporter of the project of its resource
 func (te *traceExporter) pushTraces(ctx context.Context, td pdata.Traces) (int, error) {
 	var errs []error
 	resourceSpans := td.ResourceSpans()
 	numSpans := td.SpanCount()
-	spans := make([]*traceexport.SpanData, 0, numSpans)
+	projectSpans := map[string][]*traceexport.SpanData{}
 
 	for i := 0; i < resourceSpans.Len(); i++ {
-		sd := pdataResourceSpansToOTSpanData(resourceSpans.At(i))
-		spans = append(spans, sd...)
+		rs := resourceSpans.At(i)
+		projectID := resourceProject(te.cfg, rs.Resource())
+		projectSpans[projectID] = append(projectSpans[projectID], pdataResourceSpansToOTSpanData(rs)...)
 	}
 
-	err := te.texporter.ExportSpans(ctx, spans)
-	if err != nil {
-		errs = append(errs, err)
+	exported := 0
+	for projectID, spans := range projectSpans {
+		texporter, release, err := te.exporter(projectID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = texporter.ExportSpans(ctx, spans)
+		release()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		exported += len(spans)
 	}
-	return numSpans - len(spans), componenterror.CombineErrors(errs)
+	return numSpans - exported, componenterror.CombineErrors(errs)
 }
 
 func numPoints(metrics []*metricspb.Metric) int {
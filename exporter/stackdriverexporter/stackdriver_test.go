@@ -184,6 +184,52 @@ func TestStackdriverTraceExport(t *testing.T) {
 	}
 }
 
+func TestStackdriverTraceExportProjectRouting(t *testing.T) {
+	srv := grpc.NewServer()
+	reqCh := make(chan *cloudtracepb.BatchWriteSpansRequest)
+	cloudtracepb.RegisterTraceServiceServer(srv, &testServer{reqCh: reqCh})
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	go srv.Serve(lis)
+
+	cfg := &Config{
+		ProjectID:        "idk",
+		ProjectAttribute: "gcp.project.id",
+		Endpoint:         lis.Addr().String(),
+		UseInsecure:      true,
+		TraceConfig:      TraceConfig{BundleCountThreshold: 1},
+	}
+	createParams := component.ExporterCreateParams{Logger: zap.NewNop(), ApplicationStartInfo: component.ApplicationStartInfo{Version: "v0.0.1"}}
+	sde, err := newStackdriverTraceExporter(cfg, createParams)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sde.Shutdown(context.Background())) }()
+
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().Resize(2)
+	traces.ResourceSpans().At(1).Resource().Attributes().InsertString("gcp.project.id", "other")
+	for i := 0; i < 2; i++ {
+		rspans := traces.ResourceSpans().At(i)
+		rspans.InstrumentationLibrarySpans().Resize(1)
+		rspans.InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+		rspans.InstrumentationLibrarySpans().At(0).Spans().At(0).SetName(fmt.Sprintf("span%d", i))
+	}
+	require.NoError(t, sde.ConsumeTraces(context.Background(), traces))
+
+	names := map[string]string{}
+	for i := 0; i < 2; i++ {
+		r := <-reqCh
+		require.Len(t, r.Spans, 1)
+		names[r.Name] = r.Spans[0].GetDisplayName().Value
+	}
+	assert.Equal(t, map[string]string{
+		"projects/idk":   "Span.internal-span0",
+		"projects/other": "Span.internal-span1",
+	}, names)
+}
+
 type mockMetricServer struct {
 	cloudmonitoringpb.MetricServiceServer
 
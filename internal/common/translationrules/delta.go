@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translationrules
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
+)
+
+// Point is a value of a time series, as needed to compute the deltas of the
+// "delta_metric" action.
+type Point struct {
+	Timestamp   int64
+	IsDouble    bool
+	IntValue    int64
+	DoubleValue float64
+}
+
+// DeltaTracker remembers the last point of each time series for the "delta_metric"
+// action. Time series that are not updated within the configured ttl are forgotten.
+type DeltaTracker struct {
+	prevPts *ttlmap.TTLMap
+}
+
+// NewDeltaTracker creates a DeltaTracker forgetting time series after ttl seconds.
+func NewDeltaTracker(ttl int64) *DeltaTracker {
+	sweepIntervalSeconds := ttl / 2
+	if sweepIntervalSeconds == 0 {
+		sweepIntervalSeconds = 1
+	}
+	m := ttlmap.New(sweepIntervalSeconds, ttl)
+	m.Start()
+	return &DeltaTracker{prevPts: m}
+}
+
+// Delta records currPt as the last point of the time series identified by key and returns
+// its difference with the point it replaces. The Timestamp of the returned delta is the one of
+// that previous point, which starts the interval covered by the delta. A value lower than the
+// previous one is taken as a reset of the time series, and is returned as is. ok is false when
+// the time series has no previous point, or when its value type changed.
+func (d *DeltaTracker) Delta(key string, currPt Point) (delta Point, ok bool) {
+	v := d.prevPts.Get(key)
+	d.prevPts.Put(key, currPt)
+	if v == nil {
+		return Point{}, false
+	}
+	prevPt := v.(Point)
+	if currPt.IsDouble != prevPt.IsDouble {
+		return Point{}, false
+	}
+
+	delta = Point{Timestamp: prevPt.Timestamp, IsDouble: currPt.IsDouble}
+	if currPt.IsDouble {
+		delta.DoubleValue = currPt.DoubleValue - prevPt.DoubleValue
+		if delta.DoubleValue < 0 {
+			delta.DoubleValue = currPt.DoubleValue
+		}
+		return delta, true
+	}
+	delta.IntValue = currPt.IntValue - prevPt.IntValue
+	if delta.IntValue < 0 {
+		delta.IntValue = currPt.IntValue
+	}
+	return delta, true
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translationrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaTracker(t *testing.T) {
+	d := NewDeltaTracker(3600)
+
+	_, ok := d.Delta("int", Point{Timestamp: 1, IntValue: 10})
+	assert.False(t, ok)
+	delta, ok := d.Delta("int", Point{Timestamp: 2, IntValue: 15})
+	assert.True(t, ok)
+	assert.Equal(t, Point{Timestamp: 1, IntValue: 5}, delta)
+	delta, ok = d.Delta("int", Point{Timestamp: 3, IntValue: 4})
+	assert.True(t, ok)
+	assert.Equal(t, Point{Timestamp: 2, IntValue: 4}, delta, "a decrease is a reset")
+
+	_, ok = d.Delta("double", Point{Timestamp: 1, IsDouble: true, DoubleValue: 1.5})
+	assert.False(t, ok)
+	delta, ok = d.Delta("double", Point{Timestamp: 2, IsDouble: true, DoubleValue: 4})
+	assert.True(t, ok)
+	assert.Equal(t, Point{Timestamp: 1, IsDouble: true, DoubleValue: 2.5}, delta)
+
+	_, ok = d.Delta("double", Point{Timestamp: 3, IntValue: 5})
+	assert.False(t, ok, "no delta across value types")
+	delta, ok = d.Delta("double", Point{Timestamp: 4, IntValue: 7})
+	assert.True(t, ok)
+	assert.Equal(t, Point{Timestamp: 3, IntValue: 2}, delta)
+}
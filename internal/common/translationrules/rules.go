@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package translationrules holds the metric translation rules shared by the
+// SignalFx exporter and the metrics translation processor.
+package translationrules
+
+import (
+	"fmt"
+)
+
+// Action is the enum to capture actions to perform on metrics.
+type Action string
+
+const (
+	// ActionRenameDimensionKeys renames dimension keys using Rule.Mapping.
+	// The rule can be applied only to particular metrics if MetricNames are provided,
+	// otherwise applied to all metrics.
+	ActionRenameDimensionKeys Action = "rename_dimension_keys"
+
+	// ActionRenameMetrics renames metrics using Rule.Mapping.
+	ActionRenameMetrics Action = "rename_metrics"
+
+	// ActionMultiplyInt scales integer metrics by multiplying their values using
+	// Rule.ScaleFactorsInt key/values as metric_name/multiplying_factor
+	ActionMultiplyInt Action = "multiply_int"
+
+	// ActionDivideInt scales integer metric by dividing their values using
+	// Rule.ScaleFactorsInt key/values as metric_name/divisor
+	ActionDivideInt Action = "divide_int"
+
+	// ActionMultiplyFloat scales float metric by multiplying their values using
+	// Rule.ScaleFactorsFloat key/values as metric_name/multiplying_factor
+	ActionMultiplyFloat Action = "multiply_float"
+
+	// ActionConvertValues converts float metrics values to integer values, or the other way
+	// around, using Rule.TypesMapping key/values as metric_name/new_type.
+	ActionConvertValues Action = "convert_values"
+
+	// ActionCopyMetrics copies metrics using Rule.Mapping.
+	// Rule.DimensionKey and Rule.DimensionValues can be used to filter datapoints that must be copied,
+	// if these fields are set, only datapoints having a dimension with key == Rule.DimensionKey and
+	// value in Rule.DimensionValues will be copied.
+	ActionCopyMetrics Action = "copy_metrics"
+
+	// ActionSplitMetric splits a metric with Rule.MetricName into multiple metrics
+	// based on a dimension specified in Rule.DimensionKey.
+	// Rule.Mapping represents "dimension value" -> "new metric name" for the translation.
+	// For example, having the following translation rule:
+	//   - action: split_metric
+	//     metric_name: k8s.pod.network.io
+	//     dimension_key: direction
+	//     mapping:
+	//       receive: pod_network_receive_bytes_total
+	//       transmit: pod_network_transmit_bytes_total
+	// The following translations will be performed:
+	// k8s.pod.network.io{direction="receive"} -> pod_network_receive_bytes_total{}
+	// k8s.pod.network.io{direction="transmit"} -> pod_network_transmit_bytes_total{}
+	ActionSplitMetric Action = "split_metric"
+
+	// ActionAggregateMetric aggregates the datapoints of the metric Rule.MetricName excluding
+	// dimensions set in Rule.WithoutDimensions. This method is equivalent of "without" clause
+	// in Prometheus aggregation:
+	// https://prometheus.io/docs/prometheus/latest/querying/operators/#aggregation-operators
+	// Rule.AggregationMethod is used to specify a method to aggregate the values.
+	// For example, having the following translation rule:
+	// - action: aggregate_metric
+	//   metric_name: machine_cpu_cores
+	//   aggregation_method: count
+	//   without_dimensions:
+	//   - cpu
+	// The following translations will be performed:
+	// Original datapoints:
+	//   machine_cpu_cores{cpu="cpu1",host="host1"} 0.22
+	//   machine_cpu_cores{cpu="cpu2",host="host1"} 0.11
+	//   machine_cpu_cores{cpu="cpu1",host="host2"} 0.33
+	// Transformed datapoints:
+	//   machine_cpu_cores{host="host1"} 2
+	//   machine_cpu_cores{host="host2"} 1
+	ActionAggregateMetric Action = "aggregate_metric"
+
+	// ActionCalculateNewMetric calculates a new metric based on two existing metrics.
+	// It takes two operand metrics, an operator, and a metric name and produces a new metric with the given
+	// metric name, but with the dimensions of the first operand metric.
+	// For example, for the following translation rule:
+	// - action: calculate_new_metric
+	//   metric_name: memory.utilization
+	//   operand1_metric: memory.used
+	//   operand2_metric: memory.total
+	//   operator: /
+	// the value of the 'memory.used' metric will be divided by the value of 'memory.total'. The
+	// result will be a new double gauge with the name 'memory.utilization' and the value of the quotient,
+	// for each pair of datapoints of the operand metrics with the same dimensions.
+	// Currently only division is supported.
+	ActionCalculateNewMetric Action = "calculate_new_metric"
+
+	// ActionDropMetrics drops metrics with names defined in "metric_names".
+	ActionDropMetrics Action = "drop_metrics"
+
+	// ActionDeltaMetric creates a new delta gauge metric from an existing int or double gauge or
+	// sum metric. It takes mappings of names of the existing metrics to the names of the new, delta
+	// metrics to be created. All dimensions will be preserved.
+	ActionDeltaMetric Action = "delta_metric"
+)
+
+// MetricOperator is the enum to capture operators of the calculate_new_metric action
+type MetricOperator string
+
+const (
+	// MetricOperatorDivision represents the division operator
+	MetricOperatorDivision MetricOperator = "/"
+)
+
+// MetricValueType is the enum to capture valid metric value types that can be converted
+type MetricValueType string
+
+const (
+	// MetricValueTypeInt represents integer metric value type
+	MetricValueTypeInt MetricValueType = "int"
+	// MetricValueTypeDouble represents double metric value type
+	MetricValueTypeDouble MetricValueType = "double"
+)
+
+// AggregationMethod is the enum used to capture aggregation method
+type AggregationMethod string
+
+const (
+	// AggregationMethodCount represents count aggregation method
+	AggregationMethodCount AggregationMethod = "count"
+	// AggregationMethodAvg represents average aggregation method
+	AggregationMethodAvg AggregationMethod = "avg"
+	// AggregationMethodSum represents sum aggregation method
+	AggregationMethodSum AggregationMethod = "sum"
+)
+
+// Rule is a translation rule. The dimensions of a metric are the labels of its datapoints.
+type Rule struct {
+	// Action specifies the translation action to be applied on metrics.
+	// This is a required field.
+	Action Action `mapstructure:"action"`
+
+	// Mapping specifies key/value mapping that is used by rename_dimension_keys,
+	// rename_metrics, copy_metrics, split_metric and delta_metric actions.
+	Mapping map[string]string `mapstructure:"mapping"`
+
+	// ScaleFactorsInt is used by multiply_int and divide_int action to scale
+	// integer metric values, key/value format: metric_name/scale_factor
+	ScaleFactorsInt map[string]int64 `mapstructure:"scale_factors_int"`
+
+	// ScaleFactorsFloat is used by multiply_float action to scale
+	// float metric values, key/value format: metric_name/scale_factor
+	ScaleFactorsFloat map[string]float64 `mapstructure:"scale_factors_float"`
+
+	// MetricName is used by "split_metric", "aggregate_metric" and "calculate_new_metric"
+	// translation rules to specify the name of the metric to translate or to create.
+	MetricName string `mapstructure:"metric_name"`
+
+	// DimensionKey is used by "split_metric" translation rule action to specify dimension key
+	// that will be used to translate the metric datapoints. Datapoints that don't have
+	// the specified dimension key will not be translated.
+	// DimensionKey is also used by "copy_metrics" for filtering.
+	DimensionKey string `mapstructure:"dimension_key"`
+
+	// DimensionValues is used by "copy_metrics" to filter out datapoints with dimensions values
+	// not matching values set in this field
+	DimensionValues map[string]bool `mapstructure:"dimension_values"`
+
+	// TypesMapping is represents metric_name/metric_type key/value pairs,
+	// used by ActionConvertValues.
+	TypesMapping map[string]MetricValueType `mapstructure:"types_mapping"`
+
+	// AggregationMethod specifies method used by "aggregate_metric" translation rule
+	AggregationMethod AggregationMethod `mapstructure:"aggregation_method"`
+
+	// WithoutDimensions used by "aggregate_metric" translation rule to specify dimensions to be
+	// excluded by aggregation.
+	WithoutDimensions []string `mapstructure:"without_dimensions"`
+
+	// AddDimensions used by "rename_metrics" translation rule to add dimensions to the renamed metrics.
+	AddDimensions map[string]string `mapstructure:"add_dimensions"`
+
+	// CopyDimensions used by "rename_metrics" translation rule to copy dimensions of the renamed
+	// metrics. This will duplicate the dimension value and isn't a rename.
+	CopyDimensions map[string]string `mapstructure:"copy_dimensions"`
+
+	// MetricNames is used by "rename_dimension_keys" and "drop_metrics" translation rules.
+	MetricNames map[string]bool `mapstructure:"metric_names"`
+
+	// Operand1Metric, Operand2Metric and Operator are used by "calculate_new_metric"
+	// translation rule to specify the operation computing the new metric.
+	Operand1Metric string         `mapstructure:"operand1_metric"`
+	Operand2Metric string         `mapstructure:"operand2_metric"`
+	Operator       MetricOperator `mapstructure:"operator"`
+}
+
+// Validate returns an error if one of the rules lacks a field its action requires
+// or holds an invalid value.
+func Validate(rules []Rule) error {
+	var renameDimensionKeysFound bool
+	for _, tr := range rules {
+		switch tr.Action {
+		case ActionRenameDimensionKeys:
+			if tr.Mapping == nil {
+				return fmt.Errorf("field \"mapping\" is required for %q translation rule", tr.Action)
+			}
+			if len(tr.MetricNames) == 0 {
+				if renameDimensionKeysFound {
+					return fmt.Errorf("only one %q translation rule without \"metric_names\" can be specified", tr.Action)
+				}
+				renameDimensionKeysFound = true
+			}
+		case ActionRenameMetrics:
+			if tr.Mapping == nil {
+				return fmt.Errorf("field \"mapping\" is required for %q translation rule", tr.Action)
+			}
+			for k, v := range tr.CopyDimensions {
+				if k == "" || v == "" {
+					return fmt.Errorf("mapping \"copy_dimensions\" for %q translation rule must not contain empty string keys or values", tr.Action)
+				}
+			}
+		case ActionMultiplyInt:
+			if tr.ScaleFactorsInt == nil {
+				return fmt.Errorf("field \"scale_factors_int\" is required for %q translation rule", tr.Action)
+			}
+		case ActionDivideInt:
+			if tr.ScaleFactorsInt == nil {
+				return fmt.Errorf("field \"scale_factors_int\" is required for %q translation rule", tr.Action)
+			}
+			for k, v := range tr.ScaleFactorsInt {
+				if v == 0 {
+					return fmt.Errorf("\"scale_factors_int\" for %q translation rule has 0 value for %q metric", tr.Action, k)
+				}
+			}
+		case ActionMultiplyFloat:
+			if tr.ScaleFactorsFloat == nil {
+				return fmt.Errorf("field \"scale_factors_float\" is required for %q translation rule", tr.Action)
+			}
+		case ActionCopyMetrics:
+			if tr.Mapping == nil {
+				return fmt.Errorf("field \"mapping\" is required for %q translation rule", tr.Action)
+			}
+			if tr.DimensionKey != "" && len(tr.DimensionValues) == 0 {
+				return fmt.Errorf(
+					"\"dimension_values\" has to be provided if \"dimension_key\" is set for %q translation rule",
+					tr.Action)
+			}
+		case ActionSplitMetric:
+			if tr.MetricName == "" || tr.DimensionKey == "" || tr.Mapping == nil {
+				return fmt.Errorf(
+					"fields \"metric_name\", \"dimension_key\", and \"mapping\" are required for %q translation rule",
+					tr.Action)
+			}
+		case ActionConvertValues:
+			if tr.TypesMapping == nil {
+				return fmt.Errorf("field \"types_mapping\" are required for %q translation rule", tr.Action)
+			}
+			for k, v := range tr.TypesMapping {
+				if v != MetricValueTypeInt && v != MetricValueTypeDouble {
+					return fmt.Errorf("invalid value type %q set for metric %q in \"types_mapping\"", v, k)
+				}
+			}
+		case ActionAggregateMetric:
+			if tr.MetricName == "" || tr.AggregationMethod == "" || len(tr.WithoutDimensions) == 0 {
+				return fmt.Errorf("fields \"metric_name\", \"without_dimensions\", and \"aggregation_method\" "+
+					"are required for %q translation rule", tr.Action)
+			}
+			if tr.AggregationMethod != AggregationMethodCount &&
+				tr.AggregationMethod != AggregationMethodSum &&
+				tr.AggregationMethod != AggregationMethodAvg {
+				return fmt.Errorf("invalid \"aggregation_method\": %q provided for %q translation rule",
+					tr.AggregationMethod, tr.Action)
+			}
+		case ActionCalculateNewMetric:
+			if tr.MetricName == "" || tr.Operand1Metric == "" || tr.Operand2Metric == "" || tr.Operator == "" {
+				return fmt.Errorf(`fields "metric_name", "operand1_metric", "operand2_metric", and "operator" are `+
+					"required for %q translation rule", tr.Action)
+			}
+			if tr.Operator != MetricOperatorDivision {
+				return fmt.Errorf("invalid operator %q for %q translation rule", tr.Operator, tr.Action)
+			}
+		case ActionDropMetrics:
+			if len(tr.MetricNames) == 0 {
+				return fmt.Errorf(`field "metric_names" is required for %q translation rule`, tr.Action)
+			}
+		case ActionDeltaMetric:
+			if len(tr.Mapping) == 0 {
+				return fmt.Errorf(`field "mapping" is required for %q translation rule`, tr.Action)
+			}
+		default:
+			return fmt.Errorf("unknown \"action\" value: %q", tr.Action)
+		}
+	}
+	return nil
+}
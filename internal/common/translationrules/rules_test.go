@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translationrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []Rule
+		wantError string
+	}{
+		{
+			name:      "unknown_action",
+			rules:     []Rule{{Action: "invalid_rule"}},
+			wantError: `unknown "action" value: "invalid_rule"`,
+		},
+		{
+			name: "rename_dimension_keys_many_without_metric_names",
+			rules: []Rule{
+				{Action: ActionRenameDimensionKeys, Mapping: map[string]string{"a": "b"}},
+				{Action: ActionRenameDimensionKeys, Mapping: map[string]string{"c": "d"}},
+			},
+			wantError: `only one "rename_dimension_keys" translation rule without "metric_names" can be specified`,
+		},
+		{
+			name:      "rename_metrics_no_mapping",
+			rules:     []Rule{{Action: ActionRenameMetrics}},
+			wantError: `field "mapping" is required for "rename_metrics" translation rule`,
+		},
+		{
+			name: "rename_metrics_empty_copy_dimension",
+			rules: []Rule{{
+				Action:         ActionRenameMetrics,
+				Mapping:        map[string]string{"a": "b"},
+				CopyDimensions: map[string]string{"a": ""},
+			}},
+			wantError: `mapping "copy_dimensions" for "rename_metrics" translation rule must not contain empty string keys or values`,
+		},
+		{
+			name:      "divide_int_by_zero",
+			rules:     []Rule{{Action: ActionDivideInt, ScaleFactorsInt: map[string]int64{"metric": 0}}},
+			wantError: `"scale_factors_int" for "divide_int" translation rule has 0 value for "metric" metric`,
+		},
+		{
+			name:      "multiply_float_no_scale_factors",
+			rules:     []Rule{{Action: ActionMultiplyFloat}},
+			wantError: `field "scale_factors_float" is required for "multiply_float" translation rule`,
+		},
+		{
+			name:      "copy_metrics_dimension_key_without_values",
+			rules:     []Rule{{Action: ActionCopyMetrics, Mapping: map[string]string{"a": "b"}, DimensionKey: "dim"}},
+			wantError: `"dimension_values" has to be provided if "dimension_key" is set for "copy_metrics" translation rule`,
+		},
+		{
+			name:      "convert_values_invalid_type",
+			rules:     []Rule{{Action: ActionConvertValues, TypesMapping: map[string]MetricValueType{"metric": "string"}}},
+			wantError: `invalid value type "string" set for metric "metric" in "types_mapping"`,
+		},
+		{
+			name: "aggregate_metric_invalid_method",
+			rules: []Rule{{
+				Action:            ActionAggregateMetric,
+				MetricName:        "metric",
+				WithoutDimensions: []string{"dim"},
+				AggregationMethod: "max",
+			}},
+			wantError: `invalid "aggregation_method": "max" provided for "aggregate_metric" translation rule`,
+		},
+		{
+			name: "calculate_new_metric_invalid_operator",
+			rules: []Rule{{
+				Action:         ActionCalculateNewMetric,
+				MetricName:     "metric",
+				Operand1Metric: "metric1",
+				Operand2Metric: "metric2",
+				Operator:       "*",
+			}},
+			wantError: `invalid operator "*" for "calculate_new_metric" translation rule`,
+		},
+		{
+			name:      "drop_metrics_no_metric_names",
+			rules:     []Rule{{Action: ActionDropMetrics}},
+			wantError: `field "metric_names" is required for "drop_metrics" translation rule`,
+		},
+		{
+			name:      "delta_metric_no_mapping",
+			rules:     []Rule{{Action: ActionDeltaMetric}},
+			wantError: `field "mapping" is required for "delta_metric" translation rule`,
+		},
+		{
+			name: "valid",
+			rules: []Rule{
+				{Action: ActionRenameDimensionKeys, Mapping: map[string]string{"a": "b"}},
+				{Action: ActionRenameDimensionKeys, Mapping: map[string]string{"c": "d"}, MetricNames: map[string]bool{"metric": true}},
+				{Action: ActionSplitMetric, MetricName: "metric", DimensionKey: "dim", Mapping: map[string]string{"a": "metric.a"}},
+				{Action: ActionDeltaMetric, Mapping: map[string]string{"metric": "metric.delta"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.rules)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
+)
+
+// Config defines configuration for the metrics translation processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Rules defines the translation rules applied in order to the metrics, see the README
+	// for the actions and their fields. They follow the translation rules of the SignalFx exporter.
+	Rules []translationrules.Rule `mapstructure:"rules"`
+
+	// DeltaTranslationTTL specifies in seconds the max duration to keep the most recent datapoint for any
+	// `delta_metric` specified in Rules. Default is 3600s.
+	DeltaTranslationTTL int64 `mapstructure:"delta_translation_ttl"`
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	config, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), config.Processors["metricstranslation"])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: "metricstranslation/rules",
+		},
+		Rules: []translationrules.Rule{
+			{
+				Action:  translationrules.ActionRenameMetrics,
+				Mapping: map[string]string{"system.cpu.time": "cpu.time"},
+			},
+			{
+				Action:          translationrules.ActionMultiplyInt,
+				ScaleFactorsInt: map[string]int64{"memory.used": 1024},
+			},
+			{
+				Action:         translationrules.ActionCalculateNewMetric,
+				MetricName:     "memory.utilization",
+				Operand1Metric: "memory.used",
+				Operand2Metric: "memory.total",
+				Operator:       translationrules.MetricOperatorDivision,
+			},
+			{
+				Action:  translationrules.ActionDeltaMetric,
+				Mapping: map[string]string{"cpu.time": "cpu.time.delta"},
+			},
+		},
+		DeltaTranslationTTL: 600,
+	}, config.Processors["metricstranslation/rules"])
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// forEachLabels calls f with the labels of each datapoint of the metric.
+func forEachLabels(metric pdata.Metric, f func(labels pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	}
+}
+
+// dataPointCount returns the number of datapoints of the metric.
+func dataPointCount(metric pdata.Metric) int {
+	var count int
+	forEachLabels(metric, func(pdata.StringMap) {
+		count++
+	})
+	return count
+}
+
+// filterDataPoints removes the datapoints of the metric for which keep returns false.
+func filterDataPoints(metric pdata.Metric, keep func(labels pdata.StringMap) bool) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+		filterIntDataPoints(intDataPoints(metric), keep)
+	case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+		filterDoubleDataPoints(doubleDataPoints(metric), keep)
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		kept := pdata.NewIntHistogramDataPointSlice()
+		for i := 0; i < dps.Len(); i++ {
+			if keep(dps.At(i).LabelsMap()) {
+				kept.Append(dps.At(i))
+			}
+		}
+		dps.Resize(0)
+		kept.MoveAndAppendTo(dps)
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		kept := pdata.NewDoubleHistogramDataPointSlice()
+		for i := 0; i < dps.Len(); i++ {
+			if keep(dps.At(i).LabelsMap()) {
+				kept.Append(dps.At(i))
+			}
+		}
+		dps.Resize(0)
+		kept.MoveAndAppendTo(dps)
+	case pdata.MetricDataTypeDoubleSummary:
+		dps := metric.DoubleSummary().DataPoints()
+		kept := pdata.NewDoubleSummaryDataPointSlice()
+		for i := 0; i < dps.Len(); i++ {
+			if keep(dps.At(i).LabelsMap()) {
+				kept.Append(dps.At(i))
+			}
+		}
+		dps.Resize(0)
+		kept.MoveAndAppendTo(dps)
+	}
+}
+
+func filterIntDataPoints(dps pdata.IntDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewIntDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+func filterDoubleDataPoints(dps pdata.DoubleDataPointSlice, keep func(labels pdata.StringMap) bool) {
+	kept := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < dps.Len(); i++ {
+		if keep(dps.At(i).LabelsMap()) {
+			kept.Append(dps.At(i))
+		}
+	}
+	dps.Resize(0)
+	kept.MoveAndAppendTo(dps)
+}
+
+// numberPoint is a datapoint of an int or double gauge or sum.
+type numberPoint struct {
+	labels      pdata.StringMap
+	startTime   pdata.TimestampUnixNano
+	timestamp   pdata.TimestampUnixNano
+	isDouble    bool
+	intValue    int64
+	doubleValue float64
+}
+
+func (p numberPoint) value() float64 {
+	if p.isDouble {
+		return p.doubleValue
+	}
+	return float64(p.intValue)
+}
+
+// numberPoints returns the datapoints of the metric if it is an int or double gauge or sum.
+func numberPoints(metric pdata.Metric) ([]numberPoint, bool) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+		dps := intDataPoints(metric)
+		points := make([]numberPoint, 0, dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, numberPoint{
+				labels:    dp.LabelsMap(),
+				startTime: dp.StartTime(),
+				timestamp: dp.Timestamp(),
+				intValue:  dp.Value(),
+			})
+		}
+		return points, true
+	case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+		dps := doubleDataPoints(metric)
+		points := make([]numberPoint, 0, dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, numberPoint{
+				labels:      dp.LabelsMap(),
+				startTime:   dp.StartTime(),
+				timestamp:   dp.Timestamp(),
+				isDouble:    true,
+				doubleValue: dp.Value(),
+			})
+		}
+		return points, true
+	}
+	return nil, false
+}
+
+// intDataPoints returns the datapoints of an int gauge or sum.
+func intDataPoints(metric pdata.Metric) pdata.IntDataPointSlice {
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		return metric.IntSum().DataPoints()
+	}
+	return metric.IntGauge().DataPoints()
+}
+
+// doubleDataPoints returns the datapoints of a double gauge or sum.
+func doubleDataPoints(metric pdata.Metric) pdata.DoubleDataPointSlice {
+	if metric.DataType() == pdata.MetricDataTypeDoubleSum {
+		return metric.DoubleSum().DataPoints()
+	}
+	return metric.DoubleGauge().DataPoints()
+}
+
+func isDouble(metric pdata.Metric) bool {
+	return metric.DataType() == pdata.MetricDataTypeDoubleGauge || metric.DataType() == pdata.MetricDataTypeDoubleSum
+}
+
+// appendIntPoint appends a datapoint with the labels and times of p to dps.
+func appendIntPoint(dps pdata.IntDataPointSlice, p numberPoint, labels pdata.StringMap, value int64) {
+	dp := pdata.NewIntDataPoint()
+	labels.CopyTo(dp.LabelsMap())
+	dp.SetStartTime(p.startTime)
+	dp.SetTimestamp(p.timestamp)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
+
+// appendDoublePoint appends a datapoint with the labels and times of p to dps.
+func appendDoublePoint(dps pdata.DoubleDataPointSlice, p numberPoint, labels pdata.StringMap, value float64) {
+	dp := pdata.NewDoubleDataPoint()
+	labels.CopyTo(dp.LabelsMap())
+	dp.SetStartTime(p.startTime)
+	dp.SetTimestamp(p.timestamp)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
+
+// stringifyLabels turns the passed-in labels into a string while ignoring the
+// passed-in exclusions. The result has the following form: dim1:val1//dim2:val2.
+// Order is deterministic so this function can be used to generate map keys.
+func stringifyLabels(labels pdata.StringMap, exclusions []string) string {
+	const aggregationKeyDelimiter = "//"
+	parts := make([]string, 0, labels.Len())
+	labels.ForEach(func(k string, v string) {
+		if !contains(exclusions, k) {
+			parts = append(parts, k+":"+v)
+		}
+	})
+	sort.Strings(parts)
+	return strings.Join(parts, aggregationKeyDelimiter)
+}
+
+// filterLabels returns the labels excluding withoutDimensions.
+func filterLabels(labels pdata.StringMap, withoutDimensions []string) pdata.StringMap {
+	result := pdata.NewStringMap()
+	labels.ForEach(func(k string, v string) {
+		if !contains(withoutDimensions, k) {
+			result.Insert(k, v)
+		}
+	})
+	return result
+}
+
+// labelsEqual checks if the labels have the same keys and values.
+func labelsEqual(l1 pdata.StringMap, l2 pdata.StringMap) bool {
+	if l1.Len() != l2.Len() {
+		return false
+	}
+	equal := true
+	l1.ForEach(func(k string, v string) {
+		if v2, ok := l2.Get(k); !ok || v != v2 {
+			equal = false
+		}
+	})
+	return equal
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
+)
+
+type deltaTranslator struct {
+	tracker *translationrules.DeltaTracker
+}
+
+func newDeltaTranslator(ttl int64) *deltaTranslator {
+	return &deltaTranslator{tracker: translationrules.NewDeltaTracker(ttl)}
+}
+
+// translate adds a delta gauge for each metric of Rule.Mapping, with the differences between
+// the values of its datapoints and the previous ones of the same resource and dimensions.
+func (t *deltaTranslator) translate(resource pdata.Resource, metrics pdata.MetricSlice, tr translationrules.Rule) {
+	resourceKey := stringifyAttributes(resource.Attributes())
+	count := metrics.Len()
+	for i := 0; i < count; i++ {
+		metric := metrics.At(i)
+		deltaMetricName, ok := tr.Mapping[metric.Name()]
+		if !ok {
+			// only metrics defined in Rule.Mapping get translated
+			continue
+		}
+		points, ok := numberPoints(metric)
+		if !ok {
+			continue
+		}
+
+		deltaMetric := newMetricLike(metric)
+		deltaMetric.SetName(deltaMetricName)
+		if isDouble(metric) {
+			deltaMetric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		} else {
+			deltaMetric.SetDataType(pdata.MetricDataTypeIntGauge)
+		}
+		for _, currPt := range points {
+			t.deltaPt(deltaMetric, metric.Name()+":"+resourceKey, currPt)
+		}
+		if dataPointCount(deltaMetric) > 0 {
+			metrics.Append(deltaMetric)
+		}
+	}
+}
+
+// deltaPt adds the delta of currPt with the previous datapoint to deltaMetric, if any.
+func (t *deltaTranslator) deltaPt(deltaMetric pdata.Metric, metricKey string, currPt numberPoint) {
+	delta, ok := t.tracker.Delta(metricKey+":"+stringifyLabels(currPt.labels, nil), translationrules.Point{
+		Timestamp:   int64(currPt.timestamp),
+		IsDouble:    currPt.isDouble,
+		IntValue:    currPt.intValue,
+		DoubleValue: currPt.doubleValue,
+	})
+	if !ok {
+		return
+	}
+
+	deltaPt := currPt
+	deltaPt.startTime = pdata.TimestampUnixNano(delta.Timestamp)
+	if delta.IsDouble {
+		appendDoublePoint(deltaMetric.DoubleGauge().DataPoints(), deltaPt, currPt.labels, delta.DoubleValue)
+		return
+	}
+	appendIntPoint(deltaMetric.IntGauge().DataPoints(), deltaPt, currPt.labels, delta.IntValue)
+}
+
+// stringifyAttributes turns the attributes into a string, in the format of stringifyLabels.
+func stringifyAttributes(attrs pdata.AttributeMap) string {
+	parts := make([]string, 0, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		parts = append(parts, k+":"+tracetranslator.AttributeValueToString(v, false))
+	})
+	sort.Strings(parts)
+	return strings.Join(parts, "//")
+}
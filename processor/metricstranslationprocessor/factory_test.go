@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestType(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, configmodels.Type("metricstranslation"), factory.Type())
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+		DeltaTranslationTTL: 3600,
+	}, cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	config, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	for _, name := range []string{"metricstranslation", "metricstranslation/rules"} {
+		t.Run(name, func(t *testing.T) {
+			tp, err := factory.CreateTracesProcessor(context.Background(), params, config.Processors[name], consumertest.NewTracesNop())
+			// Not implemented error
+			assert.Error(t, err)
+			assert.Nil(t, tp)
+
+			mp, err := factory.CreateMetricsProcessor(context.Background(), params, config.Processors[name], consumertest.NewMetricsNop())
+			assert.NoError(t, err)
+			assert.NotNil(t, mp)
+			assert.True(t, mp.GetCapabilities().MutatesConsumedData)
+		})
+	}
+
+	mp, err := factory.CreateMetricsProcessor(
+		context.Background(), params, config.Processors["metricstranslation/invalid"], consumertest.NewMetricsNop())
+	assert.EqualError(t, err, `invalid "rules": fields "metric_name", "dimension_key", and "mapping" are required for "split_metric" translation rule`)
+	assert.Nil(t, mp)
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+type metricsTranslationProcessor struct {
+	translator *metricTranslator
+}
+
+var _ processorhelper.MProcessor = (*metricsTranslationProcessor)(nil)
+
+func newMetricsTranslationProcessor(logger *zap.Logger, cfg *Config) (*metricsTranslationProcessor, error) {
+	translator, err := newMetricTranslator(logger, cfg.Rules, cfg.DeltaTranslationTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsTranslationProcessor{translator: translator}, nil
+}
+
+// ProcessMetrics applies the translation rules to the metrics.
+func (mtp *metricsTranslationProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	mtp.translator.translate(md)
+	return md, nil
+}
@@ -0,0 +1,450 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
+)
+
+// metricTranslator applies the translation rules to the metrics of each resource
+// and instrumentation library, the metrics created by a rule are added to the
+// instrumentation library of the metrics they are created from.
+type metricTranslator struct {
+	logger *zap.Logger
+	rules  []translationrules.Rule
+
+	deltaTranslator *deltaTranslator
+}
+
+func newMetricTranslator(logger *zap.Logger, rules []translationrules.Rule, ttl int64) (*metricTranslator, error) {
+	if err := translationrules.Validate(rules); err != nil {
+		return nil, err
+	}
+
+	mt := &metricTranslator{
+		logger: logger,
+		rules:  rules,
+	}
+	for _, tr := range rules {
+		if tr.Action == translationrules.ActionDeltaMetric {
+			mt.deltaTranslator = newDeltaTranslator(ttl)
+			break
+		}
+	}
+	return mt, nil
+}
+
+// translate applies the translation rules to md in place.
+func (mt *metricTranslator) translate(md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			mt.translateMetrics(rm.Resource(), ilms.At(j).Metrics())
+		}
+	}
+}
+
+func (mt *metricTranslator) translateMetrics(resource pdata.Resource, metrics pdata.MetricSlice) {
+	for _, tr := range mt.rules {
+		// The metrics created by a rule are not translated by the rule itself
+		count := metrics.Len()
+		switch tr.Action {
+		case translationrules.ActionRenameDimensionKeys:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if len(tr.MetricNames) > 0 && !tr.MetricNames[metric.Name()] {
+					continue
+				}
+				forEachLabels(metric, func(labels pdata.StringMap) {
+					renameLabelKeys(labels, tr.Mapping)
+				})
+			}
+		case translationrules.ActionRenameMetrics:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if newName, ok := tr.Mapping[metric.Name()]; ok {
+					metric.SetName(newName)
+					forEachLabels(metric, func(labels pdata.StringMap) {
+						for k, newKey := range tr.CopyDimensions {
+							if v, ok := labels.Get(k); ok {
+								labels.Upsert(newKey, v)
+							}
+						}
+						for k, v := range tr.AddDimensions {
+							labels.Upsert(k, v)
+						}
+					})
+				}
+			}
+		case translationrules.ActionMultiplyInt:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if multiplier, ok := tr.ScaleFactorsInt[metric.Name()]; ok {
+					scaleIntMetric(metric, func(v int64) int64 { return v * multiplier })
+				}
+			}
+		case translationrules.ActionDivideInt:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if divisor, ok := tr.ScaleFactorsInt[metric.Name()]; ok {
+					scaleIntMetric(metric, func(v int64) int64 { return v / divisor })
+				}
+			}
+		case translationrules.ActionMultiplyFloat:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if multiplier, ok := tr.ScaleFactorsFloat[metric.Name()]; ok {
+					scaleDoubleMetric(metric, multiplier)
+				}
+			}
+		case translationrules.ActionConvertValues:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if newType, ok := tr.TypesMapping[metric.Name()]; ok {
+					convertMetricValues(mt.logger, metric, newType)
+				}
+			}
+		case translationrules.ActionCopyMetrics:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if newName, ok := tr.Mapping[metric.Name()]; ok {
+					if copied, ok := copyMetric(tr, metric, newName); ok {
+						metrics.Append(copied)
+					}
+				}
+			}
+		case translationrules.ActionSplitMetric:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if metric.Name() == tr.MetricName {
+					splitMetric(metrics, metric, tr.DimensionKey, tr.Mapping)
+				}
+			}
+			removeEmptyMetrics(metrics)
+		case translationrules.ActionAggregateMetric:
+			for i := 0; i < count; i++ {
+				metric := metrics.At(i)
+				if metric.Name() == tr.MetricName {
+					aggregateMetric(mt.logger, metric, tr.WithoutDimensions, tr.AggregationMethod)
+				}
+			}
+		case translationrules.ActionCalculateNewMetric:
+			if newMetric, ok := calculateNewMetric(mt.logger, metrics, tr); ok {
+				metrics.Append(newMetric)
+			}
+		case translationrules.ActionDropMetrics:
+			removeMetrics(metrics, func(metric pdata.Metric) bool {
+				return tr.MetricNames[metric.Name()]
+			})
+		case translationrules.ActionDeltaMetric:
+			mt.deltaTranslator.translate(resource, metrics, tr)
+		}
+	}
+}
+
+// renameLabelKeys renames the keys of the labels using mapping
+func renameLabelKeys(labels pdata.StringMap, mapping map[string]string) {
+	renamed := make(map[string]string)
+	labels.ForEach(func(k string, v string) {
+		if newKey, ok := mapping[k]; ok {
+			renamed[newKey] = v
+		}
+	})
+	for k := range mapping {
+		labels.Delete(k)
+	}
+	for k, v := range renamed {
+		labels.Upsert(k, v)
+	}
+}
+
+func scaleIntMetric(metric pdata.Metric, scale func(int64) int64) {
+	if metric.DataType() != pdata.MetricDataTypeIntGauge && metric.DataType() != pdata.MetricDataTypeIntSum {
+		return
+	}
+	dps := intDataPoints(metric)
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).SetValue(scale(dps.At(i).Value()))
+	}
+}
+
+func scaleDoubleMetric(metric pdata.Metric, multiplier float64) {
+	if metric.DataType() != pdata.MetricDataTypeDoubleGauge && metric.DataType() != pdata.MetricDataTypeDoubleSum {
+		return
+	}
+	dps := doubleDataPoints(metric)
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).SetValue(dps.At(i).Value() * multiplier)
+	}
+}
+
+// convertedTypes are the types the values of the metrics are converted to by convert_values
+var convertedTypes = map[pdata.MetricDataType]pdata.MetricDataType{
+	pdata.MetricDataTypeDoubleGauge: pdata.MetricDataTypeIntGauge,
+	pdata.MetricDataTypeDoubleSum:   pdata.MetricDataTypeIntSum,
+	pdata.MetricDataTypeIntGauge:    pdata.MetricDataTypeDoubleGauge,
+	pdata.MetricDataTypeIntSum:      pdata.MetricDataTypeDoubleSum,
+}
+
+// convertMetricValues converts the values of a double gauge or sum to int, or the other way around.
+func convertMetricValues(logger *zap.Logger, metric pdata.Metric, newType translationrules.MetricValueType) {
+	points, ok := numberPoints(metric)
+	if !ok || (newType == translationrules.MetricValueTypeInt) != isDouble(metric) {
+		logger.Debug(fmt.Sprintf("only metrics of %q type can be converted to %s", oppositeType(newType), newType),
+			zap.String("metric", metric.Name()))
+		return
+	}
+
+	converted := newMetricLike(metric)
+	converted.SetDataType(convertedTypes[metric.DataType()])
+	switch metric.DataType() {
+	case pdata.MetricDataTypeDoubleSum:
+		converted.IntSum().SetAggregationTemporality(metric.DoubleSum().AggregationTemporality())
+		converted.IntSum().SetIsMonotonic(metric.DoubleSum().IsMonotonic())
+	case pdata.MetricDataTypeIntSum:
+		converted.DoubleSum().SetAggregationTemporality(metric.IntSum().AggregationTemporality())
+		converted.DoubleSum().SetIsMonotonic(metric.IntSum().IsMonotonic())
+	}
+
+	for _, p := range points {
+		if p.isDouble {
+			appendIntPoint(intDataPoints(converted), p, p.labels, int64(p.doubleValue))
+		} else {
+			appendDoublePoint(doubleDataPoints(converted), p, p.labels, float64(p.intValue))
+		}
+	}
+	converted.CopyTo(metric)
+}
+
+func oppositeType(t translationrules.MetricValueType) translationrules.MetricValueType {
+	if t == translationrules.MetricValueTypeInt {
+		return translationrules.MetricValueTypeDouble
+	}
+	return translationrules.MetricValueTypeInt
+}
+
+// newMetricLike returns a metric without data with the name, description and unit of metric.
+func newMetricLike(metric pdata.Metric) pdata.Metric {
+	newMetric := pdata.NewMetric()
+	newMetric.SetName(metric.Name())
+	newMetric.SetDescription(metric.Description())
+	newMetric.SetUnit(metric.Unit())
+	return newMetric
+}
+
+// newEmptyMetric returns a copy of the gauge or sum metric without datapoints.
+func newEmptyMetric(metric pdata.Metric) pdata.Metric {
+	newMetric := newMetricLike(metric)
+	newMetric.SetDataType(metric.DataType())
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		newMetric.IntSum().SetAggregationTemporality(metric.IntSum().AggregationTemporality())
+		newMetric.IntSum().SetIsMonotonic(metric.IntSum().IsMonotonic())
+	case pdata.MetricDataTypeDoubleSum:
+		newMetric.DoubleSum().SetAggregationTemporality(metric.DoubleSum().AggregationTemporality())
+		newMetric.DoubleSum().SetIsMonotonic(metric.DoubleSum().IsMonotonic())
+	}
+	return newMetric
+}
+
+// copyMetric returns a copy of metric named newName, with only the datapoints matching
+// the dimension filter of the rule. It returns false if no datapoint matches.
+func copyMetric(tr translationrules.Rule, metric pdata.Metric, newName string) (pdata.Metric, bool) {
+	copied := pdata.NewMetric()
+	metric.CopyTo(copied)
+	copied.SetName(newName)
+	if tr.DimensionKey != "" {
+		filterDataPoints(copied, func(labels pdata.StringMap) bool {
+			v, ok := labels.Get(tr.DimensionKey)
+			return ok && tr.DimensionValues[v]
+		})
+	}
+	return copied, dataPointCount(copied) > 0
+}
+
+// splitMetric moves the datapoints of metric with dimensionKey set to a value of mapping
+// to new metrics named mapping[value], without this dimension. The other datapoints are kept as is.
+func splitMetric(metrics pdata.MetricSlice, metric pdata.Metric, dimensionKey string, mapping map[string]string) {
+	values := make([]string, 0, len(mapping))
+	for value := range mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		split := pdata.NewMetric()
+		metric.CopyTo(split)
+		split.SetName(mapping[value])
+		filterDataPoints(split, func(labels pdata.StringMap) bool {
+			v, ok := labels.Get(dimensionKey)
+			return ok && v == value
+		})
+		if dataPointCount(split) == 0 {
+			continue
+		}
+		forEachLabels(split, func(labels pdata.StringMap) {
+			labels.Delete(dimensionKey)
+		})
+		metrics.Append(split)
+	}
+
+	filterDataPoints(metric, func(labels pdata.StringMap) bool {
+		v, ok := labels.Get(dimensionKey)
+		if !ok {
+			return true
+		}
+		_, split := mapping[v]
+		return !split
+	})
+}
+
+// aggregateMetric aggregates the datapoints of a gauge or sum with the same
+// dimensions excluding withoutDimensions.
+func aggregateMetric(logger *zap.Logger, metric pdata.Metric, withoutDimensions []string, method translationrules.AggregationMethod) {
+	points, ok := numberPoints(metric)
+	if !ok {
+		logger.Debug("only gauges and sums can be aggregated", zap.String("metric", metric.Name()))
+		return
+	}
+
+	// group datapoints by dimension values, in the order of the datapoints
+	var keys []string
+	groups := make(map[string][]numberPoint)
+	for _, p := range points {
+		key := stringifyLabels(p.labels, withoutDimensions)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], p)
+	}
+
+	aggregated := newMetricLike(metric)
+	switch method {
+	case translationrules.AggregationMethodCount:
+		aggregated.SetDataType(pdata.MetricDataTypeIntGauge)
+		for _, key := range keys {
+			group := groups[key]
+			appendIntPoint(aggregated.IntGauge().DataPoints(), group[0], filterLabels(group[0].labels, withoutDimensions), int64(len(group)))
+		}
+	case translationrules.AggregationMethodSum:
+		// The sum keeps the type of the metric
+		aggregated = newEmptyMetric(metric)
+		for _, key := range keys {
+			group := groups[key]
+			labels := filterLabels(group[0].labels, withoutDimensions)
+			if isDouble(metric) {
+				var sum float64
+				for _, p := range group {
+					sum += p.doubleValue
+				}
+				appendDoublePoint(doubleDataPoints(aggregated), group[0], labels, sum)
+			} else {
+				var sum int64
+				for _, p := range group {
+					sum += p.intValue
+				}
+				appendIntPoint(intDataPoints(aggregated), group[0], labels, sum)
+			}
+		}
+	case translationrules.AggregationMethodAvg:
+		aggregated.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		for _, key := range keys {
+			group := groups[key]
+			var mean float64
+			for _, p := range group {
+				mean += p.value()
+			}
+			mean /= float64(len(group))
+			appendDoublePoint(aggregated.DoubleGauge().DataPoints(), group[0], filterLabels(group[0].labels, withoutDimensions), mean)
+		}
+	}
+	aggregated.CopyTo(metric)
+}
+
+// calculateNewMetric computes a double gauge from the datapoints of the operand metrics
+// with the same dimensions. It returns false if there is no such datapoint.
+func calculateNewMetric(logger *zap.Logger, metrics pdata.MetricSlice, tr translationrules.Rule) (pdata.Metric, bool) {
+	var operand1Pts, operand2Pts []numberPoint
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != tr.Operand1Metric && metric.Name() != tr.Operand2Metric {
+			continue
+		}
+		points, ok := numberPoints(metric)
+		if !ok {
+			logger.Warn(
+				"calculate_new_metric: operand has no numeric value",
+				zap.String("metric", metric.Name()),
+				zap.String("tr.MetricName", tr.MetricName),
+			)
+			continue
+		}
+		if metric.Name() == tr.Operand1Metric {
+			operand1Pts = append(operand1Pts, points...)
+		} else {
+			operand2Pts = append(operand2Pts, points...)
+		}
+	}
+
+	newMetric := pdata.NewMetric()
+	newMetric.SetName(tr.MetricName)
+	newMetric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	dps := newMetric.DoubleGauge().DataPoints()
+	for _, o1 := range operand1Pts {
+		for _, o2 := range operand2Pts {
+			if !labelsEqual(o1.labels, o2.labels) {
+				continue
+			}
+			if tr.Operator == translationrules.MetricOperatorDivision && o2.value() == 0 {
+				logger.Warn(
+					"calculate_new_metric: attempt to divide by zero, skipping",
+					zap.String("tr.Operand2Metric", tr.Operand2Metric),
+					zap.String("tr.MetricName", tr.MetricName),
+				)
+				continue
+			}
+			// only supporting divide operator for now
+			appendDoublePoint(dps, o1, o1.labels, o1.value()/o2.value())
+		}
+	}
+	return newMetric, dps.Len() > 0
+}
+
+// removeMetrics removes the metrics for which remove returns true.
+func removeMetrics(metrics pdata.MetricSlice, remove func(pdata.Metric) bool) {
+	kept := pdata.NewMetricSlice()
+	for i := 0; i < metrics.Len(); i++ {
+		if !remove(metrics.At(i)) {
+			kept.Append(metrics.At(i))
+		}
+	}
+	metrics.Resize(0)
+	kept.MoveAndAppendTo(metrics)
+}
+
+// removeEmptyMetrics removes the metrics without datapoints.
+func removeEmptyMetrics(metrics pdata.MetricSlice) {
+	removeMetrics(metrics, func(metric pdata.Metric) bool {
+		return dataPointCount(metric) == 0
+	})
+}
@@ -0,0 +1,386 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/translationrules"
+)
+
+type testPoint struct {
+	labels map[string]string
+	value  float64
+}
+
+func newTestMetrics(resourceAttrs map[string]pdata.AttributeValue) (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InitFromMap(resourceAttrs)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	return md, rm.InstrumentationLibraryMetrics().At(0).Metrics()
+}
+
+func addMetric(metrics pdata.MetricSlice, name string, dataType pdata.MetricDataType, points ...testPoint) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	switch dataType {
+	case pdata.MetricDataTypeIntSum:
+		metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		metric.IntSum().SetIsMonotonic(true)
+	case pdata.MetricDataTypeDoubleSum:
+		metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		metric.DoubleSum().SetIsMonotonic(true)
+	}
+	for _, p := range points {
+		labels := pdata.NewStringMap().InitFromMap(p.labels)
+		np := numberPoint{timestamp: pdata.TimestampUnixNano(1000)}
+		if isDouble(metric) {
+			appendDoublePoint(doubleDataPoints(metric), np, labels, p.value)
+		} else {
+			appendIntPoint(intDataPoints(metric), np, labels, int64(p.value))
+		}
+	}
+	metrics.Append(metric)
+	return metrics.At(metrics.Len() - 1)
+}
+
+func testPoints(t *testing.T, metric pdata.Metric) []testPoint {
+	points, ok := numberPoints(metric)
+	require.True(t, ok)
+	var result []testPoint
+	for _, p := range points {
+		labels := make(map[string]string)
+		p.labels.ForEach(func(k string, v string) {
+			labels[k] = v
+		})
+		result = append(result, testPoint{labels: labels, value: p.value()})
+	}
+	return result
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func translate(t *testing.T, md pdata.Metrics, rules ...translationrules.Rule) {
+	mt, err := newMetricTranslator(zap.NewNop(), rules, 3600)
+	require.NoError(t, err)
+	mt.translate(md)
+}
+
+func TestRenameDimensionKeys(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "metric1", pdata.MetricDataTypeIntGauge, testPoint{map[string]string{"a": "1", "b": "2"}, 1})
+	addMetric(metrics, "metric2", pdata.MetricDataTypeDoubleGauge, testPoint{map[string]string{"a": "1", "c": "3"}, 1})
+
+	translate(t, md,
+		translationrules.Rule{Action: translationrules.ActionRenameDimensionKeys, Mapping: map[string]string{"a": "new_a"}},
+		translationrules.Rule{Action: translationrules.ActionRenameDimensionKeys, Mapping: map[string]string{"c": "new_c"}, MetricNames: map[string]bool{"metric1": true}},
+	)
+	assert.Equal(t, []testPoint{{map[string]string{"new_a": "1", "b": "2"}, 1}}, testPoints(t, metrics.At(0)))
+	assert.Equal(t, []testPoint{{map[string]string{"new_a": "1", "c": "3"}, 1}}, testPoints(t, metrics.At(1)))
+}
+
+func TestRenameMetrics(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "metric1", pdata.MetricDataTypeIntSum, testPoint{map[string]string{"a": "1"}, 1})
+	addMetric(metrics, "metric2", pdata.MetricDataTypeIntSum, testPoint{map[string]string{"a": "1"}, 2})
+
+	translate(t, md, translationrules.Rule{
+		Action:         translationrules.ActionRenameMetrics,
+		Mapping:        map[string]string{"metric1": "new_metric1"},
+		CopyDimensions: map[string]string{"a": "copied_a"},
+		AddDimensions:  map[string]string{"added": "value"},
+	})
+	assert.Equal(t, []string{"new_metric1", "metric2"}, metricNames(metrics))
+	assert.Equal(t, []testPoint{{map[string]string{"a": "1", "copied_a": "1", "added": "value"}, 1}}, testPoints(t, metrics.At(0)))
+	assert.Equal(t, []testPoint{{map[string]string{"a": "1"}, 2}}, testPoints(t, metrics.At(1)))
+}
+
+func TestScaleMetrics(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "int1", pdata.MetricDataTypeIntGauge, testPoint{nil, 3})
+	addMetric(metrics, "int2", pdata.MetricDataTypeIntSum, testPoint{nil, 3000})
+	addMetric(metrics, "double", pdata.MetricDataTypeDoubleSum, testPoint{nil, 3})
+
+	translate(t, md,
+		translationrules.Rule{Action: translationrules.ActionMultiplyInt, ScaleFactorsInt: map[string]int64{"int1": 1000, "double": 1000}},
+		translationrules.Rule{Action: translationrules.ActionDivideInt, ScaleFactorsInt: map[string]int64{"int2": 1000}},
+		translationrules.Rule{Action: translationrules.ActionMultiplyFloat, ScaleFactorsFloat: map[string]float64{"double": 0.5, "int1": 0.5}},
+	)
+	assert.Equal(t, []testPoint{{map[string]string{}, 3000}}, testPoints(t, metrics.At(0)))
+	assert.Equal(t, []testPoint{{map[string]string{}, 3}}, testPoints(t, metrics.At(1)))
+	assert.Equal(t, []testPoint{{map[string]string{}, 1.5}}, testPoints(t, metrics.At(2)))
+}
+
+func TestConvertValues(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "double", pdata.MetricDataTypeDoubleSum, testPoint{map[string]string{"a": "1"}, 2.7})
+	addMetric(metrics, "int", pdata.MetricDataTypeIntGauge, testPoint{map[string]string{"a": "1"}, 2})
+	addMetric(metrics, "unchanged", pdata.MetricDataTypeIntGauge, testPoint{nil, 2})
+
+	translate(t, md, translationrules.Rule{
+		Action: translationrules.ActionConvertValues,
+		TypesMapping: map[string]translationrules.MetricValueType{
+			"double":    translationrules.MetricValueTypeInt,
+			"int":       translationrules.MetricValueTypeDouble,
+			"unchanged": translationrules.MetricValueTypeInt,
+		},
+	})
+
+	assert.Equal(t, pdata.MetricDataTypeIntSum, metrics.At(0).DataType())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, metrics.At(0).IntSum().AggregationTemporality())
+	assert.True(t, metrics.At(0).IntSum().IsMonotonic())
+	assert.Equal(t, []testPoint{{map[string]string{"a": "1"}, 2}}, testPoints(t, metrics.At(0)))
+	assert.Equal(t, pdata.TimestampUnixNano(1000), metrics.At(0).IntSum().DataPoints().At(0).Timestamp())
+
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, metrics.At(1).DataType())
+	assert.Equal(t, []testPoint{{map[string]string{"a": "1"}, 2}}, testPoints(t, metrics.At(1)))
+
+	assert.Equal(t, pdata.MetricDataTypeIntGauge, metrics.At(2).DataType())
+}
+
+func TestCopyMetrics(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "metric1", pdata.MetricDataTypeIntGauge,
+		testPoint{map[string]string{"dim": "a"}, 1},
+		testPoint{map[string]string{"dim": "b"}, 2},
+		testPoint{map[string]string{"other": "a"}, 3},
+	)
+	addMetric(metrics, "metric2", pdata.MetricDataTypeDoubleGauge, testPoint{map[string]string{"dim": "b"}, 4})
+
+	translate(t, md,
+		translationrules.Rule{Action: translationrules.ActionCopyMetrics, Mapping: map[string]string{"metric1": "copy1"}},
+		translationrules.Rule{
+			Action:          translationrules.ActionCopyMetrics,
+			Mapping:         map[string]string{"metric1": "copy2", "metric2": "copy3"},
+			DimensionKey:    "dim",
+			DimensionValues: map[string]bool{"a": true},
+		},
+	)
+	require.Equal(t, []string{"metric1", "metric2", "copy1", "copy2"}, metricNames(metrics))
+	assert.Equal(t, testPoints(t, metrics.At(0)), testPoints(t, metrics.At(2)))
+	assert.Equal(t, []testPoint{{map[string]string{"dim": "a"}, 1}}, testPoints(t, metrics.At(3)))
+}
+
+func TestSplitMetric(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "network.io", pdata.MetricDataTypeIntSum,
+		testPoint{map[string]string{"direction": "receive", "device": "eth0"}, 1},
+		testPoint{map[string]string{"direction": "transmit", "device": "eth0"}, 2},
+		testPoint{map[string]string{"direction": "unknown", "device": "eth0"}, 3},
+	)
+	addMetric(metrics, "split.io", pdata.MetricDataTypeIntSum,
+		testPoint{map[string]string{"direction": "receive"}, 4},
+	)
+
+	translate(t, md, translationrules.Rule{
+		Action:       translationrules.ActionSplitMetric,
+		MetricName:   "network.io",
+		DimensionKey: "direction",
+		Mapping: map[string]string{
+			"receive":  "network.io.receive",
+			"transmit": "network.io.transmit",
+		},
+	})
+	require.Equal(t, []string{"network.io", "split.io", "network.io.receive", "network.io.transmit"}, metricNames(metrics))
+	assert.Equal(t, []testPoint{{map[string]string{"direction": "unknown", "device": "eth0"}, 3}}, testPoints(t, metrics.At(0)))
+	assert.Equal(t, []testPoint{{map[string]string{"device": "eth0"}, 1}}, testPoints(t, metrics.At(2)))
+	assert.Equal(t, []testPoint{{map[string]string{"device": "eth0"}, 2}}, testPoints(t, metrics.At(3)))
+	assert.Equal(t, pdata.MetricDataTypeIntSum, metrics.At(2).DataType())
+
+	// The metric is removed once all its datapoints are split
+	md, metrics = newTestMetrics(nil)
+	addMetric(metrics, "network.io", pdata.MetricDataTypeIntSum,
+		testPoint{map[string]string{"direction": "receive"}, 1},
+	)
+	translate(t, md, translationrules.Rule{
+		Action:       translationrules.ActionSplitMetric,
+		MetricName:   "network.io",
+		DimensionKey: "direction",
+		Mapping:      map[string]string{"receive": "network.io.receive"},
+	})
+	assert.Equal(t, []string{"network.io.receive"}, metricNames(metrics))
+}
+
+func TestAggregateMetric(t *testing.T) {
+	tests := []struct {
+		method     translationrules.AggregationMethod
+		dataType   pdata.MetricDataType
+		wantType   pdata.MetricDataType
+		wantPoints []testPoint
+	}{
+		{
+			method:   translationrules.AggregationMethodCount,
+			dataType: pdata.MetricDataTypeDoubleGauge,
+			wantType: pdata.MetricDataTypeIntGauge,
+			wantPoints: []testPoint{
+				{map[string]string{"host": "host1"}, 2},
+				{map[string]string{"host": "host2"}, 1},
+			},
+		},
+		{
+			method:   translationrules.AggregationMethodSum,
+			dataType: pdata.MetricDataTypeIntSum,
+			wantType: pdata.MetricDataTypeIntSum,
+			wantPoints: []testPoint{
+				{map[string]string{"host": "host1"}, 6},
+				{map[string]string{"host": "host2"}, 3},
+			},
+		},
+		{
+			method:   translationrules.AggregationMethodAvg,
+			dataType: pdata.MetricDataTypeIntGauge,
+			wantType: pdata.MetricDataTypeDoubleGauge,
+			wantPoints: []testPoint{
+				{map[string]string{"host": "host1"}, 3},
+				{map[string]string{"host": "host2"}, 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			md, metrics := newTestMetrics(nil)
+			addMetric(metrics, "cpu", tt.dataType,
+				testPoint{map[string]string{"cpu": "cpu1", "host": "host1"}, 2},
+				testPoint{map[string]string{"cpu": "cpu1", "host": "host2"}, 3},
+				testPoint{map[string]string{"cpu": "cpu2", "host": "host1"}, 4},
+			)
+
+			translate(t, md, translationrules.Rule{
+				Action:            translationrules.ActionAggregateMetric,
+				MetricName:        "cpu",
+				AggregationMethod: tt.method,
+				WithoutDimensions: []string{"cpu"},
+			})
+			require.Equal(t, []string{"cpu"}, metricNames(metrics))
+			assert.Equal(t, tt.wantType, metrics.At(0).DataType())
+			assert.Equal(t, tt.wantPoints, testPoints(t, metrics.At(0)))
+		})
+	}
+}
+
+func TestCalculateNewMetric(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "memory.used", pdata.MetricDataTypeIntGauge,
+		testPoint{map[string]string{"host": "host1"}, 1},
+		testPoint{map[string]string{"host": "host2"}, 3},
+		testPoint{map[string]string{"host": "host3"}, 3},
+	)
+	addMetric(metrics, "memory.total", pdata.MetricDataTypeDoubleGauge,
+		testPoint{map[string]string{"host": "host1"}, 4},
+		testPoint{map[string]string{"host": "host2"}, 0},
+	)
+
+	rule := translationrules.Rule{
+		Action:         translationrules.ActionCalculateNewMetric,
+		MetricName:     "memory.utilization",
+		Operand1Metric: "memory.used",
+		Operand2Metric: "memory.total",
+		Operator:       translationrules.MetricOperatorDivision,
+	}
+	translate(t, md, rule)
+	require.Equal(t, []string{"memory.used", "memory.total", "memory.utilization"}, metricNames(metrics))
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, metrics.At(2).DataType())
+	assert.Equal(t, []testPoint{{map[string]string{"host": "host1"}, 0.25}}, testPoints(t, metrics.At(2)))
+
+	// No metric is created without datapoints to compute
+	md, metrics = newTestMetrics(nil)
+	addMetric(metrics, "memory.used", pdata.MetricDataTypeIntGauge, testPoint{nil, 1})
+	translate(t, md, rule)
+	assert.Equal(t, []string{"memory.used"}, metricNames(metrics))
+}
+
+func TestDropMetrics(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "metric1", pdata.MetricDataTypeIntGauge, testPoint{nil, 1})
+	addMetric(metrics, "metric2", pdata.MetricDataTypeIntGauge, testPoint{nil, 1})
+	addMetric(metrics, "metric3", pdata.MetricDataTypeIntGauge, testPoint{nil, 1})
+
+	translate(t, md, translationrules.Rule{Action: translationrules.ActionDropMetrics, MetricNames: map[string]bool{"metric1": true, "metric3": true}})
+	assert.Equal(t, []string{"metric2"}, metricNames(metrics))
+}
+
+func TestDeltaMetric(t *testing.T) {
+	mt, err := newMetricTranslator(zap.NewNop(), []translationrules.Rule{{
+		Action:  translationrules.ActionDeltaMetric,
+		Mapping: map[string]string{"cpu.time": "cpu.time.delta", "requests": "requests.delta"},
+	}}, 3600)
+	require.NoError(t, err)
+
+	newBatch := func(host string, cpuTime float64, requests int64) pdata.MetricSlice {
+		md, metrics := newTestMetrics(map[string]pdata.AttributeValue{"host": pdata.NewAttributeValueString(host)})
+		addMetric(metrics, "cpu.time", pdata.MetricDataTypeDoubleSum, testPoint{map[string]string{"cpu": "cpu0"}, cpuTime})
+		addMetric(metrics, "requests", pdata.MetricDataTypeIntSum, testPoint{nil, float64(requests)})
+		mt.translate(md)
+		return metrics
+	}
+
+	// There is no delta without a previous datapoint
+	metrics := newBatch("host1", 10, 100)
+	assert.Equal(t, []string{"cpu.time", "requests"}, metricNames(metrics))
+
+	metrics = newBatch("host1", 15.5, 120)
+	require.Equal(t, []string{"cpu.time", "requests", "cpu.time.delta", "requests.delta"}, metricNames(metrics))
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, metrics.At(2).DataType())
+	assert.Equal(t, []testPoint{{map[string]string{"cpu": "cpu0"}, 5.5}}, testPoints(t, metrics.At(2)))
+	assert.Equal(t, pdata.MetricDataTypeIntGauge, metrics.At(3).DataType())
+	assert.Equal(t, []testPoint{{map[string]string{}, 20}}, testPoints(t, metrics.At(3)))
+
+	// The datapoints of other resources are tracked separately
+	metrics = newBatch("host2", 20, 150)
+	assert.Equal(t, []string{"cpu.time", "requests"}, metricNames(metrics))
+
+	// A negative delta is a reset, the current value is the delta
+	metrics = newBatch("host1", 2, 130)
+	require.Equal(t, []string{"cpu.time", "requests", "cpu.time.delta", "requests.delta"}, metricNames(metrics))
+	assert.Equal(t, []testPoint{{map[string]string{"cpu": "cpu0"}, 2}}, testPoints(t, metrics.At(2)))
+	assert.Equal(t, []testPoint{{map[string]string{}, 10}}, testPoints(t, metrics.At(3)))
+}
+
+func TestRulesOrder(t *testing.T) {
+	md, metrics := newTestMetrics(nil)
+	addMetric(metrics, "memory.used", pdata.MetricDataTypeIntGauge, testPoint{nil, 1024})
+	addMetric(metrics, "memory.total", pdata.MetricDataTypeIntGauge, testPoint{nil, 4096})
+
+	// Each rule is applied to the metrics translated by the previous ones
+	translate(t, md,
+		translationrules.Rule{Action: translationrules.ActionDivideInt, ScaleFactorsInt: map[string]int64{"memory.used": 2}},
+		translationrules.Rule{
+			Action:         translationrules.ActionCalculateNewMetric,
+			MetricName:     "memory.utilization",
+			Operand1Metric: "memory.used",
+			Operand2Metric: "memory.total",
+			Operator:       translationrules.MetricOperatorDivision,
+		},
+		translationrules.Rule{Action: translationrules.ActionRenameMetrics, Mapping: map[string]string{"memory.utilization": "memory.usage"}},
+		translationrules.Rule{Action: translationrules.ActionDropMetrics, MetricNames: map[string]bool{"memory.total": true}},
+	)
+	require.Equal(t, []string{"memory.used", "memory.usage"}, metricNames(metrics))
+	assert.Equal(t, []testPoint{{map[string]string{}, 0.125}}, testPoints(t, metrics.At(1)))
+}
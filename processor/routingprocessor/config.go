@@ -26,6 +26,15 @@ type Config struct {
 	// Optional.
 	DefaultExporters []string `mapstructure:"default_exporters"`
 
+	// DefaultErrorMode specifies how the errors of the exporters of the default route are handled,
+	// see RoutingTableItem.ErrorMode.
+	// Optional.
+	DefaultErrorMode ErrorMode `mapstructure:"default_error_mode"`
+
+	// DefaultMinSuccessful is the number of exporters of the default route that must succeed
+	// when DefaultErrorMode is "require_n".
+	DefaultMinSuccessful int `mapstructure:"default_min_successful"`
+
 	// FromAttribute contains the attribute name to look up the route value. This attribute should be part of the context propagated
 	// down from the previous receivers and/or processors. If all the receivers and processors are propagating the entire context correctly,
 	// this could be the HTTP/gRPC header from the original request/RPC. Typically, aggregation processors (batch, groupbytrace)
@@ -46,7 +55,26 @@ type RoutingTableItem struct {
 
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
-	// The routing processor will fail upon the first failure from these exporters.
 	// Optional.
 	Exporters []string `mapstructure:"exporters"`
+
+	// ErrorMode specifies how the errors of the exporters are handled, it defaults to "fail_fast".
+	// Optional.
+	ErrorMode ErrorMode `mapstructure:"error_mode"`
+
+	// MinSuccessful is the number of exporters that must succeed when ErrorMode is "require_n".
+	MinSuccessful int `mapstructure:"min_successful"`
 }
+
+// ErrorMode specifies how the errors of the exporters of a route are handled.
+type ErrorMode string
+
+const (
+	// ErrorModeFailFast stops sending the data to the exporters upon the first failure, and returns its error.
+	ErrorModeFailFast ErrorMode = "fail_fast"
+	// ErrorModeBestEffort sends the data to all the exporters, and returns the errors of those that failed.
+	ErrorModeBestEffort ErrorMode = "best_effort"
+	// ErrorModeRequireN sends the data to all the exporters, and only returns their errors
+	// if less than MinSuccessful of them succeeded.
+	ErrorModeRequireN ErrorMode = "require_n"
+)
@@ -54,12 +54,15 @@ func TestLoadConfig(t *testing.T) {
 			FromAttribute:    "X-Tenant",
 			Table: []RoutingTableItem{
 				{
-					Value:     "acme",
-					Exporters: []string{"jaeger/acme", "otlp/acme"},
+					Value:         "acme",
+					Exporters:     []string{"jaeger/acme", "otlp/acme"},
+					ErrorMode:     ErrorModeRequireN,
+					MinSuccessful: 1,
 				},
 				{
 					Value:     "globex",
 					Exporters: []string{"otlp/globex"},
+					ErrorMode: ErrorModeBestEffort,
 				},
 			},
 		})
@@ -16,7 +16,9 @@ package routingprocessor
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
@@ -28,8 +30,16 @@ const (
 	typeStr = "routing"
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for the routing processor.
 func NewFactory() component.ProcessorFactory {
+	once.Do(func() {
+		// Registering only fails if views with the same names but different
+		// definitions were registered, the views are then left as they are.
+		_ = view.Register(MetricViews()...)
+	})
+
 	return processorhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	tagExporterKey = tag.MustNewKey("exporter")
+
+	mExporterSucceeded = stats.Int64("exporter_succeeded", "Number of batches an exporter consumed successfully", stats.UnitDimensionless)
+	mExporterFailed    = stats.Int64("exporter_failed", "Number of batches an exporter failed to consume", stats.UnitDimensionless)
+)
+
+// MetricViews returns the views of the number of batches each exporter consumed successfully or failed to consume.
+func MetricViews() []*view.View {
+	exporterTagKeys := []tag.Key{tagExporterKey}
+
+	legacyViews := []*view.View{
+		{
+			Name:        mExporterSucceeded.Name(),
+			Measure:     mExporterSucceeded,
+			Description: mExporterSucceeded.Description(),
+			TagKeys:     exporterTagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mExporterFailed.Name(),
+			Measure:     mExporterFailed,
+			Description: mExporterFailed.Description(),
+			TagKeys:     exporterTagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestProcessorMetrics(t *testing.T) {
+	expectedViewNames := []string{
+		"processor/routing/exporter_succeeded",
+		"processor/routing/exporter_failed",
+	}
+
+	views := MetricViews()
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+func TestExporterResultsAreRecorded(t *testing.T) {
+	// prepare
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	exp := &processorImp{
+		logger: zap.NewNop(),
+		defaultRoute: &route{
+			exporters: []namedExporter{
+				{name: "otlp/ok", exporter: &mockExporter{}},
+				{name: "otlp/failing", exporter: &mockExporter{
+					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+						return errors.New("some error")
+					},
+				}},
+			},
+			errorMode: ErrorModeBestEffort,
+		},
+	}
+
+	// test
+	assert.Error(t, exp.ConsumeTraces(context.Background(), pdata.NewTraces()))
+
+	// verify
+	for _, tt := range []struct {
+		viewName string
+		exporter string
+	}{
+		{"processor/routing/exporter_succeeded", "otlp/ok"},
+		{"processor/routing/exporter_failed", "otlp/failing"},
+	} {
+		rows, err := view.RetrieveData(tt.viewName)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Len(t, rows[0].Tags, 1)
+		assert.Equal(t, tt.exporter, rows[0].Tags[0].Value)
+		assert.Equal(t, float64(1), rows[0].Data.(*view.SumData).Value)
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
@@ -32,6 +35,8 @@ var (
 	errNoTableItems           = errors.New("the routing table is empty")
 	errNoMissingFromAttribute = errors.New("the FromAttribute property is empty")
 	errExporterNotFound       = errors.New("exporter not found")
+	errInvalidErrorMode       = errors.New("unknown error mode")
+	errInvalidMinSuccessful   = errors.New("the minimum number of successful exporters must be between 1 and the number of exporters")
 )
 
 var _ component.TracesProcessor = (*processorImp)(nil)
@@ -40,8 +45,20 @@ type processorImp struct {
 	logger *zap.Logger
 	config Config
 
-	defaultTraceExporters []component.TracesExporter
-	traceExporters        map[string][]component.TracesExporter
+	defaultRoute *route
+	routes       map[string]*route
+}
+
+// route holds the exporters of a route, and how their errors are handled.
+type route struct {
+	exporters     []namedExporter
+	errorMode     ErrorMode
+	minSuccessful int
+}
+
+type namedExporter struct {
+	name     string
+	exporter component.TracesExporter
 }
 
 // Crete new processor
@@ -50,11 +67,18 @@ func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp,
 
 	oCfg := cfg.(*Config)
 
-	// validate that every route has at least one exporter
+	// validate that every route has at least one exporter, and a valid error mode
 	for _, item := range oCfg.Table {
 		if len(item.Exporters) == 0 {
 			return nil, fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
 		}
+		if err := validateErrorMode(item.ErrorMode, item.MinSuccessful, len(item.Exporters)); err != nil {
+			return nil, fmt.Errorf("invalid route %s: %w", item.Value, err)
+		}
+	}
+
+	if err := validateErrorMode(oCfg.DefaultErrorMode, oCfg.DefaultMinSuccessful, len(oCfg.DefaultExporters)); err != nil {
+		return nil, fmt.Errorf("invalid default route: %w", err)
 	}
 
 	// validate that there's at least one item in the table
@@ -67,13 +91,36 @@ func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp,
 		return nil, fmt.Errorf("invalid attribute to read the route's value from: %w", errNoMissingFromAttribute)
 	}
 
+	routes := make(map[string]*route, len(oCfg.Table))
+	for _, item := range oCfg.Table {
+		routes[item.Value] = &route{errorMode: item.ErrorMode, minSuccessful: item.MinSuccessful}
+	}
+
 	return &processorImp{
-		logger:         logger,
-		config:         *oCfg,
-		traceExporters: make(map[string][]component.TracesExporter),
+		logger: logger,
+		config: *oCfg,
+		defaultRoute: &route{
+			errorMode:     oCfg.DefaultErrorMode,
+			minSuccessful: oCfg.DefaultMinSuccessful,
+		},
+		routes: routes,
 	}, nil
 }
 
+func validateErrorMode(mode ErrorMode, minSuccessful int, numExporters int) error {
+	switch mode {
+	case "", ErrorModeFailFast, ErrorModeBestEffort:
+		return nil
+	case ErrorModeRequireN:
+		if minSuccessful < 1 || minSuccessful > numExporters {
+			return errInvalidMinSuccessful
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", errInvalidErrorMode, mode)
+	}
+}
+
 func (e *processorImp) Start(_ context.Context, host component.Host) error {
 	// first, let's build a map of exporter names with the exporter instances
 	source := host.GetExporters()
@@ -107,7 +154,7 @@ func (e *processorImp) registerExportersForDefaultRoute(available map[string]com
 		if !ok {
 			return fmt.Errorf("error registering default exporter %q: %w", exp, errExporterNotFound)
 		}
-		e.defaultTraceExporters = append(e.defaultTraceExporters, v)
+		e.defaultRoute.exporters = append(e.defaultRoute.exporters, namedExporter{name: exp, exporter: v})
 	}
 
 	return nil
@@ -119,7 +166,7 @@ func (e *processorImp) registerExportersForRoute(route string, available map[str
 		if !ok {
 			return fmt.Errorf("error registering route %q for exporter %q: %w", route, exp, errExporterNotFound)
 		}
-		e.traceExporters[route] = append(e.traceExporters[route], v)
+		e.routes[route].exporters = append(e.routes[route].exporters, namedExporter{name: exp, exporter: v})
 	}
 
 	return nil
@@ -133,31 +180,61 @@ func (e *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error
 	value := e.extractValueFromContext(ctx)
 	if len(value) == 0 {
 		// the attribute's value hasn't been found, send data to the default exporter
-		return e.pushDataToExporters(ctx, td, e.defaultTraceExporters)
+		return e.pushDataToExporters(ctx, td, e.defaultRoute)
 	}
 
-	if _, ok := e.traceExporters[value]; !ok {
+	r, ok := e.routes[value]
+	if !ok {
 		// the value has been found, but there are no exporters for the value
-		return e.pushDataToExporters(ctx, td, e.defaultTraceExporters)
+		return e.pushDataToExporters(ctx, td, e.defaultRoute)
 	}
 
 	// found the appropriate router, using it
-	return e.pushDataToExporters(ctx, td, e.traceExporters[value])
+	return e.pushDataToExporters(ctx, td, r)
 }
 
 func (e *processorImp) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
 
-func (e *processorImp) pushDataToExporters(ctx context.Context, td pdata.Traces, exporters []component.TracesExporter) error {
-	// TODO: determine the proper action when errors happen
-	for _, exp := range exporters {
-		if err := exp.ConsumeTraces(ctx, td); err != nil {
-			return err
+func (e *processorImp) pushDataToExporters(ctx context.Context, td pdata.Traces, r *route) error {
+	if r.errorMode == "" || r.errorMode == ErrorModeFailFast {
+		for _, exp := range r.exporters {
+			if err := e.consumeTraces(ctx, td, exp); err != nil {
+				return err
+			}
 		}
+		return nil
 	}
 
-	return nil
+	var errs []error
+	for _, exp := range r.exporters {
+		if err := e.consumeTraces(ctx, td, exp); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	err := componenterror.CombineErrors(errs)
+	if r.errorMode == ErrorModeRequireN && len(r.exporters)-len(errs) >= r.minSuccessful {
+		e.logger.Warn("some exporters failed to consume the data, but enough succeeded",
+			zap.Int("failed", len(errs)), zap.Int("exporters", len(r.exporters)), zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// consumeTraces sends the data to the exporter, and records whether it succeeded.
+func (e *processorImp) consumeTraces(ctx context.Context, td pdata.Traces, exp namedExporter) error {
+	err := exp.exporter.ConsumeTraces(ctx, td)
+	measure := mExporterSucceeded
+	if err != nil {
+		measure = mExporterFailed
+	}
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagExporterKey, exp.name)}, measure.M(1))
+	return err
 }
 
 func (e *processorImp) extractValueFromContext(ctx context.Context) string {
@@ -42,14 +42,17 @@ func TestRouteIsFoundForGRPCContexts(t *testing.T) {
 			FromAttribute: "X-Tenant",
 		},
 		logger: zap.NewNop(),
-		traceExporters: map[string][]component.TracesExporter{
+		routes: map[string]*route{
 			"acme": {
-				&mockExporter{
-					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
-						wg.Done()
-						return nil
+				exporters: []namedExporter{{
+					name: "otlp",
+					exporter: &mockExporter{
+						ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+							wg.Done()
+							return nil
+						},
 					},
-				},
+				}},
 			},
 		},
 	}
@@ -88,13 +91,16 @@ func TestDefaultRouteIsUsedWhenRouteCantBeDetermined(t *testing.T) {
 					FromAttribute: "X-Tenant",
 				},
 				logger: zap.NewNop(),
-				defaultTraceExporters: []component.TracesExporter{
-					&mockExporter{
-						ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
-							wg.Done()
-							return nil
+				defaultRoute: &route{
+					exporters: []namedExporter{{
+						name: "otlp",
+						exporter: &mockExporter{
+							ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+								wg.Done()
+								return nil
+							},
 						},
-					},
+					}},
 				},
 			}
 			traces := pdata.NewTraces()
@@ -151,7 +157,7 @@ func TestRegisterExportersForValidRoute(t *testing.T) {
 	exp.Start(context.Background(), host)
 
 	// verify
-	assert.Contains(t, exp.traceExporters["acme"], otlpExp)
+	assert.Contains(t, exp.routes["acme"].exporters, namedExporter{name: "otlp", exporter: otlpExp})
 }
 
 func TestErrorRequestedExporterNotFoundForRoute(t *testing.T) {
@@ -372,18 +378,26 @@ func TestFailedToPushDataToExporter(t *testing.T) {
 	wg.Add(2)
 	exp := &processorImp{
 		logger: zap.NewNop(),
-		traceExporters: map[string][]component.TracesExporter{
+		routes: map[string]*route{
 			"acme": {
-				&mockExporter{
-					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
-						wg.Done()
-						return nil
+				exporters: []namedExporter{
+					{
+						name: "otlp",
+						exporter: &mockExporter{
+							ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+								wg.Done()
+								return nil
+							},
+						},
 					},
-				},
-				&mockExporter{ // this is a cross-test with the scenario with multiple exporters
-					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
-						wg.Done()
-						return expectedErr
+					{ // this is a cross-test with the scenario with multiple exporters
+						name: "otlp/2",
+						exporter: &mockExporter{
+							ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+								wg.Done()
+								return expectedErr
+							},
+						},
 					},
 				},
 			},
@@ -392,13 +406,145 @@ func TestFailedToPushDataToExporter(t *testing.T) {
 	traces := pdata.NewTraces()
 
 	// test
-	err := exp.pushDataToExporters(context.Background(), traces, exp.traceExporters["acme"])
+	err := exp.pushDataToExporters(context.Background(), traces, exp.routes["acme"])
 
 	// verify
 	wg.Wait() // ensure that the exporter has been called
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestErrorModes(t *testing.T) {
+	errFailed := errors.New("some error")
+	for _, tt := range []struct {
+		name          string
+		errorMode     ErrorMode
+		minSuccessful int
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			name:          "fail fast",
+			errorMode:     ErrorModeFailFast,
+			expectedCalls: 2,
+			expectedErr:   true,
+		},
+		{
+			name:          "best effort",
+			errorMode:     ErrorModeBestEffort,
+			expectedCalls: 3,
+			expectedErr:   true,
+		},
+		{
+			name:          "require enough successful",
+			errorMode:     ErrorModeRequireN,
+			minSuccessful: 2,
+			expectedCalls: 3,
+		},
+		{
+			name:          "require too many successful",
+			errorMode:     ErrorModeRequireN,
+			minSuccessful: 3,
+			expectedCalls: 3,
+			expectedErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			calls := 0
+			newExporter := func(name string, err error) namedExporter {
+				return namedExporter{
+					name: name,
+					exporter: &mockExporter{
+						ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+							calls++
+							return err
+						},
+					},
+				}
+			}
+			exp := &processorImp{
+				logger: zap.NewNop(),
+				defaultRoute: &route{
+					exporters: []namedExporter{
+						newExporter("otlp/1", nil),
+						newExporter("otlp/2", errFailed),
+						newExporter("otlp/3", nil),
+					},
+					errorMode:     tt.errorMode,
+					minSuccessful: tt.minSuccessful,
+				},
+			}
+
+			// test
+			err := exp.ConsumeTraces(context.Background(), pdata.NewTraces())
+
+			// verify
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInvalidErrorModes(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name: "unknown error mode",
+			config: &Config{
+				FromAttribute: "X-Tenant",
+				Table: []RoutingTableItem{{
+					Value:     "acme",
+					Exporters: []string{"otlp"},
+					ErrorMode: "unknown",
+				}},
+			},
+			expectedErr: errInvalidErrorMode,
+		},
+		{
+			name: "more successful exporters than exporters",
+			config: &Config{
+				FromAttribute: "X-Tenant",
+				Table: []RoutingTableItem{{
+					Value:         "acme",
+					Exporters:     []string{"otlp"},
+					ErrorMode:     ErrorModeRequireN,
+					MinSuccessful: 2,
+				}},
+			},
+			expectedErr: errInvalidMinSuccessful,
+		},
+		{
+			name: "no successful exporters for the default route",
+			config: &Config{
+				DefaultExporters: []string{"otlp"},
+				DefaultErrorMode: ErrorModeRequireN,
+				FromAttribute:    "X-Tenant",
+				Table: []RoutingTableItem{{
+					Value:     "acme",
+					Exporters: []string{"otlp"},
+				}},
+			},
+			expectedErr: errInvalidMinSuccessful,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			p, err := newProcessor(zap.NewNop(), tt.config)
+
+			// verify
+			assert.Nil(t, p)
+			assert.True(t, errors.Is(err, tt.expectedErr))
+		})
+	}
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := &Config{
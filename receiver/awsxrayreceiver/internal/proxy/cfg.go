@@ -49,6 +49,12 @@ type Config struct {
 	// will be called or not. Set to `true` to skip EC2 instance
 	// metadata check.
 	LocalMode bool `mapstructure:"local_mode"`
+
+	// LocalSamplingRulesFile is the path to a file containing sampling
+	// rules. When set, the local TCP server answers the sampling rules and
+	// sampling targets calls itself instead of forwarding them to the AWS
+	// X-Ray backend, and no AWS configuration or credentials are needed.
+	LocalSamplingRulesFile string `mapstructure:"local_sampling_rules_file"`
 }
 
 func DefaultConfig() *Config {
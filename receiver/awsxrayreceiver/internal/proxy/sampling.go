@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	getSamplingRulesPath   = "/GetSamplingRules"
+	getSamplingTargetsPath = "/SamplingTargets"
+
+	defaultRuleName     = "Default"
+	defaultRulePriority = 10000
+	wildcard            = "*"
+
+	// samplingRuleVersion is the only rule version accepted by the X-Ray SDKs.
+	samplingRuleVersion = 1
+
+	// targetInterval is the number of seconds the SDKs wait before
+	// reporting sampling statistics again.
+	targetInterval = 10
+	// quotaTTL defines for how long a reservoir quota handed to a client
+	// stays valid without being refreshed.
+	quotaTTL = 3 * targetInterval * time.Second
+	// clientActiveWindow defines for how long a client that stopped
+	// reporting statistics for a rule keeps its share of the reservoir.
+	clientActiveWindow = 3 * targetInterval * time.Second
+
+	errCodeUnknownRule = "400"
+)
+
+// localRules is the content of a local sampling rules file. The format is
+// the one used by the X-Ray SDKs for their local sampling rules, extended
+// with optional rule names, priorities and service types.
+type localRules struct {
+	Version int          `json:"version"`
+	Rules   []*localRule `json:"rules"`
+	Default *localRule   `json:"default"`
+}
+
+type localRule struct {
+	RuleName    string  `json:"rule_name"`
+	Description string  `json:"description"`
+	Priority    int     `json:"priority"`
+	ServiceName string  `json:"service_name"`
+	ServiceType string  `json:"service_type"`
+	Host        string  `json:"host"`
+	HTTPMethod  string  `json:"http_method"`
+	URLPath     string  `json:"url_path"`
+	FixedTarget int64   `json:"fixed_target"`
+	Rate        float64 `json:"rate"`
+}
+
+// The types below mirror the X-Ray GetSamplingRules and GetSamplingTargets
+// API payloads. Timestamps are expressed in epoch seconds as the SDKs expect.
+
+type samplingRule struct {
+	RuleName      string            `json:"RuleName"`
+	RuleARN       string            `json:"RuleARN"`
+	ResourceARN   string            `json:"ResourceARN"`
+	Priority      int               `json:"Priority"`
+	FixedRate     float64           `json:"FixedRate"`
+	ReservoirSize int64             `json:"ReservoirSize"`
+	ServiceName   string            `json:"ServiceName"`
+	ServiceType   string            `json:"ServiceType"`
+	Host          string            `json:"Host"`
+	HTTPMethod    string            `json:"HTTPMethod"`
+	URLPath       string            `json:"URLPath"`
+	Version       int               `json:"Version"`
+	Attributes    map[string]string `json:"Attributes"`
+}
+
+type samplingRuleRecord struct {
+	SamplingRule *samplingRule `json:"SamplingRule"`
+	CreatedAt    float64       `json:"CreatedAt"`
+	ModifiedAt   float64       `json:"ModifiedAt"`
+}
+
+type getSamplingRulesOutput struct {
+	SamplingRuleRecords []*samplingRuleRecord `json:"SamplingRuleRecords"`
+}
+
+type samplingStatisticsDocument struct {
+	RuleName     string  `json:"RuleName"`
+	ClientID     string  `json:"ClientID"`
+	Timestamp    float64 `json:"Timestamp"`
+	RequestCount int64   `json:"RequestCount"`
+	SampledCount int64   `json:"SampledCount"`
+	BorrowCount  int64   `json:"BorrowCount"`
+}
+
+type getSamplingTargetsInput struct {
+	SamplingStatisticsDocuments []*samplingStatisticsDocument `json:"SamplingStatisticsDocuments"`
+}
+
+type samplingTargetDocument struct {
+	RuleName          string  `json:"RuleName"`
+	FixedRate         float64 `json:"FixedRate"`
+	ReservoirQuota    int64   `json:"ReservoirQuota"`
+	ReservoirQuotaTTL float64 `json:"ReservoirQuotaTTL"`
+	Interval          int64   `json:"Interval"`
+}
+
+type unprocessedStatistics struct {
+	RuleName  string `json:"RuleName"`
+	ErrorCode string `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+type getSamplingTargetsOutput struct {
+	SamplingTargetDocuments []*samplingTargetDocument `json:"SamplingTargetDocuments"`
+	LastRuleModification    float64                   `json:"LastRuleModification"`
+	UnprocessedStatistics   []*unprocessedStatistics  `json:"UnprocessedStatistics"`
+}
+
+// ruleState keeps the accounting of a single sampling rule across all
+// the clients reporting statistics for it.
+type ruleState struct {
+	rule *samplingRule
+	// clients maps client IDs to the last time they reported statistics.
+	clients map[string]time.Time
+
+	requestCount int64
+	sampledCount int64
+	borrowCount  int64
+}
+
+// localSampler serves sampling rules and targets from a local rules file
+// instead of forwarding the calls to the AWS X-Ray backend.
+type localSampler struct {
+	logger   *zap.Logger
+	now      func() time.Time
+	loadedAt time.Time
+
+	mu     sync.Mutex
+	rules  []*ruleState
+	byName map[string]*ruleState
+}
+
+func newLocalSampler(path string, logger *zap.Logger) (*localSampler, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read local sampling rules file: %w", err)
+	}
+	rules, err := parseLocalRules(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local sampling rules file %q: %w", path, err)
+	}
+
+	s := &localSampler{
+		logger:   logger,
+		now:      time.Now,
+		loadedAt: time.Now(),
+		byName:   make(map[string]*ruleState, len(rules)),
+	}
+	for _, r := range rules {
+		state := &ruleState{
+			rule:    r,
+			clients: make(map[string]time.Time),
+		}
+		s.rules = append(s.rules, state)
+		s.byName[r.RuleName] = state
+	}
+	return s, nil
+}
+
+// parseLocalRules converts the content of a local rules file into
+// sampling rules as returned by the X-Ray API.
+func parseLocalRules(content []byte) ([]*samplingRule, error) {
+	var file localRules
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	if file.Version != 1 && file.Version != 2 {
+		return nil, fmt.Errorf("unsupported version %d", file.Version)
+	}
+	if file.Default == nil {
+		return nil, errors.New("a default rule must be provided")
+	}
+
+	rules := make([]*samplingRule, 0, len(file.Rules)+1)
+	seen := make(map[string]bool, len(file.Rules)+1)
+	for i, r := range file.Rules {
+		name := r.RuleName
+		if name == "" {
+			name = fmt.Sprintf("Local-%d", i+1)
+		}
+		priority := r.Priority
+		if priority == 0 {
+			priority = i + 1
+		}
+		rule, err := toSamplingRule(r, name, priority)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] || name == defaultRuleName {
+			return nil, fmt.Errorf("duplicate rule name %q", name)
+		}
+		seen[name] = true
+		rules = append(rules, rule)
+	}
+
+	// The default rule matches everything and is always evaluated last.
+	def := *file.Default
+	def.ServiceName, def.ServiceType, def.Host, def.HTTPMethod, def.URLPath = "", "", "", "", ""
+	rule, err := toSamplingRule(&def, defaultRuleName, defaultRulePriority)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, rule)
+	return rules, nil
+}
+
+func toSamplingRule(r *localRule, name string, priority int) (*samplingRule, error) {
+	if r.Rate < 0 || r.Rate > 1 {
+		return nil, fmt.Errorf("rule %q: rate must be between 0 and 1", name)
+	}
+	if r.FixedTarget < 0 {
+		return nil, fmt.Errorf("rule %q: fixed_target must not be negative", name)
+	}
+	return &samplingRule{
+		RuleName:      name,
+		RuleARN:       "arn:aws:xray:::sampling-rule/" + name,
+		ResourceARN:   wildcard,
+		Priority:      priority,
+		FixedRate:     r.Rate,
+		ReservoirSize: r.FixedTarget,
+		ServiceName:   orWildcard(r.ServiceName),
+		ServiceType:   orWildcard(r.ServiceType),
+		Host:          orWildcard(r.Host),
+		HTTPMethod:    orWildcard(r.HTTPMethod),
+		URLPath:       orWildcard(r.URLPath),
+		Version:       samplingRuleVersion,
+		Attributes:    map[string]string{},
+	}, nil
+}
+
+func orWildcard(val string) string {
+	if val == "" {
+		return wildcard
+	}
+	return val
+}
+
+func (s *localSampler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.logger.Debug("Received request on X-Ray receiver TCP proxy server", zap.String("URL", req.URL.String()))
+
+	var (
+		resp interface{}
+		err  error
+	)
+	switch req.URL.Path {
+	case getSamplingRulesPath:
+		resp = s.getSamplingRules()
+	case getSamplingTargetsPath:
+		resp, err = s.getSamplingTargets(req)
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		s.logger.Debug("Unable to serve sampling targets", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		s.logger.Error("Unable to write sampling response", zap.Error(err))
+	}
+}
+
+func (s *localSampler) getSamplingRules() *getSamplingRulesOutput {
+	loadedAt := epochSeconds(s.loadedAt)
+	out := &getSamplingRulesOutput{
+		SamplingRuleRecords: make([]*samplingRuleRecord, 0, len(s.rules)),
+	}
+	for _, state := range s.rules {
+		out.SamplingRuleRecords = append(out.SamplingRuleRecords, &samplingRuleRecord{
+			SamplingRule: state.rule,
+			CreatedAt:    loadedAt,
+			ModifiedAt:   loadedAt,
+		})
+	}
+	return out
+}
+
+func (s *localSampler) getSamplingTargets(req *http.Request) (*getSamplingTargetsOutput, error) {
+	if req.Body == nil {
+		return nil, errors.New("missing request body")
+	}
+	var in getSamplingTargetsInput
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		return nil, fmt.Errorf("unable to decode sampling statistics: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	out := &getSamplingTargetsOutput{
+		SamplingTargetDocuments: []*samplingTargetDocument{},
+		LastRuleModification:    epochSeconds(s.loadedAt),
+		UnprocessedStatistics:   []*unprocessedStatistics{},
+	}
+
+	// Record all the statistics first so that every client reporting in
+	// this call is taken into account when distributing the reservoirs.
+	var reported []*samplingStatisticsDocument
+	for _, doc := range in.SamplingStatisticsDocuments {
+		state, ok := s.byName[doc.RuleName]
+		if !ok {
+			out.UnprocessedStatistics = append(out.UnprocessedStatistics, &unprocessedStatistics{
+				RuleName:  doc.RuleName,
+				ErrorCode: errCodeUnknownRule,
+				Message:   "unknown sampling rule",
+			})
+			continue
+		}
+		state.clients[doc.ClientID] = now
+		state.requestCount += doc.RequestCount
+		state.sampledCount += doc.SampledCount
+		state.borrowCount += doc.BorrowCount
+		reported = append(reported, doc)
+	}
+
+	for _, doc := range reported {
+		state := s.byName[doc.RuleName]
+		out.SamplingTargetDocuments = append(out.SamplingTargetDocuments, &samplingTargetDocument{
+			RuleName:          doc.RuleName,
+			FixedRate:         state.rule.FixedRate,
+			ReservoirQuota:    state.quota(doc.ClientID, now),
+			ReservoirQuotaTTL: epochSeconds(now.Add(quotaTTL)),
+			Interval:          targetInterval,
+		})
+	}
+	return out, nil
+}
+
+// quota returns the share of the rule's reservoir assigned to the given
+// client. The reservoir is split evenly across the clients active within
+// clientActiveWindow, the remainder going to the first clients in
+// lexicographical order so that the quotas always add up to the reservoir.
+func (r *ruleState) quota(clientID string, now time.Time) int64 {
+	active := make([]string, 0, len(r.clients))
+	for id, lastSeen := range r.clients {
+		if now.Sub(lastSeen) > clientActiveWindow {
+			delete(r.clients, id)
+			continue
+		}
+		active = append(active, id)
+	}
+	sort.Strings(active)
+
+	n := int64(len(active))
+	quota := r.rule.ReservoirSize / n
+	remainder := r.rule.ReservoirSize % n
+	for i, id := range active {
+		if id == clientID {
+			if int64(i) < remainder {
+				quota++
+			}
+			break
+		}
+	}
+	return quota
+}
+
+func epochSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalSampler(t *testing.T) (*localSampler, http.Handler) {
+	logger, _ := logSetup()
+
+	env := stashEnv()
+	defer restoreEnv(env)
+
+	cfg := DefaultConfig()
+	cfg.LocalSamplingRulesFile = path.Join("testdata", "sampling_rules.json")
+	// no region nor credentials are needed when serving rules locally
+	srv, err := NewServer(cfg, logger)
+	require.NoError(t, err, "NewServer should succeed")
+
+	handler := srv.(*http.Server).Handler
+	sampler, ok := handler.(*localSampler)
+	require.True(t, ok, "handler should be a local sampler")
+	return sampler, handler
+}
+
+func TestLocalSamplingRules(t *testing.T) {
+	_, handler := newTestLocalSampler(t)
+
+	req := httptest.NewRequest("POST", "http://127.0.0.1:2000/GetSamplingRules", strings.NewReader(`{"NextToken": null}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var out getSamplingRulesOutput
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+	require.Len(t, out.SamplingRuleRecords, 3)
+
+	checkout := out.SamplingRuleRecords[0].SamplingRule
+	assert.Equal(t, "Checkout", checkout.RuleName)
+	assert.Equal(t, 1, checkout.Priority)
+	assert.Equal(t, "checkout", checkout.ServiceName)
+	assert.Equal(t, "*", checkout.ServiceType)
+	assert.Equal(t, "POST", checkout.HTTPMethod)
+	assert.Equal(t, "/api/checkout/*", checkout.URLPath)
+	assert.Equal(t, int64(5), checkout.ReservoirSize)
+	assert.Equal(t, 0.5, checkout.FixedRate)
+	assert.Equal(t, 1, checkout.Version)
+	assert.Equal(t, "*", checkout.ResourceARN)
+
+	health := out.SamplingRuleRecords[1].SamplingRule
+	assert.Equal(t, "Local-2", health.RuleName)
+	assert.Equal(t, 2, health.Priority)
+	assert.Equal(t, "*", health.ServiceName)
+	assert.Equal(t, "/health", health.URLPath)
+
+	def := out.SamplingRuleRecords[2].SamplingRule
+	assert.Equal(t, "Default", def.RuleName)
+	assert.Equal(t, 10000, def.Priority)
+	assert.Equal(t, int64(1), def.ReservoirSize)
+	assert.Equal(t, 0.1, def.FixedRate)
+	assert.Equal(t, "*", def.URLPath)
+}
+
+func TestLocalSamplingTargets(t *testing.T) {
+	sampler, handler := newTestLocalSampler(t)
+	now := time.Unix(1600000000, 0)
+	sampler.now = func() time.Time { return now }
+
+	getTargets := func(body string) *getSamplingTargetsOutput {
+		req := httptest.NewRequest("POST", "http://127.0.0.1:2000/SamplingTargets", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var out getSamplingTargetsOutput
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+		return &out
+	}
+
+	out := getTargets(`{"SamplingStatisticsDocuments": [
+		{"RuleName": "Checkout", "ClientID": "client-a", "RequestCount": 10, "SampledCount": 5, "BorrowCount": 1},
+		{"RuleName": "Unknown", "ClientID": "client-a", "RequestCount": 1}
+	]}`)
+	require.Len(t, out.SamplingTargetDocuments, 1)
+	target := out.SamplingTargetDocuments[0]
+	assert.Equal(t, "Checkout", target.RuleName)
+	assert.Equal(t, 0.5, target.FixedRate)
+	assert.Equal(t, int64(5), target.ReservoirQuota, "a single client gets the whole reservoir")
+	assert.Equal(t, float64(now.Add(quotaTTL).Unix()), target.ReservoirQuotaTTL)
+	assert.Equal(t, int64(targetInterval), target.Interval)
+	require.Len(t, out.UnprocessedStatistics, 1)
+	assert.Equal(t, "Unknown", out.UnprocessedStatistics[0].RuleName)
+
+	// a second client shows up, the reservoir is split between both
+	now = now.Add(targetInterval * time.Second)
+	out = getTargets(`{"SamplingStatisticsDocuments": [
+		{"RuleName": "Checkout", "ClientID": "client-b", "RequestCount": 4, "SampledCount": 3}
+	]}`)
+	require.Len(t, out.SamplingTargetDocuments, 1)
+	assert.Equal(t, int64(2), out.SamplingTargetDocuments[0].ReservoirQuota)
+
+	out = getTargets(`{"SamplingStatisticsDocuments": [
+		{"RuleName": "Checkout", "ClientID": "client-a", "RequestCount": 4, "SampledCount": 3}
+	]}`)
+	require.Len(t, out.SamplingTargetDocuments, 1)
+	assert.Equal(t, int64(3), out.SamplingTargetDocuments[0].ReservoirQuota)
+
+	state := sampler.byName["Checkout"]
+	assert.Equal(t, int64(18), state.requestCount)
+	assert.Equal(t, int64(11), state.sampledCount)
+	assert.Equal(t, int64(1), state.borrowCount)
+
+	// client-a stops reporting, client-b eventually gets the whole reservoir
+	now = now.Add(clientActiveWindow + time.Second)
+	out = getTargets(`{"SamplingStatisticsDocuments": [
+		{"RuleName": "Checkout", "ClientID": "client-b", "RequestCount": 1}
+	]}`)
+	require.Len(t, out.SamplingTargetDocuments, 1)
+	assert.Equal(t, int64(5), out.SamplingTargetDocuments[0].ReservoirQuota)
+}
+
+func TestLocalSamplingBadRequests(t *testing.T) {
+	_, handler := newTestLocalSampler(t)
+
+	req := httptest.NewRequest("POST", "http://127.0.0.1:2000/SamplingTargets", strings.NewReader(`not json`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest("POST", "http://127.0.0.1:2000/TraceSegments", strings.NewReader(`{}`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestLocalSamplingRulesFileErrors(t *testing.T) {
+	logger, _ := logSetup()
+
+	cfg := DefaultConfig()
+	cfg.LocalSamplingRulesFile = path.Join("testdata", "does_not_exist.json")
+	_, err := NewServer(cfg, logger)
+	assert.Error(t, err, "NewServer should fail")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	cfg.LocalSamplingRulesFile = path.Join("testdata", "sampling_rules_invalid.json")
+	_, err = NewServer(cfg, logger)
+	assert.Error(t, err, "NewServer should fail")
+	assert.Contains(t, err.Error(), "rate must be between 0 and 1")
+}
+
+func TestParseLocalRules(t *testing.T) {
+	_, err := parseLocalRules([]byte(`{"version": 3, "default": {"fixed_target": 1, "rate": 0.1}}`))
+	assert.EqualError(t, err, "unsupported version 3")
+
+	_, err = parseLocalRules([]byte(`{"version": 2, "rules": []}`))
+	assert.EqualError(t, err, "a default rule must be provided")
+
+	_, err = parseLocalRules([]byte(`{"version": 2, "rules": [
+		{"rule_name": "a", "fixed_target": 1, "rate": 0.1},
+		{"rule_name": "a", "fixed_target": 1, "rate": 0.1}
+	], "default": {"fixed_target": 1, "rate": 0.1}}`))
+	assert.EqualError(t, err, `duplicate rule name "a"`)
+
+	_, err = parseLocalRules([]byte(`{"version": 2, "rules": [
+		{"fixed_target": -1, "rate": 0.1}
+	], "default": {"fixed_target": 1, "rate": 0.1}}`))
+	assert.EqualError(t, err, `rule "Local-1": fixed_target must not be negative`)
+}
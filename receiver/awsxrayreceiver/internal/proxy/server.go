@@ -55,6 +55,19 @@ func NewServer(cfg *Config, logger *zap.Logger) (Server, error) {
 		logger.Debug("Using remote proxy", zap.String("address", cfg.ProxyAddress))
 	}
 
+	if cfg.LocalSamplingRulesFile != "" {
+		sampler, err := newLocalSampler(cfg.LocalSamplingRulesFile, logger)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Serving sampling rules locally",
+			zap.String("file", cfg.LocalSamplingRulesFile))
+		return &http.Server{
+			Addr:    cfg.Endpoint,
+			Handler: sampler,
+		}, nil
+	}
+
 	awsCfg, sess, err := getAWSConfigSession(cfg, logger)
 	if err != nil {
 		return nil, err
@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	r0 := cfg.Receivers["carbon"]
 	assert.Equal(t, factory.CreateDefaultConfig(), r0)
@@ -100,4 +100,23 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		r2)
+
+	r3 := cfg.Receivers["carbon/pickle"].(*Config)
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: configmodels.Type(typeStr),
+				NameVal: "carbon/pickle",
+			},
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "localhost:2004",
+				Transport: "tcp",
+			},
+			TCPIdleTimeout: 30 * time.Second,
+			Parser: &protocol.Config{
+				Type:   "pickle",
+				Config: &protocol.PickleConfig{MaxFrameSize: 4194304},
+			},
+		},
+		r3)
 }
@@ -66,6 +66,28 @@ config:
 				Config: &RegexParserConfig{},
 			},
 		},
+		{
+			name: "default_pickle",
+			yaml: `type: pickle`,
+			cfg:  Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{MaxFrameSize: defaultPickleMaxFrameSize},
+			},
+		},
+		{
+			name: "custom_pickle",
+			yaml: `
+type: pickle
+config:
+  max_frame_size: 4096
+`,
+			cfg: Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{MaxFrameSize: 4096},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
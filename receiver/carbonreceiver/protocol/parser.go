@@ -15,6 +15,8 @@
 package protocol
 
 import (
+	"io"
+
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -38,6 +40,23 @@ type Parser interface {
 	Parse(line string) (*metricspb.Metric, error)
 }
 
+// FrameParser is implemented by parsers of protocols that send batches of
+// metrics in binary frames instead of text lines, e.g. the pickle protocol.
+// Transports that support framing use it in place of the Parse method.
+type FrameParser interface {
+	Parser
+
+	// ReadFrame reads the next frame from the given reader. Errors returned by
+	// it leave the reader in an unknown state so no further frames should be
+	// read from it.
+	ReadFrame(r io.Reader) ([]byte, error)
+
+	// ParseFrame transforms the frame to the collector metric format. It
+	// returns the metrics that could be parsed even if an error is returned
+	// for the remaining ones.
+	ParseFrame(frame []byte) ([]*metricspb.Metric, error)
+}
+
 // Below a few helper functions useful to different parsers.
 func buildMetricForSinglePoint(
 	metricName string,
@@ -118,36 +118,46 @@ func (pph *PathParserHelper) Parse(line string) (*metricspb.Metric, error) {
 		return nil, fmt.Errorf("invalid carbon metric time [%s]: %v", line, err)
 	}
 
-	var metricType metricspb.MetricDescriptor_Type
 	point := metricspb.Point{
 		Timestamp: convertUnixSec(unixTime),
 	}
 	intVal, err := strconv.ParseInt(valueStr, 10, 64)
 	if err == nil {
-		if parsedPath.MetricType == CumulativeMetricType {
-			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
-		} else {
-			metricType = metricspb.MetricDescriptor_GAUGE_INT64
-		}
 		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
 	} else {
 		dblVal, err := strconv.ParseFloat(valueStr, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid carbon metric value [%s]: %v", line, err)
 		}
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
+	}
+
+	return buildMetricForParsedPath(&parsedPath, &point), nil
+}
+
+// buildMetricForParsedPath builds the metric for the given parsed path and
+// point. The metric type is selected according to the TargetMetricType of the
+// path and the type of the point value.
+func buildMetricForParsedPath(parsedPath *ParsedPath, point *metricspb.Point) *metricspb.Metric {
+	var metricType metricspb.MetricDescriptor_Type
+	if _, ok := point.Value.(*metricspb.Point_Int64Value); ok {
+		if parsedPath.MetricType == CumulativeMetricType {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+		} else {
+			metricType = metricspb.MetricDescriptor_GAUGE_INT64
+		}
+	} else {
 		if parsedPath.MetricType == CumulativeMetricType {
 			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
 		} else {
 			metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
 		}
-		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
 	}
 
-	metric := buildMetricForSinglePoint(
+	return buildMetricForSinglePoint(
 		parsedPath.MetricName,
 		metricType,
 		parsedPath.LabelKeys,
 		parsedPath.LabelValues,
-		&point)
-	return metric, nil
+		point)
 }
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultPickleMaxFrameSize is the default maximum size, in bytes, of a
+	// pickle frame.
+	defaultPickleMaxFrameSize = 1024 * 1024
+
+	// pickleHeaderSize is the size of the length prefix of each frame.
+	pickleHeaderSize = 4
+)
+
+// PickleConfig holds the configuration for the pickle parser.
+type PickleConfig struct {
+	// MaxFrameSize is the maximum size, in bytes, of a pickle frame. Clients
+	// sending larger frames are disconnected. The default is 1 MiB.
+	MaxFrameSize int `mapstructure:"max_frame_size"`
+}
+
+var _ (ParserConfig) = (*PickleConfig)(nil)
+
+// BuildParser creates a new Parser instance that receives Carbon data in
+// the pickle format.
+func (p *PickleConfig) BuildParser() (Parser, error) {
+	if p.MaxFrameSize <= 0 {
+		return nil, fmt.Errorf("invalid max_frame_size %d, it must be positive", p.MaxFrameSize)
+	}
+	return &PickleParser{
+		maxFrameSize: p.MaxFrameSize,
+		pathParser:   &PlaintextPathParser{},
+	}, nil
+}
+
+// PickleParser converts the frames of https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+// Each frame is a 4 bytes big-endian length followed by a pickled list of
+// tuples in the following format:
+//
+// 	[(<metric_path>, (<metric_timestamp>, <metric_value>)), ...]
+//
+// The <metric_path> is handled like in the plaintext parser, so tags are
+// supported. Only the pickle opcodes needed to encode plain data are accepted,
+// so untrusted clients can't execute code by sending crafted frames.
+type PickleParser struct {
+	maxFrameSize int
+	pathParser   PathParser
+}
+
+var _ (FrameParser) = (*PickleParser)(nil)
+
+// Parse always fails, the pickle protocol is not line based.
+func (pp *PickleParser) Parse(string) (*metricspb.Metric, error) {
+	return nil, errors.New("the pickle parser requires a transport with support for frames")
+}
+
+// ReadFrame reads a length prefixed pickle frame from the reader.
+func (pp *PickleParser) ReadFrame(r io.Reader) ([]byte, error) {
+	var header [pickleHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if uint64(size) > uint64(pp.maxFrameSize) {
+		return nil, fmt.Errorf("pickle frame of %d bytes exceeds the maximum of %d bytes", size, pp.maxFrameSize)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// ParseFrame unpickles the frame and transforms each of its tuples in a
+// metric. Invalid tuples are reported in the returned error and skipped.
+func (pp *PickleParser) ParseFrame(frame []byte) ([]*metricspb.Metric, error) {
+	v, err := unpickle(frame)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pickle frame: %v", err)
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid pickle frame: expected a list, got %T", v)
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(items))
+	var errs []error
+	for _, item := range items {
+		metric, err := pp.parseItem(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, componenterror.CombineErrors(errs)
+}
+
+// parseItem transforms a (<metric_path>, (<metric_timestamp>, <metric_value>))
+// tuple in a metric.
+func (pp *PickleParser) parseItem(item interface{}) (*metricspb.Metric, error) {
+	tuple, ok := item.([]interface{})
+	if !ok || len(tuple) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle item %v", item)
+	}
+	path, ok := tuple[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle item %v: path is not a string", item)
+	}
+	datapoint, ok := tuple[1].([]interface{})
+	if !ok || len(datapoint) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle item %v: datapoint is not a (timestamp, value) tuple", item)
+	}
+
+	parsedPath := ParsedPath{}
+	if err := pp.pathParser.ParsePath(path, &parsedPath); err != nil {
+		return nil, fmt.Errorf("invalid carbon metric [%s]: %v", path, err)
+	}
+
+	point := metricspb.Point{}
+	switch ts := datapoint[0].(type) {
+	case int64:
+		point.Timestamp = convertUnixSec(ts)
+	case float64:
+		point.Timestamp = convertUnixFloatSec(ts)
+	default:
+		return nil, fmt.Errorf("invalid carbon metric time [%s]: %v", path, datapoint[0])
+	}
+
+	switch val := datapoint[1].(type) {
+	case int64:
+		point.Value = &metricspb.Point_Int64Value{Int64Value: val}
+	case float64:
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: val}
+	default:
+		return nil, fmt.Errorf("invalid carbon metric value [%s]: %v", path, datapoint[1])
+	}
+
+	return buildMetricForParsedPath(&parsedPath, &point), nil
+}
+
+func convertUnixFloatSec(sec float64) *timestamppb.Timestamp {
+	whole, frac := math.Modf(sec)
+	return &timestamppb.Timestamp{
+		Seconds: int64(whole),
+		Nanos:   int32(frac * 1e9),
+	}
+}
+
+func pickleDefaultConfig() ParserConfig {
+	return &PickleConfig{
+		MaxFrameSize: defaultPickleMaxFrameSize,
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The pickles below were generated by Python 3 with pickle.dumps of:
+//
+// 	[("test.metric;host=a", (1582230020, 1)),
+// 	 ("test.double", (1582230020.5, 2.5)),
+// 	 ("test.big", (1582230020, 2**40))]
+const (
+	pickleProtocol0 = "(lp0\n(Vtest.metric;host=a\np1\n(I1582230020\nI1\ntp2\ntp3\na(Vtest.double\np4\n(F1582230020.5\nF2.5\ntp5\ntp6\na(Vtest.big\np7\n(I1582230020\nL1099511627776L\ntp8\ntp9\na."
+	pickleProtocol2 = "\x80\x02]q\x00(X\x12\x00\x00\x00test.metric;host=aq\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x0b\x00\x00\x00test.doubleq\x04GA\xd7\x93\xba\x81 \x00\x00G@\x04\x00\x00\x00\x00\x00\x00\x86q\x05\x86q\x06X\x08\x00\x00\x00test.bigq\x07J\x04\xeaN^\x8a\x06\x00\x00\x00\x00\x00\x01\x86q\x08\x86q\x09e."
+	pickleProtocol4 = "\x80\x04\x95e\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x12test.metric;host=a\x94J\x04\xeaN^K\x01\x86\x94\x86\x94\x8c\x0btest.double\x94GA\xd7\x93\xba\x81 \x00\x00G@\x04\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x08test.big\x94J\x04\xeaN^\x8a\x06\x00\x00\x00\x00\x00\x01\x86\x94\x86\x94e."
+)
+
+func TestPickleParser_ParseFrame(t *testing.T) {
+	wantMetrics := []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"test.metric",
+			[]string{"host"},
+			[]string{"a"},
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			"test.double",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020, Nanos: 500000000},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 2.5},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"test.big",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 1 << 40},
+			},
+		),
+	}
+
+	tests := []struct {
+		name  string
+		frame string
+	}{
+		{name: "protocol_0", frame: pickleProtocol0},
+		{name: "protocol_2", frame: pickleProtocol2},
+		{name: "protocol_4", frame: pickleProtocol4},
+	}
+
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+	fp := p.(FrameParser)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fp.ParseFrame([]byte(tt.frame))
+			require.NoError(t, err)
+			assert.Equal(t, wantMetrics, got)
+		})
+	}
+}
+
+func TestPickleParser_ParseFrameInvalid(t *testing.T) {
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+	fp := p.(FrameParser)
+
+	tests := []struct {
+		name        string
+		frame       string
+		wantMetrics int
+	}{
+		{
+			// os.system("echo") as generated by Python 2.
+			name:  "global_and_reduce",
+			frame: "cos\nsystem\n(S'echo'\ntR.",
+		},
+		{
+			name:  "truncated",
+			frame: pickleProtocol2[:20],
+		},
+		{
+			name:  "not_a_list",
+			frame: "I1\n.",
+		},
+		{
+			// [("test.good", (1, 1)), ("test.none", (1, None)), "bad"] with
+			// Python 2 style strings.
+			name:        "bad_items",
+			frame:       "(lp0\n(S'test.good'\n(I1\nI1\nttp1\na(S'test.none'\n(I1\nNttp2\naS'bad'\na.",
+			wantMetrics: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fp.ParseFrame([]byte(tt.frame))
+			assert.Error(t, err)
+			assert.Len(t, got, tt.wantMetrics)
+		})
+	}
+}
+
+func TestPickleParser_ReadFrame(t *testing.T) {
+	cfg := &PickleConfig{MaxFrameSize: 16}
+	p, err := cfg.BuildParser()
+	require.NoError(t, err)
+	fp := p.(FrameParser)
+
+	buf := &bytes.Buffer{}
+	writeFrame(buf, []byte("first"))
+	writeFrame(buf, []byte("second"))
+	writeFrame(buf, bytes.Repeat([]byte("x"), 17))
+
+	frame, err := fp.ReadFrame(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(frame))
+
+	frame, err = fp.ReadFrame(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(frame))
+
+	_, err = fp.ReadFrame(buf)
+	assert.EqualError(t, err, "pickle frame of 17 bytes exceeds the maximum of 16 bytes")
+
+	_, err = fp.ReadFrame(bytes.NewReader([]byte{0, 0, 0, 4, 'a'}))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestPickleConfig_BuildParser(t *testing.T) {
+	_, err := (&PickleConfig{}).BuildParser()
+	assert.Error(t, err)
+
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+	_, err = p.Parse("test.metric 1 1582230020")
+	assert.Error(t, err)
+}
+
+func writeFrame(w io.Writer, frame []byte) {
+	_ = binary.Write(w, binary.BigEndian, uint32(len(frame)))
+	_, _ = w.Write(frame)
+}
@@ -0,0 +1,498 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Opcodes of the Python pickle protocols that are accepted by unpickle. See
+// https://github.com/python/cpython/blob/master/Lib/pickletools.py for their
+// description.
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opBinFloat       = 'G'
+	opEmptyList      = ']'
+	opAppends        = 'e'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opBinBytes       = 'B'
+	opShortBinBytes  = 'C'
+
+	opProto           = 0x80
+	opTuple1          = 0x85
+	opTuple2          = 0x86
+	opTuple3          = 0x87
+	opNewTrue         = 0x88
+	opNewFalse        = 0x89
+	opLong1           = 0x8a
+	opLong4           = 0x8b
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opBinBytes8       = 0x8e
+	opMemoize         = 0x94
+	opFrame           = 0x95
+)
+
+// mark separates the items pushed on the stack after a MARK opcode.
+type mark struct{}
+
+var errTruncatedPickle = errors.New("truncated pickle")
+
+// unpickle decodes a pickle holding only lists, tuples, strings, numbers,
+// booleans and None. Opcodes that could build arbitrary objects or call
+// functions are rejected, so untrusted data can be decoded safely. Tuples are
+// returned as []interface{}, like lists.
+func unpickle(data []byte) (interface{}, error) {
+	u := unpickler{buf: bytes.NewBuffer(data), memo: make(map[int]interface{})}
+	return u.run()
+}
+
+type unpickler struct {
+	buf   *bytes.Buffer
+	stack []interface{}
+	memo  map[int]interface{}
+}
+
+func (u *unpickler) run() (interface{}, error) {
+	for {
+		op, err := u.buf.ReadByte()
+		if err != nil {
+			return nil, errTruncatedPickle
+		}
+
+		switch op {
+		case opStop:
+			if len(u.stack) != 1 {
+				return nil, fmt.Errorf("invalid pickle: %d items left on the stack", len(u.stack))
+			}
+			return u.stack[0], nil
+		case opProto:
+			if _, err := u.read(1); err != nil {
+				return nil, err
+			}
+		case opFrame:
+			if _, err := u.read(8); err != nil {
+				return nil, err
+			}
+		case opMark:
+			u.push(mark{})
+		case opPop:
+			if _, err := u.pop(); err != nil {
+				return nil, err
+			}
+		case opPopMark:
+			if _, err := u.popMark(); err != nil {
+				return nil, err
+			}
+		case opDup:
+			top, err := u.top()
+			if err != nil {
+				return nil, err
+			}
+			u.push(top)
+
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+
+		case opInt:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			// Protocol 0 encodes booleans as "I01" and "I00".
+			switch line {
+			case "01":
+				u.push(true)
+			case "00":
+				u.push(false)
+			default:
+				v, err := strconv.ParseInt(line, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid INT %q: %w", line, err)
+				}
+				u.push(v)
+			}
+		case opLong:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.ParseInt(strings.TrimSuffix(line, "L"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid LONG %q: %w", line, err)
+			}
+			u.push(v)
+		case opBinInt:
+			b, err := u.read(4)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+		case opBinInt1:
+			b, err := u.read(1)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(b[0]))
+		case opBinInt2:
+			b, err := u.read(2)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(binary.LittleEndian.Uint16(b)))
+		case opLong1, opLong4:
+			var n int
+			if op == opLong1 {
+				b, err := u.read(1)
+				if err != nil {
+					return nil, err
+				}
+				n = int(b[0])
+			} else {
+				b, err := u.read(4)
+				if err != nil {
+					return nil, err
+				}
+				n = int(int32(binary.LittleEndian.Uint32(b)))
+			}
+			if n < 0 || n > u.buf.Len() {
+				return nil, errTruncatedPickle
+			}
+			b, _ := u.read(n)
+			v, err := decodeLong(b)
+			if err != nil {
+				return nil, err
+			}
+			u.push(v)
+		case opFloat:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.ParseFloat(line, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FLOAT %q: %w", line, err)
+			}
+			u.push(v)
+		case opBinFloat:
+			b, err := u.read(8)
+			if err != nil {
+				return nil, err
+			}
+			u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+		case opString:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.Unquote(pythonToGoQuote(line))
+			if err != nil {
+				return nil, fmt.Errorf("invalid STRING %q: %w", line, err)
+			}
+			u.push(v)
+		case opUnicode:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			u.push(line)
+		case opShortBinString, opShortBinBytes, opShortBinUnicode:
+			b, err := u.read(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushString(int(b[0])); err != nil {
+				return nil, err
+			}
+		case opBinString, opBinBytes, opBinUnicode:
+			b, err := u.read(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushString(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		case opBinUnicode8, opBinBytes8:
+			b, err := u.read(8)
+			if err != nil {
+				return nil, err
+			}
+			n := binary.LittleEndian.Uint64(b)
+			if n > uint64(u.buf.Len()) {
+				return nil, errTruncatedPickle
+			}
+			if err := u.pushString(int(n)); err != nil {
+				return nil, err
+			}
+
+		case opEmptyList:
+			u.push([]interface{}{})
+		case opList, opTuple:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			u.push(items)
+		case opEmptyTuple:
+			u.push([]interface{}{})
+		case opTuple1, opTuple2, opTuple3:
+			n := int(op-opTuple1) + 1
+			if len(u.stack) < n {
+				return nil, errors.New("invalid pickle: stack underflow")
+			}
+			items := make([]interface{}, n)
+			copy(items, u.stack[len(u.stack)-n:])
+			u.stack = u.stack[:len(u.stack)-n]
+			u.push(items)
+		case opAppend:
+			item, err := u.pop()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.appendToList(item); err != nil {
+				return nil, err
+			}
+		case opAppends:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.appendToList(items...); err != nil {
+				return nil, err
+			}
+
+		case opPut:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			idx, err := strconv.Atoi(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid PUT %q: %w", line, err)
+			}
+			if err := u.put(idx); err != nil {
+				return nil, err
+			}
+		case opBinPut:
+			b, err := u.read(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.put(int(b[0])); err != nil {
+				return nil, err
+			}
+		case opLongBinPut:
+			b, err := u.read(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.put(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		case opMemoize:
+			if err := u.put(len(u.memo)); err != nil {
+				return nil, err
+			}
+		case opGet:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			idx, err := strconv.Atoi(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid GET %q: %w", line, err)
+			}
+			if err := u.get(idx); err != nil {
+				return nil, err
+			}
+		case opBinGet:
+			b, err := u.read(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.get(int(b[0])); err != nil {
+				return nil, err
+			}
+		case opLongBinGet:
+			b, err := u.read(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.get(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("unsupported pickle opcode %#02x", op)
+		}
+	}
+}
+
+func (u *unpickler) read(n int) ([]byte, error) {
+	if n > u.buf.Len() {
+		return nil, errTruncatedPickle
+	}
+	return u.buf.Next(n), nil
+}
+
+func (u *unpickler) readLine() (string, error) {
+	line, err := u.buf.ReadString('\n')
+	if err != nil {
+		return "", errTruncatedPickle
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+func (u *unpickler) pushString(n int) error {
+	b, err := u.read(n)
+	if err != nil {
+		return err
+	}
+	u.push(string(b))
+	return nil
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errors.New("invalid pickle: stack underflow")
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+// popMark pops the items pushed since the last mark, and the mark itself.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(mark); ok {
+			items := make([]interface{}, len(u.stack)-i-1)
+			copy(items, u.stack[i+1:])
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errors.New("invalid pickle: mark not found")
+}
+
+// appendToList appends the items to the list at the top of the stack.
+func (u *unpickler) appendToList(items ...interface{}) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	list, ok := top.([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid pickle: cannot append to %T", top)
+	}
+	u.stack[len(u.stack)-1] = append(list, items...)
+	return nil
+}
+
+func (u *unpickler) put(idx int) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	u.memo[idx] = top
+	return nil
+}
+
+func (u *unpickler) get(idx int) error {
+	v, ok := u.memo[idx]
+	if !ok {
+		return fmt.Errorf("invalid pickle: memo key %d not found", idx)
+	}
+	u.push(v)
+	return nil
+}
+
+// decodeLong decodes a little-endian two's complement integer.
+func decodeLong(b []byte) (int64, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	v := new(big.Int).SetBytes(be)
+	if b[len(b)-1]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("integer %v overflows int64", v)
+	}
+	return v.Int64(), nil
+}
+
+// pythonToGoQuote converts a single quoted Python string literal to a double
+// quoted one that strconv.Unquote understands.
+func pythonToGoQuote(s string) string {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return s
+	}
+	inner := s[1 : len(s)-1]
+	inner = strings.ReplaceAll(inner, `\'`, `'`)
+	inner = strings.ReplaceAll(inner, `"`, `\"`)
+	return `"` + inner + `"`
+}
@@ -81,6 +81,10 @@ func New(
 		return nil, err
 	}
 
+	if _, ok := parser.(protocol.FrameParser); ok && strings.ToLower(config.Transport) == "udp" {
+		return nil, fmt.Errorf("parser %q requires the \"tcp\" transport", config.Parser.Type)
+	}
+
 	// This should be the last one built, or if any other error is raised after
 	// it, the server should be closed.
 	server, err := buildTransportServer(config)
@@ -134,6 +134,26 @@ func Test_carbonreceiver_New(t *testing.T) {
 				nextConsumer: consumertest.NewMetricsNop(),
 			},
 		},
+		{
+			name: "pickle_parser_udp",
+			args: args{
+				config: Config{
+					ReceiverSettings: configmodels.ReceiverSettings{
+						NameVal: "pickle_parser_udp_rcv",
+					},
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "udp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{MaxFrameSize: 1024},
+					},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+			wantErr: errors.New("parser \"pickle\" requires the \"tcp\" transport"),
+		},
 		{
 			name: "negative_tcp_idle_timeout",
 			args: args{
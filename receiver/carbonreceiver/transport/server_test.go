@@ -15,6 +15,7 @@
 package transport
 
 import (
+	"encoding/binary"
 	"net"
 	"runtime"
 	"strconv"
@@ -113,3 +114,55 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_TCPServer_Pickle(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	svr, err := NewTCPServer(addr, 1*time.Second)
+	require.NoError(t, err)
+
+	mc := new(consumertest.MetricsSink)
+	p, err := (&protocol.PickleConfig{MaxFrameSize: 1024}).BuildParser()
+	require.NoError(t, err)
+	mr := NewMockReporter(2)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, svr.ListenAndServe(p, mc, mr))
+	}()
+
+	runtime.Gosched()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	// [("test.metric", (1582230020, 1))] and [("test.other", (1582230020, 2))]
+	// pickled with protocol 0, with an invalid frame between them.
+	frames := []string{
+		"(lp0\n(S'test.metric'\n(I1582230020\nI1\nttp1\na.",
+		"cos\nsystem\n(S'echo'\ntR.",
+		"(lp0\n(S'test.other'\n(I1582230020\nI2\nttp1\na.",
+	}
+	for _, frame := range frames {
+		require.NoError(t, binary.Write(conn, binary.BigEndian, uint32(len(frame))))
+		_, err = conn.Write([]byte(frame))
+		require.NoError(t, err)
+	}
+
+	mr.WaitAllOnMetricsProcessedCalls()
+	require.NoError(t, conn.Close())
+	require.NoError(t, svr.Close())
+	wgListenAndServe.Wait()
+
+	mdd := mc.AllMetrics()
+	require.Len(t, mdd, 2)
+	var names []string
+	for _, md := range mdd {
+		ocmd := internaldata.MetricsToOC(md)
+		require.Len(t, ocmd, 1)
+		require.Len(t, ocmd[0].Metrics, 1)
+		names = append(names, ocmd[0].Metrics[0].GetMetricDescriptor().GetName())
+	}
+	assert.Equal(t, []string{"test.metric", "test.other"}, names)
+}
@@ -145,6 +145,11 @@ func (t *tcpServer) handleConnection(
 	conn net.Conn,
 ) {
 	defer conn.Close()
+	if fp, ok := p.(protocol.FrameParser); ok {
+		t.handleFrames(fp, nextConsumer, conn)
+		return
+	}
+
 	var span *trace.Span
 	reader := bufio.NewReader(conn)
 	for {
@@ -222,3 +227,52 @@ func (t *tcpServer) handleConnection(
 		}
 	}
 }
+
+// handleFrames handles connections of parsers that receive data in frames
+// instead of lines, e.g. the pickle protocol.
+func (t *tcpServer) handleFrames(
+	p protocol.FrameParser,
+	nextConsumer consumer.MetricsConsumer,
+	conn net.Conn,
+) {
+	reader := bufio.NewReader(conn)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		frame, err := p.ReadFrame(reader)
+		if err != nil {
+			// The stream can't be resynchronized after a bad or partial frame,
+			// so any error, including timeouts and io.EOF, closes the connection.
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - error reading frame: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, err := p.ParseFrame(frame)
+		if err != nil {
+			t.reporter.OnTranslationError(ctx, err)
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+
+		md := consumerdata.MetricsData{
+			Metrics: metrics,
+		}
+		err = nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(md))
+		t.reporter.OnMetricsProcessed(ctx, len(metrics), err)
+		if err != nil {
+			// See handleConnection on why the connection is closed.
+			return
+		}
+	}
+}
@@ -29,4 +29,24 @@ type Config struct {
 	Timeout          time.Duration `mapstructure:"timeout"`
 	AttributesPrefix string        `mapstructure:"attributes_prefix"`
 	Encoding         string        `mapstructure:"encoding"`
+
+	// Network configures the UDP listener receiving the binary protocol of
+	// the collectd network plugin.
+	Network NetworkConfig `mapstructure:"network"`
+}
+
+// NetworkConfig defines the settings of the listener receiving the binary
+// protocol of the collectd network plugin.
+type NetworkConfig struct {
+	// Endpoint is the UDP address to listen on, the listener is disabled when empty.
+	Endpoint string `mapstructure:"endpoint"`
+	// SecurityLevel is the minimum security of the accepted data: "none",
+	// "sign" or "encrypt". Defaults to "none".
+	SecurityLevel string `mapstructure:"security_level"`
+	// AuthFile is the path to the file holding the "user: password" lines used
+	// to verify signed packets and decrypt encrypted packets.
+	AuthFile string `mapstructure:"auth_file"`
+	// TypesDB lists the collectd types.db files used to name the data sources
+	// of multi-value types.
+	TypesDB []string `mapstructure:"types_db"`
 }
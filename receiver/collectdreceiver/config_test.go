@@ -58,5 +58,11 @@ func TestLoadConfig(t *testing.T) {
 			Timeout:          time.Second * 50,
 			AttributesPrefix: "dap_",
 			Encoding:         "command",
+			Network: NetworkConfig{
+				Endpoint:      "localhost:25826",
+				SecurityLevel: "sign",
+				AuthFile:      "/etc/collectd/auth_file",
+				TypesDB:       []string{"/usr/share/collectd/types.db"},
+			},
 		})
 }
@@ -54,6 +54,9 @@ func createDefaultConfig() configmodels.Receiver {
 		},
 		Timeout:  defaultTimeout,
 		Encoding: defaultEncodingFormat,
+		Network: NetworkConfig{
+			SecurityLevel: securityLevelNone,
+		},
 	}
 }
 
@@ -73,5 +76,17 @@ func createMetricsReceiver(
 			c.Encoding,
 		)
 	}
-	return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	cdr, err := newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Network.Endpoint != "" {
+		parser, err := newNetworkParser(c.Network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network settings: %w", err)
+		}
+		cdr.(*collectdReceiver).withNetwork(c.Network.Endpoint, parser)
+	}
+	return cdr, nil
 }
@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec collectd checksums the encrypted payloads with SHA-1
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Part types of the collectd binary network protocol, see
+// https://collectd.org/wiki/index.php/Binary_protocol.
+const (
+	partHost           = 0x0000
+	partTime           = 0x0001
+	partPlugin         = 0x0002
+	partPluginInstance = 0x0003
+	partType           = 0x0004
+	partTypeInstance   = 0x0005
+	partValues         = 0x0006
+	partInterval       = 0x0007
+	partTimeHR         = 0x0008
+	partIntervalHR     = 0x0009
+	partMessage        = 0x0100
+	partSignSHA256     = 0x0200
+	partEncryptAES256  = 0x0210
+)
+
+// Data source types of the values part.
+const (
+	dsTypeCounter  = 0
+	dsTypeGauge    = 1
+	dsTypeDerive   = 2
+	dsTypeAbsolute = 3
+)
+
+const (
+	securityLevelNone    = "none"
+	securityLevelSign    = "sign"
+	securityLevelEncrypt = "encrypt"
+)
+
+const (
+	partHeaderLength = 4
+	signatureLength  = sha256.Size
+	ivLength         = aes.BlockSize
+	checksumLength   = sha1.Size
+)
+
+var (
+	errInvalidPart           = errors.New("invalid part")
+	errInvalidSignature      = errors.New("invalid signature")
+	errInvalidChecksum       = errors.New("invalid checksum of the encrypted data")
+	errInsufficientSecurity  = errors.New("data does not match the configured security level")
+	errUnknownSecurityLevel  = errors.New("unknown security level")
+	errAuthFileRequired      = errors.New("auth_file is required when the security level is not none")
+	errMalformedAuthFileLine = errors.New("malformed line, expected \"user: password\"")
+)
+
+// securityLevel is the security of a part of a packet, ordered from the least
+// to the most secure.
+type securityLevel int
+
+const (
+	levelNone securityLevel = iota
+	levelSign
+	levelEncrypt
+)
+
+func parseSecurityLevel(level string) (securityLevel, error) {
+	switch strings.ToLower(level) {
+	case "", securityLevelNone:
+		return levelNone, nil
+	case securityLevelSign:
+		return levelSign, nil
+	case securityLevelEncrypt:
+		return levelEncrypt, nil
+	}
+	return levelNone, fmt.Errorf("%w %q, must be one of %q, %q or %q",
+		errUnknownSecurityLevel, level, securityLevelNone, securityLevelSign, securityLevelEncrypt)
+}
+
+// networkParser decodes the packets sent by the collectd network plugin into
+// collectd records.
+type networkParser struct {
+	securityLevel securityLevel
+	passwords     map[string]string
+	// dsNames are the data source names of each type, from the types.db files.
+	dsNames map[string][]string
+}
+
+func newNetworkParser(cfg NetworkConfig) (*networkParser, error) {
+	level, err := parseSecurityLevel(cfg.SecurityLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &networkParser{
+		securityLevel: level,
+		dsNames:       make(map[string][]string),
+	}
+
+	if cfg.AuthFile != "" {
+		if p.passwords, err = readAuthFile(cfg.AuthFile); err != nil {
+			return nil, err
+		}
+	} else if level > levelNone {
+		return nil, errAuthFileRequired
+	}
+
+	for _, path := range cfg.TypesDB {
+		if err := readTypesDB(path, p.dsNames); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// readAuthFile reads the "user: password" lines of a collectd auth file.
+func readAuthFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	passwords := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.IndexByte(line, ':')
+		if idx <= 0 {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, errMalformedAuthFileLine)
+		}
+		passwords[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return passwords, scanner.Err()
+}
+
+// readTypesDB reads the data source names of each type of a collectd types.db
+// file, e.g. "if_octets rx:DERIVE:0:U, tx:DERIVE:0:U".
+func readTypesDB(path string, dsNames map[string][]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("%s:%d: malformed type %q", path, lineNum, line)
+		}
+		var names []string
+		for _, ds := range strings.Split(strings.Join(fields[1:], ""), ",") {
+			if ds == "" {
+				continue
+			}
+			names = append(names, strings.SplitN(ds, ":", 2)[0])
+		}
+		dsNames[fields[0]] = names
+	}
+	return scanner.Err()
+}
+
+// valueList holds the state accumulated while parsing the parts of a packet,
+// each values part inherits the identifier and time set by the previous parts.
+type valueList struct {
+	host           string
+	plugin         string
+	pluginInstance string
+	typ            string
+	typeInstance   string
+	time           float64
+	interval       float64
+}
+
+// parse decodes a packet into records. Packets that do not match the
+// configured security level or fail their integrity checks are rejected.
+func (p *networkParser) parse(buf []byte) ([]collectDRecord, error) {
+	var records []collectDRecord
+	err := p.parseParts(buf, levelNone, &valueList{}, &records)
+	return records, err
+}
+
+func (p *networkParser) parseParts(buf []byte, level securityLevel, vl *valueList, records *[]collectDRecord) error {
+	for len(buf) > 0 {
+		if len(buf) < partHeaderLength {
+			return errInvalidPart
+		}
+		kind := binary.BigEndian.Uint16(buf[0:2])
+		partLength := int(binary.BigEndian.Uint16(buf[2:4]))
+		if partLength < partHeaderLength || partLength > len(buf) {
+			return fmt.Errorf("%w: type %#04x has length %d", errInvalidPart, kind, partLength)
+		}
+		payload := buf[partHeaderLength:partLength]
+
+		switch kind {
+		case partSignSHA256:
+			signed, err := p.verifySignature(payload, buf[partLength:])
+			if err != nil {
+				return err
+			}
+			if signed {
+				level = levelSign
+			}
+		case partEncryptAES256:
+			// The encrypted part spans until the end of the packet.
+			decrypted, err := p.decrypt(payload)
+			if err != nil {
+				return err
+			}
+			if decrypted != nil {
+				if err := p.parseParts(decrypted, levelEncrypt, vl, records); err != nil {
+					return err
+				}
+			}
+		case partValues:
+			if level < p.securityLevel {
+				return errInsufficientSecurity
+			}
+			record, err := p.parseValues(payload, vl)
+			if err != nil {
+				return err
+			}
+			*records = append(*records, record)
+		case partMessage:
+			// Notifications are not converted to metrics, like the events
+			// received over write_http.
+			recordEventsReceived()
+		default:
+			if err := parseIdentifierPart(kind, payload, vl); err != nil {
+				return err
+			}
+		}
+		buf = buf[partLength:]
+	}
+	return nil
+}
+
+func parseIdentifierPart(kind uint16, payload []byte, vl *valueList) error {
+	switch kind {
+	case partHost, partPlugin, partPluginInstance, partType, partTypeInstance:
+		s, err := parseString(payload)
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case partHost:
+			vl.host = s
+		case partPlugin:
+			vl.plugin = s
+		case partPluginInstance:
+			vl.pluginInstance = s
+		case partType:
+			vl.typ = s
+		case partTypeInstance:
+			vl.typeInstance = s
+		}
+	case partTime, partTimeHR, partInterval, partIntervalHR:
+		if len(payload) != 8 {
+			return fmt.Errorf("%w: numeric part of length %d", errInvalidPart, len(payload))
+		}
+		n := binary.BigEndian.Uint64(payload)
+		switch kind {
+		case partTime:
+			vl.time = float64(n)
+		case partTimeHR:
+			vl.time = float64(n) / (1 << 30)
+		case partInterval:
+			vl.interval = float64(n)
+		case partIntervalHR:
+			vl.interval = float64(n) / (1 << 30)
+		}
+	}
+	// Unknown parts, such as the severity of notifications, are skipped.
+	return nil
+}
+
+func parseString(payload []byte) (string, error) {
+	if len(payload) == 0 || payload[len(payload)-1] != 0 {
+		return "", fmt.Errorf("%w: string is not null terminated", errInvalidPart)
+	}
+	return string(payload[:len(payload)-1]), nil
+}
+
+func (p *networkParser) parseValues(payload []byte, vl *valueList) (collectDRecord, error) {
+	if len(payload) < 2 {
+		return collectDRecord{}, fmt.Errorf("%w: values part too short", errInvalidPart)
+	}
+	count := int(binary.BigEndian.Uint16(payload[0:2]))
+	if len(payload) != 2+9*count {
+		return collectDRecord{}, fmt.Errorf("%w: %d values in %d bytes", errInvalidPart, count, len(payload))
+	}
+	types := payload[2 : 2+count]
+	values := payload[2+count:]
+
+	names := p.dsNames[vl.typ]
+	if len(names) != count {
+		names = defaultDSNames(count)
+	}
+
+	vlCopy := *vl
+	record := collectDRecord{
+		Dsnames:        make([]*string, count),
+		Dstypes:        make([]*string, count),
+		Values:         make([]*json.Number, count),
+		Host:           &vlCopy.host,
+		Plugin:         &vlCopy.plugin,
+		PluginInstance: &vlCopy.pluginInstance,
+		TypeS:          &vlCopy.typ,
+		TypeInstance:   &vlCopy.typeInstance,
+		Interval:       &vlCopy.interval,
+	}
+	if vl.time > 0 {
+		record.Time = &vlCopy.time
+	}
+
+	for i := 0; i < count; i++ {
+		name := names[i]
+		record.Dsnames[i] = &name
+
+		raw := values[8*i : 8*i+8]
+		var dsType, value string
+		switch types[i] {
+		case dsTypeCounter:
+			dsType, value = collectDMetricCounter, strconv.FormatUint(binary.BigEndian.Uint64(raw), 10)
+		case dsTypeGauge:
+			// Gauges are the only values encoded in little endian.
+			v := math.Float64frombits(binary.LittleEndian.Uint64(raw))
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				// collectd reports unknown gauges as NaN, skip them.
+				continue
+			}
+			dsType, value = collectDMetricGauge, strconv.FormatFloat(v, 'g', -1, 64)
+		case dsTypeDerive:
+			dsType, value = collectDMetricDerive, strconv.FormatInt(int64(binary.BigEndian.Uint64(raw)), 10)
+		case dsTypeAbsolute:
+			dsType, value = collectDMetricAbsolute, strconv.FormatUint(binary.BigEndian.Uint64(raw), 10)
+		default:
+			return collectDRecord{}, fmt.Errorf("%w: unknown data source type %d", errInvalidPart, types[i])
+		}
+		number := json.Number(value)
+		record.Dstypes[i] = &dsType
+		record.Values[i] = &number
+	}
+	return record, nil
+}
+
+// defaultDSNames names the data sources when the type is not in the types.db
+// files: "value" for a single value, its index otherwise.
+func defaultDSNames(count int) []string {
+	if count == 1 {
+		return []string{"value"}
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
+}
+
+// verifySignature checks the HMAC-SHA256 signature of the rest of the packet,
+// it reports whether the rest of the packet can be considered signed.
+func (p *networkParser) verifySignature(payload, signedData []byte) (bool, error) {
+	if len(payload) <= signatureLength {
+		return false, fmt.Errorf("%w: signature part too short", errInvalidPart)
+	}
+	signature := payload[:signatureLength]
+	username := payload[signatureLength:]
+
+	password, ok := p.passwords[string(username)]
+	if !ok {
+		if p.securityLevel == levelNone {
+			// Like collectd, accept signed data as is when it can't be verified
+			// and signatures are not required.
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: unknown user %q", errInvalidSignature, username)
+	}
+
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write(username)
+	mac.Write(signedData)
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return false, errInvalidSignature
+	}
+	return true, nil
+}
+
+// decrypt decrypts the AES-256 OFB encrypted part, it returns nil when the
+// part can't be decrypted and encryption is not required.
+func (p *networkParser) decrypt(payload []byte) ([]byte, error) {
+	if len(payload) < 2 {
+		return nil, fmt.Errorf("%w: encrypted part too short", errInvalidPart)
+	}
+	usernameLength := int(binary.BigEndian.Uint16(payload[0:2]))
+	if len(payload) < 2+usernameLength+ivLength+checksumLength {
+		return nil, fmt.Errorf("%w: encrypted part too short", errInvalidPart)
+	}
+	username := string(payload[2 : 2+usernameLength])
+	iv := payload[2+usernameLength : 2+usernameLength+ivLength]
+	encrypted := payload[2+usernameLength+ivLength:]
+
+	password, ok := p.passwords[username]
+	if !ok {
+		if p.securityLevel == levelNone {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to decrypt data of unknown user %q", username)
+	}
+
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewOFB(block, iv).XORKeyStream(decrypted, encrypted)
+
+	checksum := sha1.Sum(decrypted[checksumLength:]) // #nosec
+	if !bytes.Equal(checksum[:], decrypted[:checksumLength]) {
+		return nil, errInvalidChecksum
+	}
+	return decrypted[checksumLength:], nil
+}
@@ -0,0 +1,341 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"net"
+	"path"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/testutil"
+	"go.opentelemetry.io/collector/translator/internaldata"
+	"go.uber.org/zap"
+)
+
+func stringPart(kind uint16, s string) []byte {
+	return part(kind, append([]byte(s), 0))
+}
+
+func numberPart(kind uint16, n uint64) []byte {
+	payload := make([]byte, 8)
+	binary.BigEndian.PutUint64(payload, n)
+	return part(kind, payload)
+}
+
+type value struct {
+	dsType byte
+	raw    uint64
+}
+
+func gauge(v float64) value  { return value{dsType: dsTypeGauge, raw: math.Float64bits(v)} }
+func derive(v int64) value   { return value{dsType: dsTypeDerive, raw: uint64(v)} }
+func counter(v uint64) value { return value{dsType: dsTypeCounter, raw: v} }
+
+func valuesPart(values ...value) []byte {
+	payload := make([]byte, 2+9*len(values))
+	binary.BigEndian.PutUint16(payload, uint16(len(values)))
+	for i, v := range values {
+		payload[2+i] = v.dsType
+		raw := payload[2+len(values)+8*i : 2+len(values)+8*i+8]
+		if v.dsType == dsTypeGauge {
+			binary.LittleEndian.PutUint64(raw, v.raw)
+		} else {
+			binary.BigEndian.PutUint64(raw, v.raw)
+		}
+	}
+	return part(partValues, payload)
+}
+
+func part(kind uint16, payload []byte) []byte {
+	buf := make([]byte, partHeaderLength, partHeaderLength+len(payload))
+	binary.BigEndian.PutUint16(buf[0:2], kind)
+	binary.BigEndian.PutUint16(buf[2:4], uint16(partHeaderLength+len(payload)))
+	return append(buf, payload...)
+}
+
+func packet(parts ...[]byte) []byte {
+	var buf []byte
+	for _, p := range parts {
+		buf = append(buf, p...)
+	}
+	return buf
+}
+
+func sign(data []byte, username, password string) []byte {
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(username))
+	mac.Write(data)
+	return append(part(partSignSHA256, append(mac.Sum(nil), username...)), data...)
+}
+
+func encrypt(data []byte, username, password string) []byte {
+	checksum := sha1.Sum(data) // #nosec
+	plain := append(checksum[:], data...)
+
+	key := sha256.Sum256([]byte(password))
+	block, _ := aes.NewCipher(key[:])
+	iv := []byte("0123456789abcdef")
+	encrypted := make([]byte, len(plain))
+	cipher.NewOFB(block, iv).XORKeyStream(encrypted, plain)
+
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, uint16(len(username)))
+	payload = append(payload, username...)
+	payload = append(payload, iv...)
+	return part(partEncryptAES256, append(payload, encrypted...))
+}
+
+func loadPacket() []byte {
+	return packet(
+		stringPart(partHost, "host-1"),
+		numberPart(partTimeHR, 1600000000<<30),
+		numberPart(partIntervalHR, 10<<30),
+		stringPart(partPlugin, "load"),
+		stringPart(partPluginInstance, ""),
+		stringPart(partType, "load"),
+		stringPart(partTypeInstance, ""),
+		valuesPart(gauge(0.5), gauge(0.25), gauge(math.NaN())),
+	)
+}
+
+func newTestNetworkParser(t *testing.T, level string) *networkParser {
+	p, err := newNetworkParser(NetworkConfig{
+		SecurityLevel: level,
+		AuthFile:      path.Join("testdata", "auth_file"),
+		TypesDB:       []string{path.Join("testdata", "types.db")},
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestNetworkParserValues(t *testing.T) {
+	p := newTestNetworkParser(t, securityLevelNone)
+
+	records, err := p.parse(packet(
+		loadPacket(),
+		stringPart(partPlugin, "interface"),
+		stringPart(partPluginInstance, "eth0"),
+		stringPart(partType, "if_octets"),
+		valuesPart(derive(1024), derive(2048)),
+		stringPart(partType, "unknown"),
+		stringPart(partTypeInstance, "total"),
+		valuesPart(counter(1), counter(2)),
+		stringPart(partMessage, "notification are ignored"),
+	))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	load := records[0]
+	assert.Equal(t, "host-1", *load.Host)
+	assert.Equal(t, "load", *load.Plugin)
+	assert.Equal(t, "load", *load.TypeS)
+	assert.Equal(t, 1600000000.0, *load.Time)
+	assert.Equal(t, 10.0, *load.Interval)
+	assert.Equal(t, "shortterm", *load.Dsnames[0])
+	assert.Equal(t, "midterm", *load.Dsnames[1])
+	assert.Equal(t, "longterm", *load.Dsnames[2])
+	assert.Equal(t, collectDMetricGauge, *load.Dstypes[0])
+	assert.Equal(t, "0.5", load.Values[0].String())
+	assert.Equal(t, "0.25", load.Values[1].String())
+	assert.Nil(t, load.Values[2], "NaN gauges are skipped")
+
+	octets := records[1]
+	assert.Equal(t, "interface", *octets.Plugin)
+	assert.Equal(t, "eth0", *octets.PluginInstance)
+	assert.Equal(t, "if_octets", *octets.TypeS)
+	assert.Equal(t, "", *octets.TypeInstance)
+	assert.Equal(t, "rx", *octets.Dsnames[0])
+	assert.Equal(t, "tx", *octets.Dsnames[1])
+	assert.Equal(t, collectDMetricDerive, *octets.Dstypes[0])
+	assert.Equal(t, "1024", octets.Values[0].String())
+	assert.Equal(t, "2048", octets.Values[1].String())
+
+	unknown := records[2]
+	assert.Equal(t, "unknown", *unknown.TypeS)
+	assert.Equal(t, "total", *unknown.TypeInstance)
+	assert.Equal(t, "0", *unknown.Dsnames[0])
+	assert.Equal(t, "1", *unknown.Dsnames[1])
+	assert.Equal(t, collectDMetricCounter, *unknown.Dstypes[1])
+	assert.Equal(t, "2", unknown.Values[1].String())
+}
+
+func TestNetworkParserMalformed(t *testing.T) {
+	p := newTestNetworkParser(t, securityLevelNone)
+
+	tests := []struct {
+		name   string
+		packet []byte
+	}{
+		{name: "truncated header", packet: []byte{0, 1, 0}},
+		{name: "length too long", packet: []byte{0, 1, 0, 20, 0, 0, 0, 0}},
+		{name: "length too short", packet: []byte{0, 1, 0, 2}},
+		{name: "string not terminated", packet: part(partHost, []byte("host"))},
+		{name: "bad number", packet: part(partTime, []byte{1, 2, 3})},
+		{name: "bad values count", packet: part(partValues, []byte{0, 2, dsTypeGauge, 0, 0, 0, 0, 0, 0, 0, 0})},
+		{name: "unknown ds type", packet: part(partValues, []byte{0, 1, 9, 0, 0, 0, 0, 0, 0, 0, 0})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.parse(tt.packet)
+			assert.ErrorIs(t, err, errInvalidPart)
+		})
+	}
+}
+
+func TestNetworkParserSigned(t *testing.T) {
+	signed := sign(loadPacket(), "alice", "secret")
+
+	p := newTestNetworkParser(t, securityLevelSign)
+	records, err := p.parse(signed)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	_, err = p.parse(loadPacket())
+	assert.ErrorIs(t, err, errInsufficientSecurity)
+
+	_, err = p.parse(sign(loadPacket(), "alice", "wrong"))
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	_, err = p.parse(sign(loadPacket(), "mallory", "secret"))
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	tampered := append([]byte{}, signed...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = p.parse(tampered)
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	// Signatures of unknown users are not verified when not required.
+	p = newTestNetworkParser(t, securityLevelNone)
+	records, err = p.parse(sign(loadPacket(), "mallory", "secret"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	p = newTestNetworkParser(t, securityLevelEncrypt)
+	_, err = p.parse(signed)
+	assert.ErrorIs(t, err, errInsufficientSecurity)
+}
+
+func TestNetworkParserEncrypted(t *testing.T) {
+	encrypted := encrypt(loadPacket(), "bob", "hunter2")
+
+	for _, level := range []string{securityLevelNone, securityLevelSign, securityLevelEncrypt} {
+		p := newTestNetworkParser(t, level)
+		records, err := p.parse(encrypted)
+		require.NoError(t, err, level)
+		require.Len(t, records, 1, level)
+		assert.Equal(t, "host-1", *records[0].Host)
+	}
+
+	p := newTestNetworkParser(t, securityLevelEncrypt)
+	_, err := p.parse(encrypt(loadPacket(), "bob", "wrong"))
+	assert.ErrorIs(t, err, errInvalidChecksum)
+
+	_, err = p.parse(encrypt(loadPacket(), "mallory", "hunter2"))
+	assert.Error(t, err)
+
+	// Encrypted data of unknown users is skipped when encryption is not required.
+	p = newTestNetworkParser(t, securityLevelNone)
+	records, err := p.parse(encrypt(loadPacket(), "mallory", "hunter2"))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestNewNetworkParserErrors(t *testing.T) {
+	_, err := newNetworkParser(NetworkConfig{SecurityLevel: "paranoid"})
+	assert.ErrorIs(t, err, errUnknownSecurityLevel)
+
+	_, err = newNetworkParser(NetworkConfig{SecurityLevel: securityLevelSign})
+	assert.Equal(t, errAuthFileRequired, err)
+
+	_, err = newNetworkParser(NetworkConfig{AuthFile: path.Join("testdata", "does-not-exist")})
+	assert.Error(t, err)
+
+	_, err = newNetworkParser(NetworkConfig{AuthFile: path.Join("testdata", "auth_file_invalid")})
+	assert.ErrorIs(t, err, errMalformedAuthFileLine)
+}
+
+func TestCollectDNetworkServer(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Network = NetworkConfig{
+		Endpoint:      addr,
+		SecurityLevel: securityLevelSign,
+		AuthFile:      path.Join("testdata", "auth_file"),
+		TypesDB:       []string{path.Join("testdata", "types.db")},
+	}
+
+	sink := new(consumertest.MetricsSink)
+	cdr, err := createMetricsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, cdr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, cdr.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The unsigned packet is dropped, only the signed one is received.
+	_, err = conn.Write(loadPacket())
+	require.NoError(t, err)
+	_, err = conn.Write(sign(loadPacket(), "alice", "secret"))
+	require.NoError(t, err)
+
+	testutil.WaitFor(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	})
+	time.Sleep(100 * time.Millisecond)
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+
+	got := internaldata.MetricsToOC(mds[0])
+	require.Len(t, got, 1)
+	metrics := got[0].Metrics
+	require.Len(t, metrics, 2)
+
+	names := []string{metrics[0].MetricDescriptor.Name, metrics[1].MetricDescriptor.Name}
+	assert.Equal(t, []string{"load.shortterm", "load.midterm"}, names)
+	assert.Equal(t, metricspb.MetricDescriptor_GAUGE_DOUBLE, metrics[0].MetricDescriptor.Type)
+	assert.Equal(t, 0.5, metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+	assert.Equal(t, int64(1600000000), metrics[0].Timeseries[0].Points[0].Timestamp.Seconds)
+}
+
+func TestCreateMetricsReceiverInvalidNetwork(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Network = NetworkConfig{
+		Endpoint:      "localhost:0",
+		SecurityLevel: securityLevelEncrypt,
+	}
+	_, err := createMetricsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewMetricsNop())
+	assert.EqualError(t, err, "invalid network settings: "+errAuthFileRequired.Error())
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -49,6 +50,13 @@ type collectdReceiver struct {
 	defaultAttrsPrefix string
 	nextConsumer       consumer.MetricsConsumer
 
+	// networkAddr is the UDP address receiving the binary protocol of the
+	// collectd network plugin, if enabled.
+	networkAddr   string
+	networkParser *networkParser
+	networkConn   net.PacketConn
+	networkWG     sync.WaitGroup
+
 	startOnce sync.Once
 	stopOnce  sync.Once
 }
@@ -79,7 +87,15 @@ func newCollectdReceiver(
 	return r, nil
 }
 
-// StartMetricsReception starts an HTTP server that can process CollectD JSON requests.
+// withNetwork enables the listener of the binary protocol of the collectd
+// network plugin on the given UDP address.
+func (cdr *collectdReceiver) withNetwork(addr string, parser *networkParser) {
+	cdr.networkAddr = addr
+	cdr.networkParser = parser
+}
+
+// StartMetricsReception starts an HTTP server that can process CollectD JSON requests,
+// and the UDP listener of the network plugin packets if enabled.
 func (cdr *collectdReceiver) Start(_ context.Context, host component.Host) error {
 	cdr.Lock()
 	defer cdr.Unlock()
@@ -87,6 +103,16 @@ func (cdr *collectdReceiver) Start(_ context.Context, host component.Host) error
 	err := errAlreadyStarted
 	cdr.startOnce.Do(func() {
 		err = nil
+		if cdr.networkAddr != "" {
+			cdr.networkConn, err = net.ListenPacket("udp", cdr.networkAddr)
+			if err != nil {
+				err = fmt.Errorf("failed to listen on %s: %w", cdr.networkAddr, err)
+				return
+			}
+			cdr.networkWG.Add(1)
+			go cdr.serveNetwork()
+		}
+
 		go func() {
 			err = cdr.server.ListenAndServe()
 			if err != nil {
@@ -106,6 +132,12 @@ func (cdr *collectdReceiver) Shutdown(context.Context) error {
 	var err = errAlreadyStopped
 	cdr.stopOnce.Do(func() {
 		err = cdr.server.Shutdown(context.Background())
+		if cdr.networkConn != nil {
+			if closeErr := cdr.networkConn.Close(); err == nil {
+				err = closeErr
+			}
+			cdr.networkWG.Wait()
+		}
 	})
 	return err
 }
@@ -182,3 +214,53 @@ func (cdr *collectdReceiver) handleHTTPErr(w http.ResponseWriter, err error, msg
 		cdr.logger.Error("error writing to response writer", zap.Error(err))
 	}
 }
+
+// maxPacketSize is the maximum size of a UDP datagram.
+const maxPacketSize = 65535
+
+// serveNetwork reads the packets sent by the collectd network plugin until the
+// listener is closed.
+func (cdr *collectdReceiver) serveNetwork() {
+	defer cdr.networkWG.Done()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := cdr.networkConn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+			return
+		}
+		recordRequestReceived()
+
+		records, err := cdr.networkParser.parse(buf[:n])
+		if err != nil {
+			recordRequestErrors()
+			cdr.logger.Debug("unable to decode collectd packet", zap.Stringer("addr", addr), zap.Error(err))
+			continue
+		}
+
+		md := consumerdata.MetricsData{}
+		for _, record := range records {
+			md.Metrics, err = record.appendToMetrics(md.Metrics, nil)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			recordRequestErrors()
+			cdr.logger.Debug("unable to process collectd packet", zap.Stringer("addr", addr), zap.Error(err))
+			continue
+		}
+		if len(md.Metrics) == 0 {
+			continue
+		}
+
+		if err := cdr.nextConsumer.ConsumeMetrics(context.Background(), internaldata.OCToMetrics(md)); err != nil {
+			recordRequestErrors()
+			cdr.logger.Error("unable to process metrics", zap.Error(err))
+		}
+	}
+}
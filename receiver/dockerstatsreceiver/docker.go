@@ -46,6 +46,8 @@ type dockerClient struct {
 	containersLock       sync.Mutex
 	excludedImageMatcher *StringMatcher
 	logger               *zap.Logger
+	// Called with the ID of each destroyed container when set.
+	containerDestroyed func(cid string)
 }
 
 func newDockerClient(config *Config, logger *zap.Logger) (*dockerClient, error) {
@@ -194,6 +196,25 @@ func (dc *dockerClient) toStatsJSON(
 	return &statsJSON, nil
 }
 
+// FollowContainerLogs opens a stream of the stdout and stderr outputs of the container, each line
+// prefixed with its timestamp, starting after the given time and following the new lines until
+// the container stops or the context is canceled.
+func (dc *dockerClient) FollowContainerLogs(
+	ctx context.Context,
+	container DockerContainer,
+	since time.Time,
+) (io.ReadCloser, error) {
+	dc.logger.Debug("Following container logs.", zap.String("id", container.ID), zap.Time("since", since))
+	options := dtypes.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Timestamps: true,
+		Follow:     true,
+	}
+	return dc.client.ContainerLogs(ctx, container.ID, options)
+}
+
 func (dc *dockerClient) ContainerEventLoop(ctx context.Context) {
 	filters := dfilters.NewArgs([]dfilters.KeyValuePair{
 		{Key: "type", Value: "container"},
@@ -224,6 +245,9 @@ EVENT_LOOP:
 				case "destroy":
 					dc.logger.Debug("Docker container was destroyed:", zap.String("id", event.ID))
 					dc.removeContainer(event.ID)
+					if dc.containerDestroyed != nil {
+						dc.containerDestroyed(event.ID)
+					}
 				default:
 					dc.logger.Debug(
 						"Docker container update:",
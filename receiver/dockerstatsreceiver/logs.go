@@ -0,0 +1,207 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+
+	// Attribute of the log records holding the output stream they were written to.
+	logStreamAttribute = "log.iostream"
+
+	// Length of the header of the frames of multiplexed log streams: the stream type
+	// on the first byte and the big-endian payload size on the last four.
+	frameHeaderLength = 8
+)
+
+// logEntry is a line written by a container to one of its outputs.
+type logEntry struct {
+	stream    string
+	timestamp time.Time
+	body      string
+}
+
+// logStreamReader reads the entries of a container logs stream as returned by the
+// Docker logs API with timestamps. Unless the container has a TTY, the stdout and
+// stderr outputs are multiplexed in the stream: each write is sent in a frame whose
+// header identifies the output. Lines longer than the Docker log buffer are split
+// across several frames, they are joined back into a single entry.
+type logStreamReader struct {
+	reader  *bufio.Reader
+	tty     bool
+	header  [frameHeaderLength]byte
+	queue   []logEntry
+	partial map[string]*logEntry
+}
+
+func newLogStreamReader(reader io.Reader, tty bool) *logStreamReader {
+	return &logStreamReader{
+		reader:  bufio.NewReader(reader),
+		tty:     tty,
+		partial: make(map[string]*logEntry),
+	}
+}
+
+// Next returns the next complete entry of the stream. Once the end of the stream
+// is reached, the partial lines left are returned before the error.
+func (lr *logStreamReader) Next() (logEntry, error) {
+	for len(lr.queue) == 0 {
+		stream, payload, err := lr.readChunk()
+		if err != nil {
+			if len(lr.partial) == 0 {
+				return logEntry{}, err
+			}
+			for _, stream := range []string{streamStdout, streamStderr} {
+				if entry, ok := lr.partial[stream]; ok {
+					lr.queue = append(lr.queue, *entry)
+					delete(lr.partial, stream)
+				}
+			}
+			break
+		}
+		lr.parseChunk(stream, payload)
+	}
+
+	entry := lr.queue[0]
+	lr.queue = lr.queue[1:]
+	return entry, nil
+}
+
+// Pending returns whether entries can be read without waiting for more data from
+// the underlying reader.
+func (lr *logStreamReader) Pending() bool {
+	return len(lr.queue) > 0 || lr.reader.Buffered() > 0
+}
+
+// readChunk returns the next frame of a multiplexed stream, or the next line of a
+// raw one.
+func (lr *logStreamReader) readChunk() (string, []byte, error) {
+	if lr.tty {
+		line, err := lr.reader.ReadBytes('\n')
+		if len(line) > 0 && err == io.EOF {
+			err = nil
+		}
+		return streamStdout, line, err
+	}
+
+	if _, err := io.ReadFull(lr.reader, lr.header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return "", nil, fmt.Errorf("truncated log frame header: %w", err)
+		}
+		return "", nil, err
+	}
+
+	var stream string
+	switch lr.header[0] {
+	case 1:
+		stream = streamStdout
+	case 2:
+		stream = streamStderr
+	default:
+		return "", nil, fmt.Errorf("unexpected log stream type %d", lr.header[0])
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint32(lr.header[4:]))
+	if _, err := io.ReadFull(lr.reader, payload); err != nil {
+		return "", nil, fmt.Errorf("truncated log frame: %w", err)
+	}
+	return stream, payload, nil
+}
+
+// parseChunk queues the lines of the chunk, keeping the last one aside until it
+// is completed by the next chunk of the same stream if it doesn't end with a
+// newline.
+func (lr *logStreamReader) parseChunk(stream string, chunk []byte) {
+	for len(chunk) > 0 {
+		line := chunk
+		complete := false
+		if i := bytes.IndexByte(chunk, '\n'); i >= 0 {
+			line = chunk[:i]
+			chunk = chunk[i+1:]
+			complete = true
+		} else {
+			chunk = nil
+		}
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+
+		timestamp, body := splitTimestamp(string(line))
+		entry, ok := lr.partial[stream]
+		if ok {
+			entry.body += body
+		} else {
+			entry = &logEntry{stream: stream, timestamp: timestamp, body: body}
+		}
+
+		if complete {
+			lr.queue = append(lr.queue, *entry)
+			delete(lr.partial, stream)
+		} else {
+			lr.partial[stream] = entry
+		}
+	}
+}
+
+// splitTimestamp splits the line into the timestamp Docker prefixed it with and
+// the line written by the container. Lines without a valid timestamp are
+// timestamped with the current time.
+func splitTimestamp(line string) (time.Time, string) {
+	parts := strings.SplitN(line, " ", 2)
+	if timestamp, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+		if len(parts) == 1 {
+			return timestamp, ""
+		}
+		return timestamp, parts[1]
+	}
+	return time.Now(), line
+}
+
+// newContainerLogs returns empty logs of the container, with the container information
+// as resource attributes.
+func newContainerLogs(container *DockerContainer, config *Config) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+
+	attrs := rl.Resource().Attributes()
+	for k, v := range containerResourceLabels(container, config) {
+		attrs.UpsertString(k, v)
+	}
+
+	rl.InstrumentationLibraryLogs().Resize(1)
+	return ld
+}
+
+// appendLogEntry adds the entry as a record to logs created by newContainerLogs.
+func appendLogEntry(ld pdata.Logs, entry logEntry) {
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(logs.Len() + 1)
+	record := logs.At(logs.Len() - 1)
+
+	record.SetTimestamp(pdata.TimestampUnixNano(entry.timestamp.UnixNano()))
+	record.Body().SetStringVal(entry.body)
+	record.Attributes().UpsertString(logStreamAttribute, entry.stream)
+}
@@ -0,0 +1,275 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/interval"
+)
+
+// maxLogBatchSize is the maximum number of records of a container sent at once to the
+// next consumer. Smaller batches are sent when the logs stream has no more data available.
+const maxLogBatchSize = 100
+
+var _ component.LogsReceiver = (*LogsReceiver)(nil)
+var _ interval.Runnable = (*LogsReceiver)(nil)
+
+// LogsReceiver follows the stdout and stderr outputs of the running containers through the
+// Docker logs API. The containers to follow are refreshed on the collection interval.
+type LogsReceiver struct {
+	config            *Config
+	logger            *zap.Logger
+	nextConsumer      consumer.LogsConsumer
+	client            *dockerClient
+	runner            *interval.Runner
+	obsCtx            context.Context
+	runnerCtx         context.Context
+	runnerCancel      context.CancelFunc
+	successfullySetup bool
+	transport         string
+	startTime         time.Time
+
+	followersLock sync.Mutex
+	// Cancel functions of the containers being followed, by container ID.
+	followers map[string]context.CancelFunc
+	// Timestamp of the last record of each container, the logs of a container followed
+	// again after being stopped or losing the connection resume from it.
+	lastTimestamps map[string]time.Time
+	// Destroyed containers whose last timestamp is forgotten once they are no longer followed.
+	destroyed   map[string]struct{}
+	followersWG sync.WaitGroup
+}
+
+func NewLogsReceiver(
+	_ context.Context,
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine receiver transport: %w", err)
+	}
+
+	receiver := LogsReceiver{
+		config:         config,
+		nextConsumer:   nextConsumer,
+		logger:         logger,
+		transport:      parsed.Scheme,
+		followers:      make(map[string]context.CancelFunc),
+		lastTimestamps: make(map[string]time.Time),
+		destroyed:      make(map[string]struct{}),
+	}
+
+	return &receiver, nil
+}
+
+func (r *LogsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.client, err = newDockerClient(r.config, r.logger)
+	if err != nil {
+		return err
+	}
+	r.client.containerDestroyed = r.forgetContainer
+
+	// Only the logs written from now on are collected.
+	r.startTime = time.Now()
+	r.obsCtx = obsreport.ReceiverContext(ctx, r.config.Name(), r.transport)
+
+	r.runnerCtx, r.runnerCancel = context.WithCancel(context.Background())
+	r.runner = interval.NewRunner(r.config.CollectionInterval, r)
+
+	go func() {
+		if err := r.runner.Start(); err != nil {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	return nil
+}
+
+func (r *LogsReceiver) Shutdown(ctx context.Context) error {
+	// No container is followed once the runner context is canceled, so no follower is
+	// added while waiting for them.
+	r.followersLock.Lock()
+	if r.runnerCancel != nil {
+		r.runnerCancel()
+	}
+	r.followersLock.Unlock()
+
+	if r.runner != nil {
+		r.runner.Stop()
+	}
+	r.followersWG.Wait()
+	return nil
+}
+
+func (r *LogsReceiver) Setup() error {
+	err := r.client.LoadContainerList(r.runnerCtx)
+	if err != nil {
+		return err
+	}
+
+	go r.client.ContainerEventLoop(r.runnerCtx)
+	r.successfullySetup = true
+	r.followContainers()
+	return nil
+}
+
+func (r *LogsReceiver) Run() error {
+	if !r.successfullySetup {
+		return r.Setup()
+	}
+
+	r.followContainers()
+	return nil
+}
+
+// followContainers starts following the logs of the containers of interest that are not
+// followed yet, stops following the ones that are no longer running and forgets the last
+// timestamps of the destroyed ones.
+func (r *LogsReceiver) followContainers() {
+	containers := r.client.Containers()
+
+	r.followersLock.Lock()
+	defer r.followersLock.Unlock()
+
+	if r.runnerCtx.Err() != nil {
+		return
+	}
+
+	running := make(map[string]struct{}, len(containers))
+	for _, container := range containers {
+		running[container.ID] = struct{}{}
+		if _, ok := r.followers[container.ID]; ok {
+			continue
+		}
+
+		since, ok := r.lastTimestamps[container.ID]
+		if !ok {
+			since = r.startTime
+		}
+
+		ctx, cancel := context.WithCancel(r.runnerCtx)
+		r.followers[container.ID] = cancel
+		r.followersWG.Add(1)
+		go func(container DockerContainer) {
+			defer r.followersWG.Done()
+			r.followContainer(ctx, container, since)
+
+			r.followersLock.Lock()
+			defer r.followersLock.Unlock()
+			cancel()
+			delete(r.followers, container.ID)
+		}(container)
+	}
+
+	for id, cancel := range r.followers {
+		if _, ok := running[id]; !ok {
+			cancel()
+		}
+	}
+
+	// The logs of a destroyed container still followed may still be consumed, its last
+	// timestamp is forgotten on a later run.
+	for id := range r.destroyed {
+		if _, ok := r.followers[id]; ok {
+			continue
+		}
+		delete(r.lastTimestamps, id)
+		delete(r.destroyed, id)
+	}
+}
+
+// forgetContainer records that the container was destroyed, its logs are never followed again.
+func (r *LogsReceiver) forgetContainer(cid string) {
+	r.followersLock.Lock()
+	defer r.followersLock.Unlock()
+	r.destroyed[cid] = struct{}{}
+}
+
+// followContainer sends the logs of the container written after since to the next consumer
+// until its logs stream ends or the context is canceled.
+func (r *LogsReceiver) followContainer(ctx context.Context, container DockerContainer, since time.Time) {
+	body, err := r.client.FollowContainerLogs(ctx, container, since)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Warn("Could not follow docker container logs", zap.String("id", container.ID), zap.Error(err))
+		}
+		return
+	}
+	defer body.Close()
+
+	reader := newLogStreamReader(body, container.Config.Tty)
+	ld := newContainerLogs(&container, r.config)
+	numRecords := 0
+	last := since
+
+	for {
+		entry, err := reader.Next()
+		if err == nil && entry.timestamp.After(since) {
+			appendLogEntry(ld, entry)
+			numRecords++
+			if entry.timestamp.After(last) {
+				last = entry.timestamp
+			}
+		}
+
+		if numRecords > 0 && (err != nil || numRecords >= maxLogBatchSize || !reader.Pending()) {
+			r.consumeLogs(container.ID, ld, numRecords, last)
+			ld = newContainerLogs(&container, r.config)
+			numRecords = 0
+		}
+
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				r.logger.Warn("Could not read docker container logs", zap.String("id", container.ID), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// consumeLogs sends the logs of the container to the next consumer and records the
+// timestamp of their last record, from which its logs are resumed.
+func (r *LogsReceiver) consumeLogs(cid string, ld pdata.Logs, numRecords int, last time.Time) {
+	c := obsreport.StartLogsReceiveOp(r.obsCtx, r.config.Name(), r.transport)
+	err := r.nextConsumer.ConsumeLogs(c, ld)
+	obsreport.EndLogsReceiveOp(c, typeStr, numRecords, err)
+	if err != nil {
+		r.logger.Warn("Could not consume docker container logs", zap.String("id", cid), zap.Error(err))
+	}
+
+	r.followersLock.Lock()
+	defer r.followersLock.Unlock()
+	r.lastTimestamps[cid] = last
+}
@@ -0,0 +1,256 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+// TODO review if tests should succeed on Windows
+
+package dockerstatsreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	dtime "github.com/docker/docker/api/types/time"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+// fakeDockerAPI serves the container list, inspect, events and logs endpoints of the Docker
+// API for a set of containers. The logs of each container are served once per request, the
+// lines written after the requested "since" time only.
+type fakeDockerAPI struct {
+	sync.Mutex
+	containers map[string]dtypes.ContainerJSON
+	logs       map[string][][]byte
+	logTimes   map[string][]time.Time
+	sinces     map[string][]string
+}
+
+func newFakeDockerAPI() *fakeDockerAPI {
+	return &fakeDockerAPI{
+		containers: make(map[string]dtypes.ContainerJSON),
+		logs:       make(map[string][][]byte),
+		logTimes:   make(map[string][]time.Time),
+		sinces:     make(map[string][]string),
+	}
+}
+
+func (f *fakeDockerAPI) addContainer(id string, image string) {
+	f.Lock()
+	defer f.Unlock()
+	f.containers[id] = dtypes.ContainerJSON{
+		ContainerJSONBase: &dtypes.ContainerJSONBase{
+			ID:    id,
+			Name:  "/" + id + "-name",
+			State: &dtypes.ContainerState{Running: true},
+		},
+		Config: &dcontainer.Config{
+			Image:  image,
+			Labels: map[string]string{"my.label": id + "-label"},
+		},
+	}
+}
+
+func (f *fakeDockerAPI) addLog(id string, streamType byte, timestamp time.Time, line string) {
+	f.Lock()
+	defer f.Unlock()
+	f.logs[id] = append(f.logs[id], logFrame(streamType, timestamp.Format(time.RFC3339Nano)+" "+line+"\n"))
+	f.logTimes[id] = append(f.logTimes[id], timestamp)
+}
+
+func (f *fakeDockerAPI) logsSince(id string) []string {
+	f.Lock()
+	defer f.Unlock()
+	return append([]string(nil), f.sinces[id]...)
+}
+
+func (f *fakeDockerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/containers/json"):
+		var list []dtypes.Container
+		for id, c := range f.containers {
+			list = append(list, dtypes.Container{ID: id, Image: c.Config.Image})
+		}
+		json.NewEncoder(w).Encode(list)
+	case strings.HasSuffix(path, "/events"):
+		// Keep the events stream open without any event.
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		f.Unlock()
+		<-r.Context().Done()
+		f.Lock()
+	case strings.HasSuffix(path, "/json"):
+		id := strings.Split(strings.TrimPrefix(path, "/v1.22/containers/"), "/")[0]
+		c, ok := f.containers[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(c)
+	case strings.HasSuffix(path, "/logs"):
+		id := strings.Split(strings.TrimPrefix(path, "/v1.22/containers/"), "/")[0]
+		since := r.URL.Query().Get("since")
+		f.sinces[id] = append(f.sinces[id], since)
+		sec, _, err := dtime.ParseTimestamps(since, 0)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for i, frame := range f.logs[id] {
+			// Like the Docker daemon with older API versions, return the lines from the second
+			// of "since", included.
+			if f.logTimes[id][i].Unix() >= sec {
+				w.Write(frame)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func logBodies(ld []pdata.Logs) map[string][]string {
+	bodies := make(map[string][]string)
+	for _, l := range ld {
+		for i := 0; i < l.ResourceLogs().Len(); i++ {
+			rl := l.ResourceLogs().At(i)
+			name, _ := rl.Resource().Attributes().Get(conventions.AttributeContainerName)
+			logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+			for j := 0; j < logs.Len(); j++ {
+				bodies[name.StringVal()] = append(bodies[name.StringVal()], logs.At(j).Body().StringVal())
+			}
+		}
+	}
+	return bodies
+}
+
+func TestLogsReceiver(t *testing.T) {
+	api := newFakeDockerAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	api.addContainer("followed", "my-image")
+	api.addContainer("excluded", "excluded-image")
+
+	now := time.Now()
+	// Written before the receiver started, not collected.
+	api.addLog("followed", 1, now.Add(-time.Hour), "old line")
+	api.addLog("followed", 1, now.Add(time.Second), "first line")
+	api.addLog("followed", 2, now.Add(2*time.Second), "an error")
+	api.addLog("excluded", 1, now.Add(time.Second), "excluded line")
+
+	config := &Config{
+		Endpoint:           srv.URL,
+		CollectionInterval: 100 * time.Millisecond,
+		Timeout:            time.Second,
+		ExcludedImages:     []string{"excluded-*"},
+		ContainerLabelsToMetricLabels: map[string]string{
+			"my.label": "label",
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	receiver, err := NewLogsReceiver(context.Background(), zap.NewNop(), config, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, receiver.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordsCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ld := sink.AllLogs()
+	assert.Equal(t, map[string][]string{
+		"followed-name": {"first line", "an error"},
+	}, logBodies(ld))
+
+	attrs := ld[0].ResourceLogs().At(0).Resource().Attributes()
+	id, _ := attrs.Get(conventions.AttributeContainerID)
+	assert.Equal(t, "followed", id.StringVal())
+	image, _ := attrs.Get(conventions.AttributeContainerImage)
+	assert.Equal(t, "my-image", image.StringVal())
+	label, _ := attrs.Get("label")
+	assert.Equal(t, "followed-label", label.StringVal())
+
+	// The logs stream of the fake API ends after each request, the container is followed
+	// again from the last line received.
+	api.addLog("followed", 1, now.Add(3*time.Second), "after reconnection")
+	require.Eventually(t, func() bool {
+		return sink.LogRecordsCount() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, map[string][]string{
+		"followed-name": {"first line", "an error", "after reconnection"},
+	}, logBodies(sink.AllLogs()))
+
+	sinces := api.logsSince("followed")
+	require.GreaterOrEqual(t, len(sinces), 2)
+	lastTimestamp := now.Add(2 * time.Second)
+	assert.Contains(t, sinces, fmt.Sprintf("%d.%09d", lastTimestamp.Unix(), lastTimestamp.Nanosecond()))
+	assert.Empty(t, api.logsSince("excluded"))
+}
+
+func TestLogsReceiverShutdownWithoutStart(t *testing.T) {
+	config := &Config{
+		Endpoint:           "unix:///run/some.sock",
+		CollectionInterval: time.Second,
+	}
+	receiver, err := NewLogsReceiver(context.Background(), zap.NewNop(), config, new(consumertest.LogsSink))
+	require.NoError(t, err)
+	assert.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestLogsReceiverForgetsDestroyedContainers(t *testing.T) {
+	config := &Config{
+		Endpoint:           "unix:///run/some.sock",
+		CollectionInterval: time.Second,
+	}
+	receiver, err := NewLogsReceiver(context.Background(), zap.NewNop(), config, new(consumertest.LogsSink))
+	require.NoError(t, err)
+
+	r := receiver.(*LogsReceiver)
+	r.client = &dockerClient{containers: make(map[string]DockerContainer)}
+	r.runnerCtx = context.Background()
+	now := time.Now()
+	r.lastTimestamps["stopped"] = now
+	r.lastTimestamps["destroyed"] = now
+	r.lastTimestamps["followed"] = now
+	r.followers["followed"] = func() {}
+
+	r.forgetContainer("destroyed")
+	r.forgetContainer("followed")
+	r.followContainers()
+	assert.Equal(t, map[string]time.Time{"stopped": now, "followed": now}, r.lastTimestamps)
+
+	delete(r.followers, "followed")
+	r.followContainers()
+	assert.Equal(t, map[string]time.Time{"stopped": now}, r.lastTimestamps)
+	assert.Empty(t, r.destroyed)
+}
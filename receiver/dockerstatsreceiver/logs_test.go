@@ -0,0 +1,178 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// logFrame returns a frame of a multiplexed logs stream.
+func logFrame(streamType byte, payload string) []byte {
+	frame := make([]byte, frameHeaderLength, frameHeaderLength+len(payload))
+	frame[0] = streamType
+	binary.BigEndian.PutUint32(frame[4:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+func readAllEntries(reader *logStreamReader) ([]logEntry, error) {
+	var entries []logEntry
+	for {
+		entry, err := reader.Next()
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestLogStreamReaderMultiplexed(t *testing.T) {
+	stream := bytes.Join([][]byte{
+		logFrame(1, "2021-01-01T00:00:00.000000001Z first line\n"),
+		logFrame(2, "2021-01-01T00:00:00.000000002Z an error\n"),
+		// Long lines are split in several frames, each prefixed with the timestamp.
+		logFrame(1, "2021-01-01T00:00:00.000000003Z a long"),
+		logFrame(2, "2021-01-01T00:00:00.000000004Z another error\r\n"),
+		logFrame(1, "2021-01-01T00:00:00.000000005Z  line\n"),
+		// Several lines in a frame.
+		logFrame(1, "2021-01-01T00:00:00.000000006Z one\n2021-01-01T00:00:00.000000007Z two\n"),
+		logFrame(1, "no timestamp\n"),
+		logFrame(1, "2021-01-01T00:00:00.000000008Z unterminated"),
+	}, nil)
+
+	entries, err := readAllEntries(newLogStreamReader(bytes.NewReader(stream), false))
+	assert.Equal(t, io.EOF, err)
+	require.Len(t, entries, 8)
+
+	ts := func(nsec int) time.Time {
+		return time.Date(2021, 1, 1, 0, 0, 0, nsec, time.UTC)
+	}
+	assert.Equal(t, logEntry{stream: streamStdout, timestamp: ts(1), body: "first line"}, entries[0])
+	assert.Equal(t, logEntry{stream: streamStderr, timestamp: ts(2), body: "an error"}, entries[1])
+	assert.Equal(t, logEntry{stream: streamStderr, timestamp: ts(4), body: "another error"}, entries[2])
+	assert.Equal(t, logEntry{stream: streamStdout, timestamp: ts(3), body: "a long line"}, entries[3])
+	assert.Equal(t, logEntry{stream: streamStdout, timestamp: ts(6), body: "one"}, entries[4])
+	assert.Equal(t, logEntry{stream: streamStdout, timestamp: ts(7), body: "two"}, entries[5])
+	assert.Equal(t, "no timestamp", entries[6].body)
+	assert.WithinDuration(t, time.Now(), entries[6].timestamp, time.Minute)
+	assert.Equal(t, logEntry{stream: streamStdout, timestamp: ts(8), body: "unterminated"}, entries[7])
+}
+
+func TestLogStreamReaderTTY(t *testing.T) {
+	stream := "2021-01-01T00:00:00Z first line\r\n2021-01-01T00:00:01Z second line"
+
+	entries, err := readAllEntries(newLogStreamReader(strings.NewReader(stream), true))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []logEntry{
+		{stream: streamStdout, timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), body: "first line"},
+		{stream: streamStdout, timestamp: time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC), body: "second line"},
+	}, entries)
+}
+
+func TestLogStreamReaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  []byte
+		wantErr string
+	}{
+		{
+			name:    "unexpected stream type",
+			stream:  logFrame(0, "2021-01-01T00:00:00Z stdin\n"),
+			wantErr: "unexpected log stream type 0",
+		},
+		{
+			name:    "truncated header",
+			stream:  []byte{1, 0, 0},
+			wantErr: "truncated log frame header: unexpected EOF",
+		},
+		{
+			name:    "truncated payload",
+			stream:  logFrame(1, "2021-01-01T00:00:00Z line\n")[:12],
+			wantErr: "truncated log frame: unexpected EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := readAllEntries(newLogStreamReader(bytes.NewReader(tt.stream), false))
+			assert.Empty(t, entries)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestContainerLogs(t *testing.T) {
+	container := &DockerContainer{
+		ContainerJSON: &dtypes.ContainerJSON{
+			ContainerJSONBase: &dtypes.ContainerJSONBase{
+				ID:   "a1b2c3",
+				Name: "/my-container",
+			},
+			Config: &dcontainer.Config{
+				Hostname: "abcdef012345",
+				Image:    "myImage",
+				Labels: map[string]string{
+					"my.specified.docker.label": "my_specified_docker_label_value",
+				},
+			},
+		},
+		EnvMap: map[string]string{
+			"MY_ENV_VAR": "my_env_var_value",
+		},
+	}
+	config := &Config{
+		ContainerLabelsToMetricLabels: map[string]string{
+			"my.specified.docker.label": "my.label",
+		},
+		EnvVarsToMetricLabels: map[string]string{
+			"MY_ENV_VAR": "my.env.var",
+		},
+	}
+
+	ld := newContainerLogs(container, config)
+	timestamp := time.Date(2021, 1, 1, 0, 0, 0, 1, time.UTC)
+	appendLogEntry(ld, logEntry{stream: streamStderr, timestamp: timestamp, body: "an error"})
+	appendLogEntry(ld, logEntry{stream: streamStdout, timestamp: timestamp, body: "a line"})
+	require.Equal(t, 2, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"container.hostname":                pdata.NewAttributeValueString("abcdef012345"),
+		conventions.AttributeContainerID:    pdata.NewAttributeValueString("a1b2c3"),
+		conventions.AttributeContainerImage: pdata.NewAttributeValueString("myImage"),
+		conventions.AttributeContainerName:  pdata.NewAttributeValueString("my-container"),
+		"my.label":                          pdata.NewAttributeValueString("my_specified_docker_label_value"),
+		"my.env.var":                        pdata.NewAttributeValueString("my_env_var_value"),
+	}).Sort(), rl.Resource().Attributes().Sort())
+
+	record := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampUnixNano(timestamp.UnixNano()), record.Timestamp())
+	assert.Equal(t, "an error", record.Body().StringVal())
+	stream, ok := record.Attributes().Get(logStreamAttribute)
+	require.True(t, ok)
+	assert.Equal(t, streamStderr, stream.StringVal())
+}
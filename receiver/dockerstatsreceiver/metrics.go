@@ -62,33 +62,37 @@ func ContainerStatsToMetrics(
 	md := &consumerdata.MetricsData{
 		Metrics: metrics,
 		Resource: &resourcepb.Resource{
-			Type: "container",
-			Labels: map[string]string{
-				"container.hostname":                container.Config.Hostname,
-				conventions.AttributeContainerID:    container.ID,
-				conventions.AttributeContainerImage: container.Config.Image,
-				conventions.AttributeContainerName:  strings.TrimPrefix(container.Name, "/"),
-			},
+			Type:   "container",
+			Labels: containerResourceLabels(container, config),
 		},
 	}
 
-	updateConfiguredResourceLabels(md, container, config)
-
 	return md, nil
 }
 
-func updateConfiguredResourceLabels(md *consumerdata.MetricsData, container *DockerContainer, config *Config) {
+// containerResourceLabels returns the labels identifying the container, along with the
+// ones configured from its environment variables and labels.
+func containerResourceLabels(container *DockerContainer, config *Config) map[string]string {
+	labels := map[string]string{
+		"container.hostname":                container.Config.Hostname,
+		conventions.AttributeContainerID:    container.ID,
+		conventions.AttributeContainerImage: container.Config.Image,
+		conventions.AttributeContainerName:  strings.TrimPrefix(container.Name, "/"),
+	}
+
 	for k, label := range config.EnvVarsToMetricLabels {
 		if v := container.EnvMap[k]; v != "" {
-			md.Resource.Labels[label] = v
+			labels[label] = v
 		}
 	}
 
 	for k, label := range config.ContainerLabelsToMetricLabels {
 		if v := container.Config.Labels[k]; v != "" {
-			md.Resource.Labels[label] = v
+			labels[label] = v
 		}
 	}
+
+	return labels
 }
 
 type blkioStat struct {
@@ -0,0 +1,63 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcachedreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Len(t, cfg.Receivers, 2)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers["memcached"])
+
+	rcfg := cfg.Receivers["memcached/allsettings"].(*Config)
+	assert.Equal(t, "localhost:11212", rcfg.Endpoint)
+	assert.Equal(t, 30*time.Second, rcfg.CollectionInterval)
+	assert.Equal(t, 5*time.Second, rcfg.Timeout)
+
+	metrics := metadata.DefaultMetricsSettings()
+	metrics.MemcachedGetMisses.Enabled = false
+	metrics.MemcachedBytes.Name = "memcached.memory.usage"
+	assert.Equal(t, metrics, rcfg.Metrics)
+}
+
+func TestLoadConfigUnknownMetric(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factories.Receivers[configmodels.Type(typeStr)] = NewFactory()
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_unknown_metric.yaml"), factories)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memcached.unknown")
+}
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
 )
 
 const (
@@ -53,6 +55,7 @@ func createDefaultConfig() configmodels.Receiver {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: "localhost:11211",
 		},
+		Metrics: metadata.DefaultMetricsSettings(),
 	}
 }
 
@@ -44,11 +44,20 @@ func (m *metricImpl) New() pdata.Metric {
 }
 
 type metricStruct struct {
-	MemcachedBytes              metricIntf
-	MemcachedCurrentConnections metricIntf
-	MemcachedGetHits            metricIntf
-	MemcachedGetMisses          metricIntf
-	MemcachedTotalConnections   metricIntf
+	MemcachedBytes               metricIntf
+	MemcachedCurrentConnections  metricIntf
+	MemcachedGetHits             metricIntf
+	MemcachedGetMisses           metricIntf
+	MemcachedSlabChunkSize       metricIntf
+	MemcachedSlabChunksFree      metricIntf
+	MemcachedSlabChunksUsed      metricIntf
+	MemcachedSlabEvictions       metricIntf
+	MemcachedSlabItems           metricIntf
+	MemcachedSlabItemsAge        metricIntf
+	MemcachedSlabMemoryRequested metricIntf
+	MemcachedSlabOutOfMemory     metricIntf
+	MemcachedSlabPages           metricIntf
+	MemcachedTotalConnections    metricIntf
 }
 
 // Names returns a list of all the metric name strings.
@@ -58,16 +67,34 @@ func (m *metricStruct) Names() []string {
 		"memcached.current_connections",
 		"memcached.get_hits",
 		"memcached.get_misses",
+		"memcached.slab.chunk_size",
+		"memcached.slab.chunks.free",
+		"memcached.slab.chunks.used",
+		"memcached.slab.evictions",
+		"memcached.slab.items",
+		"memcached.slab.items.age",
+		"memcached.slab.memory.requested",
+		"memcached.slab.out_of_memory",
+		"memcached.slab.pages",
 		"memcached.total_connections",
 	}
 }
 
 var metricsByName = map[string]metricIntf{
-	"memcached.bytes":               Metrics.MemcachedBytes,
-	"memcached.current_connections": Metrics.MemcachedCurrentConnections,
-	"memcached.get_hits":            Metrics.MemcachedGetHits,
-	"memcached.get_misses":          Metrics.MemcachedGetMisses,
-	"memcached.total_connections":   Metrics.MemcachedTotalConnections,
+	"memcached.bytes":                 Metrics.MemcachedBytes,
+	"memcached.current_connections":   Metrics.MemcachedCurrentConnections,
+	"memcached.get_hits":              Metrics.MemcachedGetHits,
+	"memcached.get_misses":            Metrics.MemcachedGetMisses,
+	"memcached.slab.chunk_size":       Metrics.MemcachedSlabChunkSize,
+	"memcached.slab.chunks.free":      Metrics.MemcachedSlabChunksFree,
+	"memcached.slab.chunks.used":      Metrics.MemcachedSlabChunksUsed,
+	"memcached.slab.evictions":        Metrics.MemcachedSlabEvictions,
+	"memcached.slab.items":            Metrics.MemcachedSlabItems,
+	"memcached.slab.items.age":        Metrics.MemcachedSlabItemsAge,
+	"memcached.slab.memory.requested": Metrics.MemcachedSlabMemoryRequested,
+	"memcached.slab.out_of_memory":    Metrics.MemcachedSlabOutOfMemory,
+	"memcached.slab.pages":            Metrics.MemcachedSlabPages,
+	"memcached.total_connections":     Metrics.MemcachedTotalConnections,
 }
 
 func (m *metricStruct) ByName(n string) metricIntf {
@@ -76,11 +103,20 @@ func (m *metricStruct) ByName(n string) metricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 	return map[string]func() pdata.Metric{
-		Metrics.MemcachedBytes.Name():              Metrics.MemcachedBytes.New,
-		Metrics.MemcachedCurrentConnections.Name(): Metrics.MemcachedCurrentConnections.New,
-		Metrics.MemcachedGetHits.Name():            Metrics.MemcachedGetHits.New,
-		Metrics.MemcachedGetMisses.Name():          Metrics.MemcachedGetMisses.New,
-		Metrics.MemcachedTotalConnections.Name():   Metrics.MemcachedTotalConnections.New,
+		Metrics.MemcachedBytes.Name():               Metrics.MemcachedBytes.New,
+		Metrics.MemcachedCurrentConnections.Name():  Metrics.MemcachedCurrentConnections.New,
+		Metrics.MemcachedGetHits.Name():             Metrics.MemcachedGetHits.New,
+		Metrics.MemcachedGetMisses.Name():           Metrics.MemcachedGetMisses.New,
+		Metrics.MemcachedSlabChunkSize.Name():       Metrics.MemcachedSlabChunkSize.New,
+		Metrics.MemcachedSlabChunksFree.Name():      Metrics.MemcachedSlabChunksFree.New,
+		Metrics.MemcachedSlabChunksUsed.Name():      Metrics.MemcachedSlabChunksUsed.New,
+		Metrics.MemcachedSlabEvictions.Name():       Metrics.MemcachedSlabEvictions.New,
+		Metrics.MemcachedSlabItems.Name():           Metrics.MemcachedSlabItems.New,
+		Metrics.MemcachedSlabItemsAge.Name():        Metrics.MemcachedSlabItemsAge.New,
+		Metrics.MemcachedSlabMemoryRequested.Name(): Metrics.MemcachedSlabMemoryRequested.New,
+		Metrics.MemcachedSlabOutOfMemory.Name():     Metrics.MemcachedSlabOutOfMemory.New,
+		Metrics.MemcachedSlabPages.Name():           Metrics.MemcachedSlabPages.New,
+		Metrics.MemcachedTotalConnections.Name():    Metrics.MemcachedTotalConnections.New,
 	}
 }
 
@@ -139,6 +175,118 @@ var Metrics = &metricStruct{
 			return metric
 		},
 	},
+	&metricImpl{
+		"memcached.slab.chunk_size",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.chunk_size")
+			metric.SetDescription("The amount of space each chunk of the slab class uses")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.chunks.free",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.chunks.free")
+			metric.SetDescription("Number of chunks of the slab class not yet allocated to items, or freed via delete")
+			metric.SetUnit("chunks")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.chunks.used",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.chunks.used")
+			metric.SetDescription("Number of chunks of the slab class allocated to items")
+			metric.SetUnit("chunks")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.evictions",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.evictions")
+			metric.SetDescription("Number of times an item had to be evicted from the LRU of the slab class before it expired")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.items",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.items")
+			metric.SetDescription("Number of items presently stored in the slab class")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.items.age",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.items.age")
+			metric.SetDescription("Age of the oldest item in the slab class")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.memory.requested",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.memory.requested")
+			metric.SetDescription("Number of bytes requested to be stored in the slab class")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.out_of_memory",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.out_of_memory")
+			metric.SetDescription("Number of times the underlying slab class was unable to store a new item")
+			metric.SetUnit("errors")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"memcached.slab.pages",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("memcached.slab.pages")
+			metric.SetDescription("Number of pages allocated to the slab class")
+			metric.SetUnit("pages")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
 	&metricImpl{
 		"memcached.total_connections",
 		func() pdata.Metric {
@@ -159,9 +307,125 @@ var Metrics = &metricStruct{
 // manipulating those metrics. M is an alias for Metrics
 var M = Metrics
 
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	// Enabled determines whether the metric is emitted.
+	Enabled bool `mapstructure:"enabled"`
+	// Name, when set, replaces the name the metric is emitted with.
+	Name string `mapstructure:"name"`
+}
+
+// MetricsSettings provides settings for memcachedreceiver metrics.
+type MetricsSettings struct {
+	MemcachedBytes               MetricSettings `mapstructure:"memcached.bytes"`
+	MemcachedCurrentConnections  MetricSettings `mapstructure:"memcached.current_connections"`
+	MemcachedGetHits             MetricSettings `mapstructure:"memcached.get_hits"`
+	MemcachedGetMisses           MetricSettings `mapstructure:"memcached.get_misses"`
+	MemcachedSlabChunkSize       MetricSettings `mapstructure:"memcached.slab.chunk_size"`
+	MemcachedSlabChunksFree      MetricSettings `mapstructure:"memcached.slab.chunks.free"`
+	MemcachedSlabChunksUsed      MetricSettings `mapstructure:"memcached.slab.chunks.used"`
+	MemcachedSlabEvictions       MetricSettings `mapstructure:"memcached.slab.evictions"`
+	MemcachedSlabItems           MetricSettings `mapstructure:"memcached.slab.items"`
+	MemcachedSlabItemsAge        MetricSettings `mapstructure:"memcached.slab.items.age"`
+	MemcachedSlabMemoryRequested MetricSettings `mapstructure:"memcached.slab.memory.requested"`
+	MemcachedSlabOutOfMemory     MetricSettings `mapstructure:"memcached.slab.out_of_memory"`
+	MemcachedSlabPages           MetricSettings `mapstructure:"memcached.slab.pages"`
+	MemcachedTotalConnections    MetricSettings `mapstructure:"memcached.total_connections"`
+}
+
+// DefaultMetricsSettings returns the default settings, emitting all the metrics under their own names.
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		MemcachedBytes:               MetricSettings{Enabled: true},
+		MemcachedCurrentConnections:  MetricSettings{Enabled: true},
+		MemcachedGetHits:             MetricSettings{Enabled: true},
+		MemcachedGetMisses:           MetricSettings{Enabled: true},
+		MemcachedSlabChunkSize:       MetricSettings{Enabled: true},
+		MemcachedSlabChunksFree:      MetricSettings{Enabled: true},
+		MemcachedSlabChunksUsed:      MetricSettings{Enabled: true},
+		MemcachedSlabEvictions:       MetricSettings{Enabled: true},
+		MemcachedSlabItems:           MetricSettings{Enabled: true},
+		MemcachedSlabItemsAge:        MetricSettings{Enabled: true},
+		MemcachedSlabMemoryRequested: MetricSettings{Enabled: true},
+		MemcachedSlabOutOfMemory:     MetricSettings{Enabled: true},
+		MemcachedSlabPages:           MetricSettings{Enabled: true},
+		MemcachedTotalConnections:    MetricSettings{Enabled: true},
+	}
+}
+
+func (ms *MetricsSettings) byName(n string) (MetricSettings, bool) {
+	switch n {
+	case "memcached.bytes":
+		return ms.MemcachedBytes, true
+	case "memcached.current_connections":
+		return ms.MemcachedCurrentConnections, true
+	case "memcached.get_hits":
+		return ms.MemcachedGetHits, true
+	case "memcached.get_misses":
+		return ms.MemcachedGetMisses, true
+	case "memcached.slab.chunk_size":
+		return ms.MemcachedSlabChunkSize, true
+	case "memcached.slab.chunks.free":
+		return ms.MemcachedSlabChunksFree, true
+	case "memcached.slab.chunks.used":
+		return ms.MemcachedSlabChunksUsed, true
+	case "memcached.slab.evictions":
+		return ms.MemcachedSlabEvictions, true
+	case "memcached.slab.items":
+		return ms.MemcachedSlabItems, true
+	case "memcached.slab.items.age":
+		return ms.MemcachedSlabItemsAge, true
+	case "memcached.slab.memory.requested":
+		return ms.MemcachedSlabMemoryRequested, true
+	case "memcached.slab.out_of_memory":
+		return ms.MemcachedSlabOutOfMemory, true
+	case "memcached.slab.pages":
+		return ms.MemcachedSlabPages, true
+	case "memcached.total_connections":
+		return ms.MemcachedTotalConnections, true
+	}
+	return MetricSettings{}, false
+}
+
+// Enabled returns whether the metric with the given name is emitted. Unknown metrics are never emitted.
+func (ms *MetricsSettings) Enabled(n string) bool {
+	settings, ok := ms.byName(n)
+	return ok && settings.Enabled
+}
+
+// FactoriesByName returns the factories of the enabled metrics, keyed by the metric names
+// from metadata.yaml. The created metrics carry the configured name when one is set.
+func (ms *MetricsSettings) FactoriesByName() map[string]func() pdata.Metric {
+	factories := make(map[string]func() pdata.Metric)
+	for name, metric := range metricsByName {
+		settings, _ := ms.byName(name)
+		if !settings.Enabled {
+			continue
+		}
+		if settings.Name == "" {
+			factories[name] = metric.New
+			continue
+		}
+		factories[name] = renamed(metric.New, settings.Name)
+	}
+	return factories
+}
+
+func renamed(newFunc func() pdata.Metric, name string) func() pdata.Metric {
+	return func() pdata.Metric {
+		metric := newFunc()
+		metric.SetName(name)
+		return metric
+	}
+}
+
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
-}{}
+	// SlabID (The ID of the slab class.)
+	SlabID string
+}{
+	"slab_id",
+}
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
@@ -16,6 +16,7 @@ package memcachedreceiver
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/grobie/gomemcache/memcache"
@@ -62,7 +63,7 @@ func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice
 	metrics := simple.Metrics{
 		Metrics:                    pdata.NewMetrics(),
 		Timestamp:                  time.Now(),
-		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		MetricFactoriesByName:      r.config.Metrics.FactoriesByName(),
 		InstrumentationLibraryName: "otelcol/memcached",
 	}
 
@@ -76,18 +77,64 @@ func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice
 		for k, v := range stats.Stats {
 			switch k {
 			case "bytes":
-				metrics.AddGaugeDataPoint(metadata.M.MemcachedBytes.Name(), parseInt(v))
+				r.addGaugeDataPoint(&metrics, metadata.M.MemcachedBytes.Name(), parseInt(v))
 			case "curr_connections":
-				metrics.AddGaugeDataPoint(metadata.M.MemcachedCurrentConnections.Name(), parseInt(v))
+				r.addGaugeDataPoint(&metrics, metadata.M.MemcachedCurrentConnections.Name(), parseInt(v))
 			case "total_connections":
-				metrics.AddSumDataPoint(metadata.M.MemcachedTotalConnections.Name(), parseInt(v))
+				r.addSumDataPoint(&metrics, metadata.M.MemcachedTotalConnections.Name(), parseInt(v))
 			case "get_hits":
-				metrics.AddSumDataPoint(metadata.M.MemcachedGetHits.Name(), parseInt(v))
+				r.addSumDataPoint(&metrics, metadata.M.MemcachedGetHits.Name(), parseInt(v))
 			case "get_misses":
-				metrics.AddSumDataPoint(metadata.M.MemcachedGetMisses.Name(), parseInt(v))
+				r.addSumDataPoint(&metrics, metadata.M.MemcachedGetMisses.Name(), parseInt(v))
+			}
+		}
+
+		for id, slab := range stats.Slabs {
+			slabMetrics := metrics.WithLabels(map[string]string{metadata.L.SlabID: strconv.Itoa(id)})
+			for k, v := range slab {
+				switch k {
+				case "chunk_size":
+					r.addGaugeDataPoint(slabMetrics, metadata.M.MemcachedSlabChunkSize.Name(), parseInt(v))
+				case "used_chunks":
+					r.addGaugeDataPoint(slabMetrics, metadata.M.MemcachedSlabChunksUsed.Name(), parseInt(v))
+				case "free_chunks":
+					r.addGaugeDataPoint(slabMetrics, metadata.M.MemcachedSlabChunksFree.Name(), parseInt(v))
+				case "total_pages":
+					r.addGaugeDataPoint(slabMetrics, metadata.M.MemcachedSlabPages.Name(), parseInt(v))
+				case "mem_requested":
+					r.addGaugeDataPoint(slabMetrics, metadata.M.MemcachedSlabMemoryRequested.Name(), parseInt(v))
+				}
+			}
+		}
+
+		for id, items := range stats.Items {
+			itemMetrics := metrics.WithLabels(map[string]string{metadata.L.SlabID: strconv.Itoa(id)})
+			for k, v := range items {
+				switch k {
+				case "number":
+					r.addGaugeDataPoint(itemMetrics, metadata.M.MemcachedSlabItems.Name(), parseInt(v))
+				case "age":
+					r.addGaugeDataPoint(itemMetrics, metadata.M.MemcachedSlabItemsAge.Name(), parseInt(v))
+				case "evicted":
+					r.addSumDataPoint(itemMetrics, metadata.M.MemcachedSlabEvictions.Name(), parseInt(v))
+				case "outofmemory":
+					r.addSumDataPoint(itemMetrics, metadata.M.MemcachedSlabOutOfMemory.Name(), parseInt(v))
+				}
 			}
 		}
 	}
 
 	return metrics.Metrics.ResourceMetrics(), nil
 }
+
+func (r *memcachedScraper) addGaugeDataPoint(metrics *simple.Metrics, name string, value int64) {
+	if r.config.Metrics.Enabled(name) {
+		metrics.AddGaugeDataPoint(name, value)
+	}
+}
+
+func (r *memcachedScraper) addSumDataPoint(metrics *simple.Metrics, name string, value int64) {
+	if r.config.Metrics.Enabled(name) {
+		metrics.AddSumDataPoint(name, value)
+	}
+}
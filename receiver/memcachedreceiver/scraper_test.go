@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcachedreceiver
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
+)
+
+var fakeResponses = map[string]string{
+	"stats": `STAT pid 1
+STAT uptime 1234
+STAT curr_connections 10
+STAT total_connections 42
+STAT bytes 2048
+STAT get_hits 100
+STAT get_misses 7
+END
+`,
+	"stats slabs": `STAT 1:chunk_size 96
+STAT 1:chunks_per_page 10922
+STAT 1:total_pages 1
+STAT 1:total_chunks 10922
+STAT 1:used_chunks 3
+STAT 1:free_chunks 10919
+STAT 1:mem_requested 210
+STAT 5:chunk_size 240
+STAT 5:chunks_per_page 4369
+STAT 5:total_pages 2
+STAT 5:total_chunks 8738
+STAT 5:used_chunks 8000
+STAT 5:free_chunks 738
+STAT 5:mem_requested 1800000
+STAT active_slabs 2
+STAT total_malloced 3145728
+END
+`,
+	"stats items": `STAT items:1:number 3
+STAT items:1:age 120
+STAT items:1:evicted 0
+STAT items:1:outofmemory 0
+STAT items:5:number 8000
+STAT items:5:age 3600
+STAT items:5:evicted 512
+STAT items:5:outofmemory 4
+END
+`,
+}
+
+// fakeMemcached serves the stats commands over the memcached text protocol.
+func fakeMemcached(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					resp, ok := fakeResponses[strings.TrimSpace(line)]
+					if !ok {
+						resp = "ERROR\n"
+					}
+					_, _ = conn.Write([]byte(strings.ReplaceAll(resp, "\n", "\r\n")))
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestScraper(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = fakeMemcached(t)
+	cfg.Timeout = time.Second
+
+	ms := &memcachedScraper{logger: zap.NewNop(), config: cfg}
+	rms, err := ms.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, len(metadata.Metrics.Names()), metrics.Len())
+
+	values := metricValues(metrics)
+	require.Equal(t, map[string]int64{"": 2048}, values["memcached.bytes"])
+	require.Equal(t, map[string]int64{"": 10}, values["memcached.current_connections"])
+	require.Equal(t, map[string]int64{"": 42}, values["memcached.total_connections"])
+	require.Equal(t, map[string]int64{"": 100}, values["memcached.get_hits"])
+	require.Equal(t, map[string]int64{"": 7}, values["memcached.get_misses"])
+	require.Equal(t, map[string]int64{"1": 96, "5": 240}, values["memcached.slab.chunk_size"])
+	require.Equal(t, map[string]int64{"1": 3, "5": 8000}, values["memcached.slab.chunks.used"])
+	require.Equal(t, map[string]int64{"1": 10919, "5": 738}, values["memcached.slab.chunks.free"])
+	require.Equal(t, map[string]int64{"1": 1, "5": 2}, values["memcached.slab.pages"])
+	require.Equal(t, map[string]int64{"1": 210, "5": 1800000}, values["memcached.slab.memory.requested"])
+	require.Equal(t, map[string]int64{"1": 3, "5": 8000}, values["memcached.slab.items"])
+	require.Equal(t, map[string]int64{"1": 120, "5": 3600}, values["memcached.slab.items.age"])
+	require.Equal(t, map[string]int64{"1": 0, "5": 512}, values["memcached.slab.evictions"])
+	require.Equal(t, map[string]int64{"1": 0, "5": 4}, values["memcached.slab.out_of_memory"])
+}
+
+func TestScraperDisabledSlabMetrics(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = fakeMemcached(t)
+	cfg.Timeout = time.Second
+	cfg.Metrics.MemcachedSlabChunkSize.Enabled = false
+	cfg.Metrics.MemcachedSlabItemsAge.Enabled = false
+
+	ms := &memcachedScraper{logger: zap.NewNop(), config: cfg}
+	rms, err := ms.scrape(context.Background())
+	require.NoError(t, err)
+
+	values := metricValues(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics())
+	require.Len(t, values, len(metadata.Metrics.Names())-2)
+	require.NotContains(t, values, "memcached.slab.chunk_size")
+	require.NotContains(t, values, "memcached.slab.items.age")
+}
+
+// metricValues returns the data point values of each metric keyed by their slab ID.
+func metricValues(metrics pdata.MetricSlice) map[string]map[string]int64 {
+	values := make(map[string]map[string]int64, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			dps = m.IntSum().DataPoints()
+		}
+
+		values[m.Name()] = make(map[string]int64, dps.Len())
+		for j := 0; j < dps.Len(); j++ {
+			slabID, _ := dps.At(j).LabelsMap().Get(metadata.L.SlabID)
+			values[m.Name()][slabID] = dps.At(j).Value()
+		}
+	}
+	return values
+}
@@ -17,9 +17,27 @@ package nginxreceiver
 import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
+)
+
+const (
+	// modeStubStatus scrapes the page exposed by ngx_http_stub_status_module.
+	modeStubStatus = "stub_status"
+	// modePlus scrapes the NGINX Plus REST API.
+	modePlus = "plus"
+	// modeVTS scrapes the JSON status page of the nginx-module-vts module.
+	modeVTS = "vts"
 )
 
 type config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// Mode selects how nginx is scraped, one of "stub_status", "plus" or "vts".
+	// Defaults to "stub_status".
+	Mode string `mapstructure:"mode"`
+
+	// Metrics allows to disable or rename the emitted metrics.
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
 }
@@ -18,6 +18,7 @@ package nginxreceiver
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -26,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
 )
 
 const (
@@ -53,6 +56,8 @@ func createDefaultConfig() configmodels.Receiver {
 			Endpoint: "http://localhost:80/status",
 			Timeout:  10 * time.Second,
 		},
+		Mode:    modeStubStatus,
+		Metrics: metadata.DefaultMetricsSettings(),
 	}
 }
 
@@ -64,6 +69,12 @@ func createMetricsReceiver(
 ) (component.MetricsReceiver, error) {
 	cfg := rConf.(*config)
 
+	switch cfg.Mode {
+	case "", modeStubStatus, modePlus, modeVTS:
+	default:
+		return nil, fmt.Errorf("unknown mode %q, must be one of %q, %q or %q", cfg.Mode, modeStubStatus, modePlus, modeVTS)
+	}
+
 	ns := newNginxScraper(params.Logger, cfg)
 	scraper := scraperhelper.NewResourceMetricsScraper(typeStr, ns.scrape)
 
@@ -54,3 +54,16 @@ func TestCreateMetricsReceiver(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, metricsReceiver)
 }
+
+func TestCreateMetricsReceiverUnknownMode(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*config)
+	cfg.Mode = "status"
+	_, err := factory.CreateMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		cfg,
+		&testbed.MockMetricConsumer{},
+	)
+	require.EqualError(t, err, `unknown mode "status", must be one of "stub_status", "plus" or "vts"`)
+}
@@ -44,18 +44,32 @@ func (m *metricImpl) New() pdata.Metric {
 }
 
 type metricStruct struct {
-	NginxConnectionsAccepted metricIntf
-	NginxConnectionsActive   metricIntf
-	NginxConnectionsHandled  metricIntf
-	NginxConnectionsReading  metricIntf
-	NginxConnectionsWaiting  metricIntf
-	NginxConnectionsWriting  metricIntf
-	NginxRequests            metricIntf
+	NginxCacheResponses                metricIntf
+	NginxCacheSize                     metricIntf
+	NginxConnectionsAccepted           metricIntf
+	NginxConnectionsActive             metricIntf
+	NginxConnectionsHandled            metricIntf
+	NginxConnectionsReading            metricIntf
+	NginxConnectionsWaiting            metricIntf
+	NginxConnectionsWriting            metricIntf
+	NginxRequests                      metricIntf
+	NginxServerZoneReceived            metricIntf
+	NginxServerZoneRequests            metricIntf
+	NginxServerZoneResponses           metricIntf
+	NginxServerZoneSent                metricIntf
+	NginxUpstreamPeerConnectionsActive metricIntf
+	NginxUpstreamPeerFails             metricIntf
+	NginxUpstreamPeerHealth            metricIntf
+	NginxUpstreamPeerRequests          metricIntf
+	NginxUpstreamPeerResponseTime      metricIntf
+	NginxUpstreamPeerResponses         metricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"nginx.cache.responses",
+		"nginx.cache.size",
 		"nginx.connections_accepted",
 		"nginx.connections_active",
 		"nginx.connections_handled",
@@ -63,17 +77,39 @@ func (m *metricStruct) Names() []string {
 		"nginx.connections_waiting",
 		"nginx.connections_writing",
 		"nginx.requests",
+		"nginx.server_zone.received",
+		"nginx.server_zone.requests",
+		"nginx.server_zone.responses",
+		"nginx.server_zone.sent",
+		"nginx.upstream.peer.connections_active",
+		"nginx.upstream.peer.fails",
+		"nginx.upstream.peer.health",
+		"nginx.upstream.peer.requests",
+		"nginx.upstream.peer.response_time",
+		"nginx.upstream.peer.responses",
 	}
 }
 
 var metricsByName = map[string]metricIntf{
-	"nginx.connections_accepted": Metrics.NginxConnectionsAccepted,
-	"nginx.connections_active":   Metrics.NginxConnectionsActive,
-	"nginx.connections_handled":  Metrics.NginxConnectionsHandled,
-	"nginx.connections_reading":  Metrics.NginxConnectionsReading,
-	"nginx.connections_waiting":  Metrics.NginxConnectionsWaiting,
-	"nginx.connections_writing":  Metrics.NginxConnectionsWriting,
-	"nginx.requests":             Metrics.NginxRequests,
+	"nginx.cache.responses":                  Metrics.NginxCacheResponses,
+	"nginx.cache.size":                       Metrics.NginxCacheSize,
+	"nginx.connections_accepted":             Metrics.NginxConnectionsAccepted,
+	"nginx.connections_active":               Metrics.NginxConnectionsActive,
+	"nginx.connections_handled":              Metrics.NginxConnectionsHandled,
+	"nginx.connections_reading":              Metrics.NginxConnectionsReading,
+	"nginx.connections_waiting":              Metrics.NginxConnectionsWaiting,
+	"nginx.connections_writing":              Metrics.NginxConnectionsWriting,
+	"nginx.requests":                         Metrics.NginxRequests,
+	"nginx.server_zone.received":             Metrics.NginxServerZoneReceived,
+	"nginx.server_zone.requests":             Metrics.NginxServerZoneRequests,
+	"nginx.server_zone.responses":            Metrics.NginxServerZoneResponses,
+	"nginx.server_zone.sent":                 Metrics.NginxServerZoneSent,
+	"nginx.upstream.peer.connections_active": Metrics.NginxUpstreamPeerConnectionsActive,
+	"nginx.upstream.peer.fails":              Metrics.NginxUpstreamPeerFails,
+	"nginx.upstream.peer.health":             Metrics.NginxUpstreamPeerHealth,
+	"nginx.upstream.peer.requests":           Metrics.NginxUpstreamPeerRequests,
+	"nginx.upstream.peer.response_time":      Metrics.NginxUpstreamPeerResponseTime,
+	"nginx.upstream.peer.responses":          Metrics.NginxUpstreamPeerResponses,
 }
 
 func (m *metricStruct) ByName(n string) metricIntf {
@@ -82,19 +118,57 @@ func (m *metricStruct) ByName(n string) metricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 	return map[string]func() pdata.Metric{
-		Metrics.NginxConnectionsAccepted.Name(): Metrics.NginxConnectionsAccepted.New,
-		Metrics.NginxConnectionsActive.Name():   Metrics.NginxConnectionsActive.New,
-		Metrics.NginxConnectionsHandled.Name():  Metrics.NginxConnectionsHandled.New,
-		Metrics.NginxConnectionsReading.Name():  Metrics.NginxConnectionsReading.New,
-		Metrics.NginxConnectionsWaiting.Name():  Metrics.NginxConnectionsWaiting.New,
-		Metrics.NginxConnectionsWriting.Name():  Metrics.NginxConnectionsWriting.New,
-		Metrics.NginxRequests.Name():            Metrics.NginxRequests.New,
+		Metrics.NginxCacheResponses.Name():                Metrics.NginxCacheResponses.New,
+		Metrics.NginxCacheSize.Name():                     Metrics.NginxCacheSize.New,
+		Metrics.NginxConnectionsAccepted.Name():           Metrics.NginxConnectionsAccepted.New,
+		Metrics.NginxConnectionsActive.Name():             Metrics.NginxConnectionsActive.New,
+		Metrics.NginxConnectionsHandled.Name():            Metrics.NginxConnectionsHandled.New,
+		Metrics.NginxConnectionsReading.Name():            Metrics.NginxConnectionsReading.New,
+		Metrics.NginxConnectionsWaiting.Name():            Metrics.NginxConnectionsWaiting.New,
+		Metrics.NginxConnectionsWriting.Name():            Metrics.NginxConnectionsWriting.New,
+		Metrics.NginxRequests.Name():                      Metrics.NginxRequests.New,
+		Metrics.NginxServerZoneReceived.Name():            Metrics.NginxServerZoneReceived.New,
+		Metrics.NginxServerZoneRequests.Name():            Metrics.NginxServerZoneRequests.New,
+		Metrics.NginxServerZoneResponses.Name():           Metrics.NginxServerZoneResponses.New,
+		Metrics.NginxServerZoneSent.Name():                Metrics.NginxServerZoneSent.New,
+		Metrics.NginxUpstreamPeerConnectionsActive.Name(): Metrics.NginxUpstreamPeerConnectionsActive.New,
+		Metrics.NginxUpstreamPeerFails.Name():             Metrics.NginxUpstreamPeerFails.New,
+		Metrics.NginxUpstreamPeerHealth.Name():            Metrics.NginxUpstreamPeerHealth.New,
+		Metrics.NginxUpstreamPeerRequests.Name():          Metrics.NginxUpstreamPeerRequests.New,
+		Metrics.NginxUpstreamPeerResponseTime.Name():      Metrics.NginxUpstreamPeerResponseTime.New,
+		Metrics.NginxUpstreamPeerResponses.Name():         Metrics.NginxUpstreamPeerResponses.New,
 	}
 }
 
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"nginx.cache.responses",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.cache.responses")
+			metric.SetDescription("The total number of responses read from or written to the cache, by cache status. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.cache.size",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.cache.size")
+			metric.SetDescription("The current size of the cache. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
 	&metricImpl{
 		"nginx.connections_accepted",
 		func() pdata.Metric {
@@ -182,6 +256,140 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetIsMonotonic(true)
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.received",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.server_zone.received")
+			metric.SetDescription("The total number of bytes received from clients by the server zone. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.requests",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.server_zone.requests")
+			metric.SetDescription("The total number of client requests received from clients by the server zone. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.responses",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.server_zone.responses")
+			metric.SetDescription("The total number of responses sent to clients by the server zone, by status class. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.sent",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.server_zone.sent")
+			metric.SetDescription("The total number of bytes sent to clients by the server zone. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.connections_active",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.connections_active")
+			metric.SetDescription("The current number of active connections to the upstream server. Only available with the NGINX Plus API.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.fails",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.fails")
+			metric.SetDescription("The total number of unsuccessful attempts to communicate with the upstream server. Only available with the NGINX Plus API.")
+			metric.SetUnit("attempts")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.health",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.health")
+			metric.SetDescription("Whether the upstream server is up (1) or not (0). Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.requests",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.requests")
+			metric.SetDescription("The total number of client requests forwarded to the upstream server. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.response_time",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.response_time")
+			metric.SetDescription("The average time to receive the last byte of data from the upstream server. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+			return metric
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.responses",
+		func() pdata.Metric {
+			metric := pdata.NewMetric()
+			metric.SetName("nginx.upstream.peer.responses")
+			metric.SetDescription("The total number of responses obtained from the upstream server, by status class. Only available with the NGINX Plus API and VTS.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
 			return metric
 		},
 	},
@@ -191,9 +399,160 @@ var Metrics = &metricStruct{
 // manipulating those metrics. M is an alias for Metrics
 var M = Metrics
 
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	// Enabled determines whether the metric is emitted.
+	Enabled bool `mapstructure:"enabled"`
+	// Name, when set, replaces the name the metric is emitted with.
+	Name string `mapstructure:"name"`
+}
+
+// MetricsSettings provides settings for nginxreceiver metrics.
+type MetricsSettings struct {
+	NginxCacheResponses                MetricSettings `mapstructure:"nginx.cache.responses"`
+	NginxCacheSize                     MetricSettings `mapstructure:"nginx.cache.size"`
+	NginxConnectionsAccepted           MetricSettings `mapstructure:"nginx.connections_accepted"`
+	NginxConnectionsActive             MetricSettings `mapstructure:"nginx.connections_active"`
+	NginxConnectionsHandled            MetricSettings `mapstructure:"nginx.connections_handled"`
+	NginxConnectionsReading            MetricSettings `mapstructure:"nginx.connections_reading"`
+	NginxConnectionsWaiting            MetricSettings `mapstructure:"nginx.connections_waiting"`
+	NginxConnectionsWriting            MetricSettings `mapstructure:"nginx.connections_writing"`
+	NginxRequests                      MetricSettings `mapstructure:"nginx.requests"`
+	NginxServerZoneReceived            MetricSettings `mapstructure:"nginx.server_zone.received"`
+	NginxServerZoneRequests            MetricSettings `mapstructure:"nginx.server_zone.requests"`
+	NginxServerZoneResponses           MetricSettings `mapstructure:"nginx.server_zone.responses"`
+	NginxServerZoneSent                MetricSettings `mapstructure:"nginx.server_zone.sent"`
+	NginxUpstreamPeerConnectionsActive MetricSettings `mapstructure:"nginx.upstream.peer.connections_active"`
+	NginxUpstreamPeerFails             MetricSettings `mapstructure:"nginx.upstream.peer.fails"`
+	NginxUpstreamPeerHealth            MetricSettings `mapstructure:"nginx.upstream.peer.health"`
+	NginxUpstreamPeerRequests          MetricSettings `mapstructure:"nginx.upstream.peer.requests"`
+	NginxUpstreamPeerResponseTime      MetricSettings `mapstructure:"nginx.upstream.peer.response_time"`
+	NginxUpstreamPeerResponses         MetricSettings `mapstructure:"nginx.upstream.peer.responses"`
+}
+
+// DefaultMetricsSettings returns the default settings, emitting all the metrics under their own names.
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		NginxCacheResponses:                MetricSettings{Enabled: true},
+		NginxCacheSize:                     MetricSettings{Enabled: true},
+		NginxConnectionsAccepted:           MetricSettings{Enabled: true},
+		NginxConnectionsActive:             MetricSettings{Enabled: true},
+		NginxConnectionsHandled:            MetricSettings{Enabled: true},
+		NginxConnectionsReading:            MetricSettings{Enabled: true},
+		NginxConnectionsWaiting:            MetricSettings{Enabled: true},
+		NginxConnectionsWriting:            MetricSettings{Enabled: true},
+		NginxRequests:                      MetricSettings{Enabled: true},
+		NginxServerZoneReceived:            MetricSettings{Enabled: true},
+		NginxServerZoneRequests:            MetricSettings{Enabled: true},
+		NginxServerZoneResponses:           MetricSettings{Enabled: true},
+		NginxServerZoneSent:                MetricSettings{Enabled: true},
+		NginxUpstreamPeerConnectionsActive: MetricSettings{Enabled: true},
+		NginxUpstreamPeerFails:             MetricSettings{Enabled: true},
+		NginxUpstreamPeerHealth:            MetricSettings{Enabled: true},
+		NginxUpstreamPeerRequests:          MetricSettings{Enabled: true},
+		NginxUpstreamPeerResponseTime:      MetricSettings{Enabled: true},
+		NginxUpstreamPeerResponses:         MetricSettings{Enabled: true},
+	}
+}
+
+func (ms *MetricsSettings) byName(n string) (MetricSettings, bool) {
+	switch n {
+	case "nginx.cache.responses":
+		return ms.NginxCacheResponses, true
+	case "nginx.cache.size":
+		return ms.NginxCacheSize, true
+	case "nginx.connections_accepted":
+		return ms.NginxConnectionsAccepted, true
+	case "nginx.connections_active":
+		return ms.NginxConnectionsActive, true
+	case "nginx.connections_handled":
+		return ms.NginxConnectionsHandled, true
+	case "nginx.connections_reading":
+		return ms.NginxConnectionsReading, true
+	case "nginx.connections_waiting":
+		return ms.NginxConnectionsWaiting, true
+	case "nginx.connections_writing":
+		return ms.NginxConnectionsWriting, true
+	case "nginx.requests":
+		return ms.NginxRequests, true
+	case "nginx.server_zone.received":
+		return ms.NginxServerZoneReceived, true
+	case "nginx.server_zone.requests":
+		return ms.NginxServerZoneRequests, true
+	case "nginx.server_zone.responses":
+		return ms.NginxServerZoneResponses, true
+	case "nginx.server_zone.sent":
+		return ms.NginxServerZoneSent, true
+	case "nginx.upstream.peer.connections_active":
+		return ms.NginxUpstreamPeerConnectionsActive, true
+	case "nginx.upstream.peer.fails":
+		return ms.NginxUpstreamPeerFails, true
+	case "nginx.upstream.peer.health":
+		return ms.NginxUpstreamPeerHealth, true
+	case "nginx.upstream.peer.requests":
+		return ms.NginxUpstreamPeerRequests, true
+	case "nginx.upstream.peer.response_time":
+		return ms.NginxUpstreamPeerResponseTime, true
+	case "nginx.upstream.peer.responses":
+		return ms.NginxUpstreamPeerResponses, true
+	}
+	return MetricSettings{}, false
+}
+
+// Enabled returns whether the metric with the given name is emitted. Unknown metrics are never emitted.
+func (ms *MetricsSettings) Enabled(n string) bool {
+	settings, ok := ms.byName(n)
+	return ok && settings.Enabled
+}
+
+// FactoriesByName returns the factories of the enabled metrics, keyed by the metric names
+// from metadata.yaml. The created metrics carry the configured name when one is set.
+func (ms *MetricsSettings) FactoriesByName() map[string]func() pdata.Metric {
+	factories := make(map[string]func() pdata.Metric)
+	for name, metric := range metricsByName {
+		settings, _ := ms.byName(name)
+		if !settings.Enabled {
+			continue
+		}
+		if settings.Name == "" {
+			factories[name] = metric.New
+			continue
+		}
+		factories[name] = renamed(metric.New, settings.Name)
+	}
+	return factories
+}
+
+func renamed(newFunc func() pdata.Metric, name string) func() pdata.Metric {
+	return func() pdata.Metric {
+		metric := newFunc()
+		metric.SetName(name)
+		return metric
+	}
+}
+
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
-}{}
+	// Cache (The name of the cache zone.)
+	Cache string
+	// CacheStatus (The cache status of the responses (hit, miss, stale, updating, revalidated, expired, bypass or scarce).)
+	CacheStatus string
+	// Peer (The address of the upstream server.)
+	Peer string
+	// ServerZone (The name of the server zone.)
+	ServerZone string
+	// StatusClass (The class of the response status code (1xx, 2xx, 3xx, 4xx or 5xx).)
+	StatusClass string
+	// Upstream (The name of the upstream group.)
+	Upstream string
+}{
+	"cache",
+	"cache_status",
+	"peer",
+	"server_zone",
+	"status_class",
+	"upstream",
+}
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/simple"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
+)
+
+// plusConnections is the response of the /connections endpoint of the NGINX Plus API.
+type plusConnections struct {
+	Accepted int64 `json:"accepted"`
+	Dropped  int64 `json:"dropped"`
+	Active   int64 `json:"active"`
+	Idle     int64 `json:"idle"`
+}
+
+// plusRequests is the response of the /http/requests endpoint of the NGINX Plus API.
+type plusRequests struct {
+	Total   int64 `json:"total"`
+	Current int64 `json:"current"`
+}
+
+// plusServerZone is an entry of the /http/server_zones endpoint of the NGINX Plus API.
+type plusServerZone struct {
+	Requests  int64           `json:"requests"`
+	Responses statusResponses `json:"responses"`
+	Received  int64           `json:"received"`
+	Sent      int64           `json:"sent"`
+}
+
+// plusUpstream is an entry of the /http/upstreams endpoint of the NGINX Plus API.
+type plusUpstream struct {
+	Peers []plusPeer `json:"peers"`
+}
+
+type plusPeer struct {
+	Server       string          `json:"server"`
+	State        string          `json:"state"`
+	Active       int64           `json:"active"`
+	Requests     int64           `json:"requests"`
+	Responses    statusResponses `json:"responses"`
+	Fails        int64           `json:"fails"`
+	ResponseTime int64           `json:"response_time"`
+}
+
+// plusCache is an entry of the /http/caches endpoint of the NGINX Plus API.
+type plusCache struct {
+	Size        int64              `json:"size"`
+	Hit         plusCacheResponses `json:"hit"`
+	Stale       plusCacheResponses `json:"stale"`
+	Updating    plusCacheResponses `json:"updating"`
+	Revalidated plusCacheResponses `json:"revalidated"`
+	Miss        plusCacheResponses `json:"miss"`
+	Expired     plusCacheResponses `json:"expired"`
+	Bypass      plusCacheResponses `json:"bypass"`
+}
+
+type plusCacheResponses struct {
+	Responses int64 `json:"responses"`
+}
+
+// scrapePlus collects the metrics from the NGINX Plus API, the endpoint must
+// include the API version, e.g. http://localhost:8080/api/6.
+func (r *nginxScraper) scrapePlus(ctx context.Context, metrics *simple.Metrics) error {
+	endpoint := strings.TrimSuffix(r.config.HTTPClientSettings.Endpoint, "/")
+
+	var connections plusConnections
+	if err := r.getJSON(ctx, endpoint+"/connections", &connections); err != nil {
+		return err
+	}
+	var requests plusRequests
+	if err := r.getJSON(ctx, endpoint+"/http/requests", &requests); err != nil {
+		return err
+	}
+	var serverZones map[string]plusServerZone
+	if err := r.getJSON(ctx, endpoint+"/http/server_zones", &serverZones); err != nil {
+		return err
+	}
+	var upstreams map[string]plusUpstream
+	if err := r.getJSON(ctx, endpoint+"/http/upstreams", &upstreams); err != nil {
+		return err
+	}
+	var caches map[string]plusCache
+	if err := r.getJSON(ctx, endpoint+"/http/caches", &caches); err != nil {
+		return err
+	}
+
+	r.addSumDataPoint(metrics, metadata.M.NginxRequests.Name(), requests.Total)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsActive.Name(), connections.Active)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsAccepted.Name(), connections.Accepted)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsHandled.Name(), connections.Accepted-connections.Dropped)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsWaiting.Name(), connections.Idle)
+
+	zoneNames := make([]string, 0, len(serverZones))
+	for name := range serverZones {
+		zoneNames = append(zoneNames, name)
+	}
+	sort.Strings(zoneNames)
+	for _, name := range zoneNames {
+		zone := serverZones[name]
+		zoneMetrics := metrics.WithLabels(map[string]string{metadata.L.ServerZone: name})
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneRequests.Name(), zone.Requests)
+		r.addStatusResponses(zoneMetrics, metadata.M.NginxServerZoneResponses.Name(), zone.Responses)
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneReceived.Name(), zone.Received)
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneSent.Name(), zone.Sent)
+	}
+
+	upstreamNames := make([]string, 0, len(upstreams))
+	for name := range upstreams {
+		upstreamNames = append(upstreamNames, name)
+	}
+	sort.Strings(upstreamNames)
+	for _, name := range upstreamNames {
+		for _, peer := range upstreams[name].Peers {
+			peerMetrics := metrics.WithLabels(map[string]string{
+				metadata.L.Upstream: name,
+				metadata.L.Peer:     peer.Server,
+			})
+			var health int64
+			if peer.State == "up" {
+				health = 1
+			}
+			r.addSumDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerRequests.Name(), peer.Requests)
+			r.addStatusResponses(peerMetrics, metadata.M.NginxUpstreamPeerResponses.Name(), peer.Responses)
+			r.addGaugeDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerHealth.Name(), health)
+			r.addGaugeDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerResponseTime.Name(), peer.ResponseTime)
+			r.addGaugeDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerConnectionsActive.Name(), peer.Active)
+			r.addSumDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerFails.Name(), peer.Fails)
+		}
+	}
+
+	cacheNames := make([]string, 0, len(caches))
+	for name := range caches {
+		cacheNames = append(cacheNames, name)
+	}
+	sort.Strings(cacheNames)
+	for _, name := range cacheNames {
+		cache := caches[name]
+		cacheMetrics := metrics.WithLabels(map[string]string{metadata.L.Cache: name})
+		r.addGaugeDataPoint(cacheMetrics, metadata.M.NginxCacheSize.Name(), cache.Size)
+		r.addCacheResponses(cacheMetrics, map[string]int64{
+			"hit":         cache.Hit.Responses,
+			"stale":       cache.Stale.Responses,
+			"updating":    cache.Updating.Responses,
+			"revalidated": cache.Revalidated.Responses,
+			"miss":        cache.Miss.Responses,
+			"expired":     cache.Expired.Responses,
+			"bypass":      cache.Bypass.Responses,
+		})
+	}
+	return nil
+}
+
+// addCacheResponses records the cache responses of each cache status, in a
+// stable order.
+func (r *nginxScraper) addCacheResponses(metrics *simple.Metrics, responses map[string]int64) {
+	statuses := make([]string, 0, len(responses))
+	for status := range responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		r.addSumDataPoint(metrics.WithLabels(map[string]string{metadata.L.CacheStatus: status}),
+			metadata.M.NginxCacheResponses.Name(), responses[status])
+	}
+}
@@ -16,6 +16,9 @@ package nginxreceiver
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/nginxinc/nginx-prometheus-exporter/client"
@@ -27,7 +30,8 @@ import (
 )
 
 type nginxScraper struct {
-	client *client.NginxClient
+	httpClient *http.Client
+	client     *client.NginxClient
 
 	logger *zap.Logger
 	config *config
@@ -46,39 +50,119 @@ func newNginxScraper(
 func (r *nginxScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
-	if r.client == nil {
+	if r.httpClient == nil {
 		httpClient, err := r.config.ToClient()
 		if err != nil {
 			return pdata.ResourceMetricsSlice{}, err
 		}
-
-		r.client, err = client.NewNginxClient(httpClient, r.config.HTTPClientSettings.Endpoint)
-		if err != nil {
-			r.client = nil
-			return pdata.ResourceMetricsSlice{}, err
-		}
+		r.httpClient = httpClient
 	}
 
 	metrics := simple.Metrics{
 		Metrics:                    pdata.NewMetrics(),
 		Timestamp:                  time.Now(),
-		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		MetricFactoriesByName:      r.config.Metrics.FactoriesByName(),
 		InstrumentationLibraryName: "otelcol/nginx",
 	}
 
-	stats, err := r.client.GetStubStats()
+	var err error
+	switch r.config.Mode {
+	case modePlus:
+		err = r.scrapePlus(ctx, &metrics)
+	case modeVTS:
+		err = r.scrapeVTS(ctx, &metrics)
+	default:
+		err = r.scrapeStubStatus(&metrics)
+	}
 	if err != nil {
 		r.logger.Error("Failed to fetch nginx stats", zap.Error(err))
 		return pdata.ResourceMetricsSlice{}, err
 	}
 
-	metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), stats.Requests)
-	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsActive.Name(), stats.Connections.Active)
-	metrics.AddSumDataPoint(metadata.M.NginxConnectionsAccepted.Name(), stats.Connections.Accepted)
-	metrics.AddSumDataPoint(metadata.M.NginxConnectionsHandled.Name(), stats.Connections.Handled)
-	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsReading.Name(), stats.Connections.Reading)
-	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsWriting.Name(), stats.Connections.Writing)
-	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsWaiting.Name(), stats.Connections.Waiting)
-
 	return metrics.Metrics.ResourceMetrics(), nil
 }
+
+func (r *nginxScraper) scrapeStubStatus(metrics *simple.Metrics) error {
+	if r.client == nil {
+		var err error
+		r.client, err = client.NewNginxClient(r.httpClient, r.config.HTTPClientSettings.Endpoint)
+		if err != nil {
+			r.client = nil
+			return err
+		}
+	}
+
+	stats, err := r.client.GetStubStats()
+	if err != nil {
+		return err
+	}
+
+	r.addSumDataPoint(metrics, metadata.M.NginxRequests.Name(), stats.Requests)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsActive.Name(), stats.Connections.Active)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsAccepted.Name(), stats.Connections.Accepted)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsHandled.Name(), stats.Connections.Handled)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsReading.Name(), stats.Connections.Reading)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsWriting.Name(), stats.Connections.Writing)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsWaiting.Name(), stats.Connections.Waiting)
+	return nil
+}
+
+// getJSON fetches url and decodes its JSON body into v.
+func (r *nginxScraper) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected %v response, got %v", http.StatusOK, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response of %v: %w", url, err)
+	}
+	return nil
+}
+
+// statusResponses holds the response counters by status class, as reported
+// by both the NGINX Plus API and VTS.
+type statusResponses struct {
+	Class1xx int64 `json:"1xx"`
+	Class2xx int64 `json:"2xx"`
+	Class3xx int64 `json:"3xx"`
+	Class4xx int64 `json:"4xx"`
+	Class5xx int64 `json:"5xx"`
+}
+
+func (r *nginxScraper) addStatusResponses(metrics *simple.Metrics, name string, responses statusResponses) {
+	for _, class := range []struct {
+		name  string
+		value int64
+	}{
+		{"1xx", responses.Class1xx},
+		{"2xx", responses.Class2xx},
+		{"3xx", responses.Class3xx},
+		{"4xx", responses.Class4xx},
+		{"5xx", responses.Class5xx},
+	} {
+		r.addSumDataPoint(metrics.WithLabels(map[string]string{metadata.L.StatusClass: class.name}), name, class.value)
+	}
+}
+
+func (r *nginxScraper) addGaugeDataPoint(metrics *simple.Metrics, name string, value int64) {
+	if r.config.Metrics.Enabled(name) {
+		metrics.AddGaugeDataPoint(name, value)
+	}
+}
+
+func (r *nginxScraper) addSumDataPoint(metrics *simple.Metrics, name string, value int64) {
+	if r.config.Metrics.Enabled(name) {
+		metrics.AddSumDataPoint(name, value)
+	}
+}
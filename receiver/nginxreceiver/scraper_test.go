@@ -19,12 +19,16 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
 )
 
 func TestScraper(t *testing.T) {
@@ -44,6 +48,7 @@ Reading: 6 Writing: 179 Waiting: 106
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: nginxMock.URL + "/status",
 		},
+		Metrics: metadata.DefaultMetricsSettings(),
 	})
 	rms, err := sc.scrape(context.Background())
 	require.Nil(t, err)
@@ -119,3 +124,307 @@ func TestScraperError(t *testing.T) {
 		require.Equal(t, errors.New("failed to parse response body \"Bad status page\": invalid input \"Bad status page\""), err)
 	})
 }
+
+func TestScraperMetricsSettings(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		_, _ = rw.Write([]byte(`Active connections: 291
+server accepts handled requests
+ 16630948 16630948 31070465
+Reading: 6 Writing: 179 Waiting: 106
+`))
+	}))
+	metricsSettings := metadata.DefaultMetricsSettings()
+	metricsSettings.NginxConnectionsReading.Enabled = false
+	metricsSettings.NginxConnectionsWriting.Enabled = false
+	metricsSettings.NginxConnectionsWaiting.Enabled = false
+	metricsSettings.NginxRequests.Name = "nginx.requests.total"
+	sc := newNginxScraper(zap.NewNop(), &config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/status",
+		},
+		Metrics: metricsSettings,
+	})
+	rms, err := sc.scrape(context.Background())
+	require.Nil(t, err)
+
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	names := make([]string, 0, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		names = append(names, ms.At(i).Name())
+	}
+	require.ElementsMatch(t, []string{
+		"nginx.requests.total",
+		"nginx.connections_active",
+		"nginx.connections_accepted",
+		"nginx.connections_handled",
+	}, names)
+}
+
+// dataPoints returns the data point values of each metric keyed by their sorted labels.
+func dataPoints(ms pdata.MetricSlice) map[string]map[string]int64 {
+	values := make(map[string]map[string]int64, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			dps = m.IntSum().DataPoints()
+		}
+
+		values[m.Name()] = make(map[string]int64, dps.Len())
+		for j := 0; j < dps.Len(); j++ {
+			var labels []string
+			dps.At(j).LabelsMap().ForEach(func(k, v string) {
+				labels = append(labels, k+"="+v)
+			})
+			sort.Strings(labels)
+			values[m.Name()][strings.Join(labels, ",")] = dps.At(j).Value()
+		}
+	}
+	return values
+}
+
+func TestScraperPlus(t *testing.T) {
+	responses := map[string]string{
+		"/api/6/connections":   `{"accepted": 100, "dropped": 2, "active": 5, "idle": 3}`,
+		"/api/6/http/requests": `{"total": 250, "current": 4}`,
+		"/api/6/http/server_zones": `{"www": {"processing": 1, "requests": 200, "discarded": 0, "received": 5000, "sent": 90000,
+			"responses": {"1xx": 0, "2xx": 180, "3xx": 5, "4xx": 10, "5xx": 5, "total": 200}}}`,
+		"/api/6/http/upstreams": `{"backend": {"zone": "backend", "keepalive": 0, "peers": [
+			{"id": 0, "server": "10.0.0.1:80", "name": "10.0.0.1:80", "backup": false, "weight": 1, "state": "up",
+				"active": 2, "requests": 120, "responses": {"1xx": 0, "2xx": 110, "3xx": 0, "4xx": 6, "5xx": 4, "total": 120},
+				"sent": 100, "received": 1000, "fails": 1, "unavail": 0, "response_time": 12},
+			{"id": 1, "server": "10.0.0.2:80", "name": "10.0.0.2:80", "backup": false, "weight": 1, "state": "unhealthy",
+				"active": 0, "requests": 80, "responses": {"1xx": 0, "2xx": 70, "3xx": 0, "4xx": 4, "5xx": 6, "total": 80},
+				"sent": 100, "received": 1000, "fails": 7, "unavail": 1, "response_time": 40}]}}`,
+		"/api/6/http/caches": `{"static": {"size": 4096, "max_size": 1048576, "cold": false,
+			"hit": {"responses": 30, "bytes": 300}, "stale": {"responses": 1, "bytes": 10},
+			"updating": {"responses": 0, "bytes": 0}, "revalidated": {"responses": 2, "bytes": 20},
+			"miss": {"responses": 10, "bytes": 100}, "expired": {"responses": 3, "bytes": 30},
+			"bypass": {"responses": 4, "bytes": 40}}}`,
+	}
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if resp, ok := responses[req.URL.Path]; ok {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(resp))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/api/6/",
+		},
+		Mode:    modePlus,
+		Metrics: metadata.DefaultMetricsSettings(),
+	})
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+
+	values := dataPoints(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics())
+	require.Equal(t, map[string]map[string]int64{
+		"nginx.requests":             {"": 250},
+		"nginx.connections_active":   {"": 5},
+		"nginx.connections_accepted": {"": 100},
+		"nginx.connections_handled":  {"": 98},
+		"nginx.connections_waiting":  {"": 3},
+		"nginx.server_zone.requests": {"server_zone=www": 200},
+		"nginx.server_zone.responses": {
+			"server_zone=www,status_class=1xx": 0,
+			"server_zone=www,status_class=2xx": 180,
+			"server_zone=www,status_class=3xx": 5,
+			"server_zone=www,status_class=4xx": 10,
+			"server_zone=www,status_class=5xx": 5,
+		},
+		"nginx.server_zone.received": {"server_zone=www": 5000},
+		"nginx.server_zone.sent":     {"server_zone=www": 90000},
+		"nginx.upstream.peer.requests": {
+			"peer=10.0.0.1:80,upstream=backend": 120,
+			"peer=10.0.0.2:80,upstream=backend": 80,
+		},
+		"nginx.upstream.peer.responses": {
+			"peer=10.0.0.1:80,status_class=1xx,upstream=backend": 0,
+			"peer=10.0.0.1:80,status_class=2xx,upstream=backend": 110,
+			"peer=10.0.0.1:80,status_class=3xx,upstream=backend": 0,
+			"peer=10.0.0.1:80,status_class=4xx,upstream=backend": 6,
+			"peer=10.0.0.1:80,status_class=5xx,upstream=backend": 4,
+			"peer=10.0.0.2:80,status_class=1xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=2xx,upstream=backend": 70,
+			"peer=10.0.0.2:80,status_class=3xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=4xx,upstream=backend": 4,
+			"peer=10.0.0.2:80,status_class=5xx,upstream=backend": 6,
+		},
+		"nginx.upstream.peer.health": {
+			"peer=10.0.0.1:80,upstream=backend": 1,
+			"peer=10.0.0.2:80,upstream=backend": 0,
+		},
+		"nginx.upstream.peer.response_time": {
+			"peer=10.0.0.1:80,upstream=backend": 12,
+			"peer=10.0.0.2:80,upstream=backend": 40,
+		},
+		"nginx.upstream.peer.connections_active": {
+			"peer=10.0.0.1:80,upstream=backend": 2,
+			"peer=10.0.0.2:80,upstream=backend": 0,
+		},
+		"nginx.upstream.peer.fails": {
+			"peer=10.0.0.1:80,upstream=backend": 1,
+			"peer=10.0.0.2:80,upstream=backend": 7,
+		},
+		"nginx.cache.size": {"cache=static": 4096},
+		"nginx.cache.responses": {
+			"cache=static,cache_status=bypass":      4,
+			"cache=static,cache_status=expired":     3,
+			"cache=static,cache_status=hit":         30,
+			"cache=static,cache_status=miss":        10,
+			"cache=static,cache_status=revalidated": 2,
+			"cache=static,cache_status=stale":       1,
+			"cache=static,cache_status=updating":    0,
+		},
+	}, values)
+}
+
+func TestScraperVTS(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status/format/json" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(`{
+	"hostName": "edge-1",
+	"connections": {"active": 4, "reading": 1, "writing": 2, "waiting": 1, "accepted": 50, "handled": 50, "requests": 300},
+	"serverZones": {
+		"www": {"requestCounter": 300, "inBytes": 6000, "outBytes": 120000,
+			"responses": {"1xx": 0, "2xx": 280, "3xx": 10, "4xx": 8, "5xx": 2, "miss": 5, "bypass": 0, "expired": 0, "stale": 0, "updating": 0, "revalidated": 0, "hit": 20, "scarce": 0}},
+		"*": {"requestCounter": 300, "inBytes": 6000, "outBytes": 120000,
+			"responses": {"1xx": 0, "2xx": 280, "3xx": 10, "4xx": 8, "5xx": 2}}
+	},
+	"upstreamZones": {
+		"backend": [
+			{"server": "10.0.0.1:80", "requestCounter": 150, "inBytes": 100, "outBytes": 1000,
+				"responses": {"1xx": 0, "2xx": 145, "3xx": 0, "4xx": 3, "5xx": 2},
+				"responseMsec": 15, "weight": 1, "maxFails": 1, "failTimeout": 10, "backup": false, "down": false},
+			{"server": "10.0.0.2:80", "requestCounter": 0, "inBytes": 0, "outBytes": 0,
+				"responses": {"1xx": 0, "2xx": 0, "3xx": 0, "4xx": 0, "5xx": 0},
+				"responseMsec": 0, "weight": 1, "maxFails": 1, "failTimeout": 10, "backup": false, "down": true}
+		]
+	},
+	"cacheZones": {
+		"static": {"maxSize": 1048576, "usedSize": 2048, "inBytes": 10, "outBytes": 100,
+			"responses": {"miss": 5, "bypass": 0, "expired": 1, "stale": 0, "updating": 0, "revalidated": 0, "hit": 20, "scarce": 0}}
+	}
+}`))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/status/format/json",
+		},
+		Mode:    modeVTS,
+		Metrics: metadata.DefaultMetricsSettings(),
+	})
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+
+	values := dataPoints(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics())
+	require.Equal(t, map[string]map[string]int64{
+		"nginx.requests":             {"": 300},
+		"nginx.connections_active":   {"": 4},
+		"nginx.connections_accepted": {"": 50},
+		"nginx.connections_handled":  {"": 50},
+		"nginx.connections_reading":  {"": 1},
+		"nginx.connections_writing":  {"": 2},
+		"nginx.connections_waiting":  {"": 1},
+		"nginx.server_zone.requests": {"server_zone=www": 300},
+		"nginx.server_zone.responses": {
+			"server_zone=www,status_class=1xx": 0,
+			"server_zone=www,status_class=2xx": 280,
+			"server_zone=www,status_class=3xx": 10,
+			"server_zone=www,status_class=4xx": 8,
+			"server_zone=www,status_class=5xx": 2,
+		},
+		"nginx.server_zone.received": {"server_zone=www": 6000},
+		"nginx.server_zone.sent":     {"server_zone=www": 120000},
+		"nginx.upstream.peer.requests": {
+			"peer=10.0.0.1:80,upstream=backend": 150,
+			"peer=10.0.0.2:80,upstream=backend": 0,
+		},
+		"nginx.upstream.peer.responses": {
+			"peer=10.0.0.1:80,status_class=1xx,upstream=backend": 0,
+			"peer=10.0.0.1:80,status_class=2xx,upstream=backend": 145,
+			"peer=10.0.0.1:80,status_class=3xx,upstream=backend": 0,
+			"peer=10.0.0.1:80,status_class=4xx,upstream=backend": 3,
+			"peer=10.0.0.1:80,status_class=5xx,upstream=backend": 2,
+			"peer=10.0.0.2:80,status_class=1xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=2xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=3xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=4xx,upstream=backend": 0,
+			"peer=10.0.0.2:80,status_class=5xx,upstream=backend": 0,
+		},
+		"nginx.upstream.peer.health": {
+			"peer=10.0.0.1:80,upstream=backend": 1,
+			"peer=10.0.0.2:80,upstream=backend": 0,
+		},
+		"nginx.upstream.peer.response_time": {
+			"peer=10.0.0.1:80,upstream=backend": 15,
+			"peer=10.0.0.2:80,upstream=backend": 0,
+		},
+		"nginx.cache.size": {"cache=static": 2048},
+		"nginx.cache.responses": {
+			"cache=static,cache_status=bypass":      0,
+			"cache=static,cache_status=expired":     1,
+			"cache=static,cache_status=hit":         20,
+			"cache=static,cache_status=miss":        5,
+			"cache=static,cache_status=revalidated": 0,
+			"cache=static,cache_status=scarce":      0,
+			"cache=static,cache_status=stale":       0,
+			"cache=static,cache_status=updating":    0,
+		},
+	}, values)
+}
+
+func TestScraperPlusError(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/6/connections" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(`not json`))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	t.Run("404", func(t *testing.T) {
+		sc := newNginxScraper(zap.NewNop(), &config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nginxMock.URL + "/api/5",
+			},
+			Mode: modePlus,
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response, got 404")
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		sc := newNginxScraper(zap.NewNop(), &config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nginxMock.URL + "/api/6",
+			},
+			Mode: modePlus,
+		})
+		_, err := sc.scrape(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decode response of "+nginxMock.URL+"/api/6/connections")
+	})
+}
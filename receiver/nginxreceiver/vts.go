@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/collector/consumer/simple"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
+)
+
+// vtsAllServerZones is the name of the server zone that VTS uses to report
+// the sum of all the other zones.
+const vtsAllServerZones = "*"
+
+// vtsStatus is the JSON status page of the nginx-module-vts module.
+type vtsStatus struct {
+	Connections struct {
+		Active   int64 `json:"active"`
+		Reading  int64 `json:"reading"`
+		Writing  int64 `json:"writing"`
+		Waiting  int64 `json:"waiting"`
+		Accepted int64 `json:"accepted"`
+		Handled  int64 `json:"handled"`
+		Requests int64 `json:"requests"`
+	} `json:"connections"`
+	ServerZones   map[string]vtsServerZone     `json:"serverZones"`
+	UpstreamZones map[string][]vtsUpstreamPeer `json:"upstreamZones"`
+	CacheZones    map[string]vtsCacheZone      `json:"cacheZones"`
+}
+
+type vtsServerZone struct {
+	RequestCounter int64           `json:"requestCounter"`
+	InBytes        int64           `json:"inBytes"`
+	OutBytes       int64           `json:"outBytes"`
+	Responses      statusResponses `json:"responses"`
+}
+
+type vtsUpstreamPeer struct {
+	Server         string          `json:"server"`
+	RequestCounter int64           `json:"requestCounter"`
+	Responses      statusResponses `json:"responses"`
+	ResponseMsec   int64           `json:"responseMsec"`
+	Down           bool            `json:"down"`
+}
+
+type vtsCacheZone struct {
+	UsedSize  int64 `json:"usedSize"`
+	Responses struct {
+		Miss        int64 `json:"miss"`
+		Bypass      int64 `json:"bypass"`
+		Expired     int64 `json:"expired"`
+		Stale       int64 `json:"stale"`
+		Updating    int64 `json:"updating"`
+		Revalidated int64 `json:"revalidated"`
+		Hit         int64 `json:"hit"`
+		Scarce      int64 `json:"scarce"`
+	} `json:"responses"`
+}
+
+// scrapeVTS collects the metrics from the JSON status page of VTS, the endpoint
+// must point to it, e.g. http://localhost/status/format/json.
+func (r *nginxScraper) scrapeVTS(ctx context.Context, metrics *simple.Metrics) error {
+	var status vtsStatus
+	if err := r.getJSON(ctx, r.config.HTTPClientSettings.Endpoint, &status); err != nil {
+		return err
+	}
+
+	r.addSumDataPoint(metrics, metadata.M.NginxRequests.Name(), status.Connections.Requests)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsActive.Name(), status.Connections.Active)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsAccepted.Name(), status.Connections.Accepted)
+	r.addSumDataPoint(metrics, metadata.M.NginxConnectionsHandled.Name(), status.Connections.Handled)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsReading.Name(), status.Connections.Reading)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsWriting.Name(), status.Connections.Writing)
+	r.addGaugeDataPoint(metrics, metadata.M.NginxConnectionsWaiting.Name(), status.Connections.Waiting)
+
+	zoneNames := make([]string, 0, len(status.ServerZones))
+	for name := range status.ServerZones {
+		// The aggregated zone would double count the requests of the other zones.
+		if name != vtsAllServerZones {
+			zoneNames = append(zoneNames, name)
+		}
+	}
+	sort.Strings(zoneNames)
+	for _, name := range zoneNames {
+		zone := status.ServerZones[name]
+		zoneMetrics := metrics.WithLabels(map[string]string{metadata.L.ServerZone: name})
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneRequests.Name(), zone.RequestCounter)
+		r.addStatusResponses(zoneMetrics, metadata.M.NginxServerZoneResponses.Name(), zone.Responses)
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneReceived.Name(), zone.InBytes)
+		r.addSumDataPoint(zoneMetrics, metadata.M.NginxServerZoneSent.Name(), zone.OutBytes)
+	}
+
+	upstreamNames := make([]string, 0, len(status.UpstreamZones))
+	for name := range status.UpstreamZones {
+		upstreamNames = append(upstreamNames, name)
+	}
+	sort.Strings(upstreamNames)
+	for _, name := range upstreamNames {
+		for _, peer := range status.UpstreamZones[name] {
+			peerMetrics := metrics.WithLabels(map[string]string{
+				metadata.L.Upstream: name,
+				metadata.L.Peer:     peer.Server,
+			})
+			var health int64
+			if !peer.Down {
+				health = 1
+			}
+			r.addSumDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerRequests.Name(), peer.RequestCounter)
+			r.addStatusResponses(peerMetrics, metadata.M.NginxUpstreamPeerResponses.Name(), peer.Responses)
+			r.addGaugeDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerHealth.Name(), health)
+			r.addGaugeDataPoint(peerMetrics, metadata.M.NginxUpstreamPeerResponseTime.Name(), peer.ResponseMsec)
+		}
+	}
+
+	cacheNames := make([]string, 0, len(status.CacheZones))
+	for name := range status.CacheZones {
+		cacheNames = append(cacheNames, name)
+	}
+	sort.Strings(cacheNames)
+	for _, name := range cacheNames {
+		cache := status.CacheZones[name]
+		cacheMetrics := metrics.WithLabels(map[string]string{metadata.L.Cache: name})
+		r.addGaugeDataPoint(cacheMetrics, metadata.M.NginxCacheSize.Name(), cache.UsedSize)
+		r.addCacheResponses(cacheMetrics, map[string]int64{
+			"hit":         cache.Responses.Hit,
+			"stale":       cache.Responses.Stale,
+			"updating":    cache.Responses.Updating,
+			"revalidated": cache.Responses.Revalidated,
+			"miss":        cache.Responses.Miss,
+			"expired":     cache.Responses.Expired,
+			"bypass":      cache.Responses.Bypass,
+			"scarce":      cache.Responses.Scarce,
+		})
+	}
+	return nil
+}
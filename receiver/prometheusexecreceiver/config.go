@@ -32,4 +32,22 @@ type Config struct {
 	Port int `mapstructure:"port"`
 	// SubprocessConfig is the configuration needed for the subprocess
 	SubprocessConfig subprocessmanager.SubprocessConfig `mapstructure:",squash"`
+	// Subprocesses is a list of additional subprocesses managed by the Receiver, each scraped with its own settings
+	Subprocesses []SubprocessTarget `mapstructure:"subprocesses"`
+}
+
+// SubprocessTarget is the definition of a subprocess and the settings used to scrape it
+type SubprocessTarget struct {
+	// Name identifies the subprocess in logs and metrics, and is used as its Prometheus job name
+	Name string `mapstructure:"name"`
+	// ScrapeInterval is the time between each scrape of the subprocess, defaults to the Receiver's scrape_interval
+	ScrapeInterval time.Duration `mapstructure:"scrape_interval"`
+	// MetricsPath is the HTTP path to scrape metrics from, defaults to /metrics
+	MetricsPath string `mapstructure:"metrics_path"`
+	// Port is the port assigned to the subprocess, and to the {{port}} template variables
+	Port int `mapstructure:"port"`
+	// Labels are added to all the metrics scraped from the subprocess
+	Labels map[string]string `mapstructure:"labels"`
+	// SubprocessConfig is the configuration needed for the subprocess
+	SubprocessConfig subprocessmanager.SubprocessConfig `mapstructure:",squash"`
 }
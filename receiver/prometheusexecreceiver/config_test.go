@@ -86,6 +86,44 @@ var (
 			Env:     []subprocessmanager.EnvConfig{},
 		},
 	}
+
+	wantReceiver6 = &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: configmodels.Type("prometheus_exec"),
+			NameVal: "prometheus_exec/multiple",
+		},
+		ScrapeInterval: 30 * time.Second,
+		SubprocessConfig: subprocessmanager.SubprocessConfig{
+			Env: []subprocessmanager.EnvConfig{},
+		},
+		Subprocesses: []SubprocessTarget{
+			{
+				Name: "mysql",
+				Labels: map[string]string{
+					"database": "mysql",
+				},
+				SubprocessConfig: subprocessmanager.SubprocessConfig{
+					Command: "mysqld_exporter --web.listen-address=:{{port}}",
+				},
+			},
+			{
+				Name:           "postgres",
+				Port:           9187,
+				ScrapeInterval: 15 * time.Second,
+				MetricsPath:    "/probe",
+				SubprocessConfig: subprocessmanager.SubprocessConfig{
+					Command: "postgres_exporter",
+					Env: []subprocessmanager.EnvConfig{
+						{
+							Name:  "DATA_SOURCE_NAME",
+							Value: "postgresql://localhost:5432/postgres",
+						},
+					},
+					ShutdownTimeout: 2 * time.Second,
+				},
+			},
+		},
+	}
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -101,7 +139,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, config)
 
-	assert.Equal(t, len(config.Receivers), 5)
+	assert.Equal(t, len(config.Receivers), 6)
 
 	receiver1 := config.Receivers[receiverType]
 	assert.Equal(t, factory.CreateDefaultConfig(), receiver1)
@@ -117,4 +155,7 @@ func TestLoadConfig(t *testing.T) {
 
 	receiver5 := config.Receivers["prometheus_exec/end_to_end_test/2"]
 	assert.Equal(t, wantReceiver5, receiver5)
+
+	receiver6 := config.Receivers["prometheus_exec/multiple"]
+	assert.Equal(t, wantReceiver6, receiver6)
 }
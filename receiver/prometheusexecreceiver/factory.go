@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
@@ -36,6 +37,8 @@ const (
 
 // NewFactory creates a factory for the prometheusexec receiver
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(MetricViews()...)
+
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -74,26 +74,47 @@ func TestCreateTraceAndMetricsReceiver(t *testing.T) {
 		params:   component.ReceiverCreateParams{Logger: zap.NewNop()},
 		config:   receiver.(*Config),
 		consumer: nil,
-		promReceiverConfig: &prometheusreceiver.Config{
-			ReceiverSettings: configmodels.ReceiverSettings{
-				TypeVal: "prometheus_exec",
-				NameVal: "prometheus_exec/test",
-			},
-			PrometheusConfig: &promconfig.Config{
-				ScrapeConfigs: []*promconfig.ScrapeConfig{
-					{
-						ScrapeInterval:  model.Duration(60 * time.Second),
-						ScrapeTimeout:   model.Duration(10 * time.Second),
-						Scheme:          "http",
-						MetricsPath:     "/metrics",
-						JobName:         "test",
-						HonorLabels:     false,
-						HonorTimestamps: true,
-						ServiceDiscoveryConfigs: discovery.Configs{
-							&discovery.StaticConfig{
-								{
-									Targets: []model.LabelSet{
-										{model.AddressLabel: model.LabelValue("localhost:9104")},
+	}
+	wantTarget := &SubprocessTarget{
+		Name:           "test",
+		ScrapeInterval: 60 * time.Second,
+		MetricsPath:    "/metrics",
+		Port:           9104,
+		SubprocessConfig: subprocessmanager.SubprocessConfig{
+			Command: "mysqld_exporter",
+			Env:     []subprocessmanager.EnvConfig{},
+		},
+	}
+	wantPorts := newPortAllocator()
+	wantPorts.reserve(9104)
+	wantPer.subprocesses = []*subprocessReceiver{
+		{
+			params:       component.ReceiverCreateParams{Logger: zap.NewNop()},
+			receiverName: "prometheus_exec/test",
+			consumer:     nil,
+			target:       wantTarget,
+			ports:        wantPorts,
+			promReceiverConfig: &prometheusreceiver.Config{
+				ReceiverSettings: configmodels.ReceiverSettings{
+					TypeVal: "prometheus_exec",
+					NameVal: "prometheus_exec/test",
+				},
+				PrometheusConfig: &promconfig.Config{
+					ScrapeConfigs: []*promconfig.ScrapeConfig{
+						{
+							ScrapeInterval:  model.Duration(60 * time.Second),
+							ScrapeTimeout:   model.Duration(10 * time.Second),
+							Scheme:          "http",
+							MetricsPath:     "/metrics",
+							JobName:         "test",
+							HonorLabels:     false,
+							HonorTimestamps: true,
+							ServiceDiscoveryConfigs: discovery.Configs{
+								&discovery.StaticConfig{
+									{
+										Targets: []model.LabelSet{
+											{model.AddressLabel: model.LabelValue("localhost:9104")},
+										},
 									},
 								},
 							},
@@ -101,13 +122,12 @@ func TestCreateTraceAndMetricsReceiver(t *testing.T) {
 					},
 				},
 			},
+			subprocessConfig: &subprocessmanager.SubprocessConfig{
+				Command: "mysqld_exporter",
+				Env:     []subprocessmanager.EnvConfig{},
+			},
+			port: 9104,
 		},
-		subprocessConfig: &subprocessmanager.SubprocessConfig{
-			Command: "mysqld_exporter",
-			Env:     []subprocessmanager.EnvConfig{},
-		},
-		port:               9104,
-		prometheusReceiver: nil,
 	}
 
 	assert.Equal(t, wantPer, metricReceiver)
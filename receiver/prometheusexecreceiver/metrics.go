@@ -0,0 +1,73 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexecreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagReceiverKey   = tag.MustNewKey("receiver")
+	tagSubprocessKey = tag.MustNewKey("subprocess")
+
+	mRestartDelay = stats.Int64("prometheus_exec_subprocess_restart_delay", "Delay before the last restart of a subprocess", stats.UnitMilliseconds)
+	mCrashCount   = stats.Int64("prometheus_exec_subprocess_crash_count", "Number of times a subprocess exited without staying alive long enough to be considered healthy", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views of the restarts of the subprocesses.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagReceiverKey, tagSubprocessKey}
+	return []*view.View{
+		{
+			Name:        "prometheus_exec_subprocess_restarts",
+			Measure:     mRestartDelay,
+			Description: "Number of times a subprocess was restarted",
+			TagKeys:     tagKeys,
+			Aggregation: view.Count(),
+		},
+		{
+			Name:        mRestartDelay.Name(),
+			Measure:     mRestartDelay,
+			Description: mRestartDelay.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mCrashCount.Name(),
+			Measure:     mCrashCount,
+			Description: mCrashCount.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+	}
+}
+
+// recordRestart records the backoff state of a subprocess about to be restarted.
+func recordRestart(receiverName string, subprocessName string, crashCount int, delay time.Duration) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{
+			tag.Upsert(tagReceiverKey, receiverName),
+			tag.Upsert(tagSubprocessKey, subprocessName),
+		},
+		mRestartDelay.M(delay.Milliseconds()),
+		mCrashCount.M(int64(crashCount)),
+	)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexecreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"prometheus_exec_subprocess_restarts",
+		"prometheus_exec_subprocess_restart_delay",
+		"prometheus_exec_subprocess_crash_count",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+func TestRecordRestart(t *testing.T) {
+	// The views are registered by NewFactory
+	NewFactory()
+
+	recordRestart("prometheus_exec/test_metrics", "mysql", 4, 2*time.Second)
+
+	wantTags := []tag.Tag{
+		{Key: tagReceiverKey, Value: "prometheus_exec/test_metrics"},
+		{Key: tagSubprocessKey, Value: "mysql"},
+	}
+
+	rows, err := view.RetrieveData("prometheus_exec_subprocess_restart_delay")
+	require.NoError(t, err)
+	row := findRow(rows, wantTags)
+	require.NotNil(t, row)
+	assert.Equal(t, 2000.0, row.Data.(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("prometheus_exec_subprocess_crash_count")
+	require.NoError(t, err)
+	row = findRow(rows, wantTags)
+	require.NotNil(t, row)
+	assert.Equal(t, 4.0, row.Data.(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("prometheus_exec_subprocess_restarts")
+	require.NoError(t, err)
+	row = findRow(rows, wantTags)
+	require.NotNil(t, row)
+	assert.Equal(t, int64(1), row.Data.(*view.CountData).Value)
+}
+
+func findRow(rows []*view.Row, tags []tag.Tag) *view.Row {
+	for _, row := range rows {
+		if assert.ObjectsAreEqual(tags, row.Tags) {
+			return row
+		}
+	}
+	return nil
+}
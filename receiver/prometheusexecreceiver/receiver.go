@@ -16,12 +16,14 @@ package prometheusexecreceiver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/common/model"
@@ -51,13 +53,36 @@ const (
 	defaultMetricsPath = "/metrics"
 	// defaul timeout for a scrape
 	defaultScrapeTimeout = 10 * time.Second
+	// maxPortAttempts is the number of random ports tried before giving up on finding a free one
+	maxPortAttempts = 10
 )
 
+// prometheusExecReceiver manages the subprocesses of a prometheus_exec receiver
 type prometheusExecReceiver struct {
 	params   component.ReceiverCreateParams
 	config   *Config
 	consumer consumer.MetricsConsumer
 
+	// Subprocesses and the Prometheus receivers scraping them
+	subprocesses []*subprocessReceiver
+
+	// Wait group of the goroutines managing the subprocesses
+	wg sync.WaitGroup
+	// Shutdown channel
+	shutdownCh chan struct{}
+}
+
+// subprocessReceiver handles starting and restarting a single subprocess and its Prometheus receiver
+type subprocessReceiver struct {
+	params       component.ReceiverCreateParams
+	receiverName string
+	consumer     consumer.MetricsConsumer
+
+	// target is the subprocess definition, before filling the port placeholders
+	target *SubprocessTarget
+	// ports hands out the free ports when the target doesn't define one
+	ports *portAllocator
+
 	// Prometheus receiver config
 	promReceiverConfig *prometheusreceiver.Config
 
@@ -65,10 +90,7 @@ type prometheusExecReceiver struct {
 	subprocessConfig *subprocessmanager.SubprocessConfig
 	port             int
 
-	// Underlying receiver data
-	prometheusReceiver component.MetricsReceiver
-
-	// Shutdown channel
+	// Shutdown channel, shared by all the subprocesses of the receiver
 	shutdownCh chan struct{}
 }
 
@@ -79,48 +101,116 @@ type runResult struct {
 
 // newPromExecReceiver returns a prometheusExecReceiver
 func newPromExecReceiver(params component.ReceiverCreateParams, config *Config, consumer consumer.MetricsConsumer) (*prometheusExecReceiver, error) {
-	if config.SubprocessConfig.Command == "" {
+	targets := getSubprocessTargets(config)
+	if len(targets) == 0 {
 		return nil, fmt.Errorf("no command to execute entered in config file for %v", config.Name())
 	}
-	subprocessConfig := getSubprocessConfig(config)
-	promReceiverConfig := getPromReceiverConfig(config)
+
+	ports := newPortAllocator()
+	names := make(map[string]struct{}, len(targets))
+	subprocesses := make([]*subprocessReceiver, 0, len(targets))
+	for i := range targets {
+		target := &targets[i]
+		if target.SubprocessConfig.Command == "" {
+			return nil, fmt.Errorf("no command to execute entered for subprocess %q of %v", target.Name, config.Name())
+		}
+		if target.Name == "" {
+			return nil, fmt.Errorf("subprocess of %v has no name", config.Name())
+		}
+		if _, ok := names[target.Name]; ok {
+			return nil, fmt.Errorf("duplicate subprocess name %q in %v", target.Name, config.Name())
+		}
+		names[target.Name] = struct{}{}
+		if target.Port != 0 && !ports.reserve(target.Port) {
+			return nil, fmt.Errorf("port %d of subprocess %q is used by another subprocess of %v", target.Port, target.Name, config.Name())
+		}
+
+		subprocesses = append(subprocesses, &subprocessReceiver{
+			params:             params,
+			receiverName:       config.Name(),
+			consumer:           consumer,
+			target:             target,
+			ports:              ports,
+			subprocessConfig:   getSubprocessConfig(target),
+			promReceiverConfig: getPromReceiverConfig(config.Name(), target),
+			port:               target.Port,
+		})
+	}
 
 	return &prometheusExecReceiver{
-		params:             params,
-		config:             config,
-		consumer:           consumer,
-		subprocessConfig:   subprocessConfig,
-		promReceiverConfig: promReceiverConfig,
-		port:               config.Port,
+		params:       params,
+		config:       config,
+		consumer:     consumer,
+		subprocesses: subprocesses,
 	}, nil
 }
 
+// getSubprocessTargets returns the subprocesses to run: the one defined at the top level of the config, if any, and
+// the ones in the subprocesses list, with their default values set
+func getSubprocessTargets(cfg *Config) []SubprocessTarget {
+	targets := make([]SubprocessTarget, 0, len(cfg.Subprocesses)+1)
+	if cfg.SubprocessConfig.Command != "" {
+		targets = append(targets, getTopLevelTarget(cfg))
+	}
+
+	for _, target := range cfg.Subprocesses {
+		if target.ScrapeInterval == 0 {
+			target.ScrapeInterval = cfg.ScrapeInterval
+		}
+		if target.MetricsPath == "" {
+			target.MetricsPath = defaultMetricsPath
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// getTopLevelTarget returns the subprocess defined at the top level of the config, named after the receiver
+func getTopLevelTarget(cfg *Config) SubprocessTarget {
+	return SubprocessTarget{
+		Name:             extractName(cfg),
+		ScrapeInterval:   cfg.ScrapeInterval,
+		MetricsPath:      defaultMetricsPath,
+		Port:             cfg.Port,
+		SubprocessConfig: cfg.SubprocessConfig,
+	}
+}
+
 // getPromReceiverConfig returns the Prometheus receiver config
-func getPromReceiverConfig(cfg *Config) *prometheusreceiver.Config {
+func getPromReceiverConfig(receiverName string, target *SubprocessTarget) *prometheusreceiver.Config {
 	scrapeConfig := &config.ScrapeConfig{}
 
-	scrapeConfig.ScrapeInterval = model.Duration(cfg.ScrapeInterval)
+	scrapeConfig.ScrapeInterval = model.Duration(target.ScrapeInterval)
 	scrapeConfig.ScrapeTimeout = model.Duration(defaultScrapeTimeout)
 	scrapeConfig.Scheme = "http"
-	scrapeConfig.MetricsPath = defaultMetricsPath
-	scrapeConfig.JobName = extractName(cfg)
+	scrapeConfig.MetricsPath = target.MetricsPath
+	scrapeConfig.JobName = target.Name
 	scrapeConfig.HonorLabels = false
 	scrapeConfig.HonorTimestamps = true
 
+	var labels model.LabelSet
+	if len(target.Labels) > 0 {
+		labels = make(model.LabelSet, len(target.Labels))
+		for k, v := range target.Labels {
+			labels[model.LabelName(k)] = model.LabelValue(v)
+		}
+	}
+
 	// Set the proper target by creating one target inside a single target group (this is how Prometheus wants its scrape config)
 	scrapeConfig.ServiceDiscoveryConfigs = discovery.Configs{
 		&discovery.StaticConfig{
 			{
 				Targets: []model.LabelSet{
-					{model.AddressLabel: model.LabelValue(fmt.Sprintf("localhost:%v", cfg.Port))},
+					{model.AddressLabel: model.LabelValue(fmt.Sprintf("localhost:%v", target.Port))},
 				},
+				Labels: labels,
 			},
 		},
 	}
 
 	receiverSettings := &configmodels.ReceiverSettings{
 		TypeVal: typeStr,
-		NameVal: cfg.Name(),
+		NameVal: receiverName,
 	}
 
 	return &prometheusreceiver.Config{
@@ -132,11 +222,12 @@ func getPromReceiverConfig(cfg *Config) *prometheusreceiver.Config {
 }
 
 // getSubprocessConfig returns the subprocess config
-func getSubprocessConfig(cfg *Config) *subprocessmanager.SubprocessConfig {
+func getSubprocessConfig(target *SubprocessTarget) *subprocessmanager.SubprocessConfig {
 	subprocessConfig := &subprocessmanager.SubprocessConfig{}
 
-	subprocessConfig.Command = cfg.SubprocessConfig.Command
-	subprocessConfig.Env = cfg.SubprocessConfig.Env
+	subprocessConfig.Command = target.SubprocessConfig.Command
+	subprocessConfig.Env = target.SubprocessConfig.Env
+	subprocessConfig.ShutdownTimeout = target.SubprocessConfig.ShutdownTimeout
 
 	return subprocessConfig
 }
@@ -151,42 +242,59 @@ func extractName(cfg *Config) string {
 	return splitName[0]
 }
 
-// Start creates the configs and calls the function that handles the prometheus_exec receiver
+// Start starts a goroutine handling each subprocess of the receiver
 func (per *prometheusExecReceiver) Start(ctx context.Context, host component.Host) error {
 	// shutdown channel
 	per.shutdownCh = make(chan struct{})
 
-	go per.manageProcess(context.Background(), host)
+	for _, sr := range per.subprocesses {
+		sr.shutdownCh = per.shutdownCh
+		per.wg.Add(1)
+		go func(sr *subprocessReceiver) {
+			defer per.wg.Done()
+			sr.manageProcess(context.Background(), host)
+		}(sr)
+	}
 
 	return nil
 }
 
 // manageProcess is an infinite loop that handles starting and restarting Prometheus-receiver/subprocess pairs
-func (per *prometheusExecReceiver) manageProcess(ctx context.Context, host component.Host) {
+func (sr *subprocessReceiver) manageProcess(ctx context.Context, host component.Host) {
 	var crashCount int
 
 	for {
 
-		receiver, err := per.createAndStartReceiver(ctx, host)
+		receiver, err := sr.createAndStartReceiver(ctx, host)
 		if err != nil {
-			per.params.Logger.Error("createReceiver() error", zap.String("error", err.Error()))
+			sr.params.Logger.Error("createReceiver() error", zap.String("subprocess", sr.target.Name), zap.String("error", err.Error()))
 			return
 		}
 
-		elapsed := per.runProcess(ctx)
+		elapsed := sr.runProcess(ctx)
+		sr.releasePort()
 
 		err = receiver.Shutdown(ctx)
 		if err != nil {
-			per.params.Logger.Error("could not stop receiver associated to process, killing it", zap.String("error", err.Error()))
+			sr.params.Logger.Error("could not stop receiver associated to process, killing it", zap.String("subprocess", sr.target.Name), zap.String("error", err.Error()))
 			return
 		}
 
-		crashCount = per.computeCrashCount(elapsed, crashCount)
-		per.computeDelayAndSleep(elapsed, crashCount)
+		// Exit loop if shutdown was signaled
+		select {
+		case <-sr.shutdownCh:
+			return
+		default:
+		}
+
+		crashCount = sr.computeCrashCount(elapsed, crashCount)
+		delay := sr.computeDelay(elapsed, crashCount)
+		recordRestart(sr.receiverName, sr.target.Name, crashCount, delay)
+		sr.sleep(delay)
 
 		// Exit loop if shutdown was signaled
 		select {
-		case <-per.shutdownCh:
+		case <-sr.shutdownCh:
 			return
 		default:
 		}
@@ -194,84 +302,103 @@ func (per *prometheusExecReceiver) manageProcess(ctx context.Context, host compo
 }
 
 // createAndStartReceiver will create the underlying Prometheus receiver and generate a random port if one is needed, then start it
-func (per *prometheusExecReceiver) createAndStartReceiver(ctx context.Context, host component.Host) (component.MetricsReceiver, error) {
-	currentPort := per.port
+func (sr *subprocessReceiver) createAndStartReceiver(ctx context.Context, host component.Host) (component.MetricsReceiver, error) {
+	currentPort := sr.target.Port
 
 	// Generate a port if none was specified
 	if currentPort == 0 {
 		var err error
-		currentPort, err = generateRandomPort()
+		currentPort, err = sr.ports.allocate()
 		if err != nil {
 			return nil, fmt.Errorf("generateRandomPort() error - killing this single process/receiver: %w", err)
 		}
 
-		staticConfig := per.promReceiverConfig.PrometheusConfig.ScrapeConfigs[0].ServiceDiscoveryConfigs[0].(*discovery.StaticConfig)
+		staticConfig := sr.promReceiverConfig.PrometheusConfig.ScrapeConfigs[0].ServiceDiscoveryConfigs[0].(*discovery.StaticConfig)
 		(*staticConfig)[0].Targets = []model.LabelSet{
 			{model.AddressLabel: model.LabelValue(fmt.Sprintf("localhost:%v", currentPort))},
 		}
 	}
+	sr.port = currentPort
 
 	// Create and start the underlying Prometheus receiver
 	factory := prometheusreceiver.NewFactory()
-	receiver, err := factory.CreateMetricsReceiver(ctx, per.params, per.promReceiverConfig, per.consumer)
+	receiver, err := factory.CreateMetricsReceiver(ctx, sr.params, sr.promReceiverConfig, sr.consumer)
 	if err != nil {
+		sr.releasePort()
 		return nil, fmt.Errorf("unable to create Prometheus receiver - killing this single process/receiver: %w", err)
 	}
 
-	per.subprocessConfig = per.fillPortPlaceholders(currentPort)
+	sr.subprocessConfig = sr.fillPortPlaceholders(currentPort)
 
 	err = receiver.Start(ctx, host)
 	if err != nil {
+		sr.releasePort()
 		return nil, fmt.Errorf("could not start receiver - killing this single process/receiver: %w", err)
 	}
 
 	return receiver, nil
 }
 
+// releasePort makes the port of the subprocess available again if it was generated
+func (sr *subprocessReceiver) releasePort() {
+	if sr.target.Port == 0 {
+		sr.ports.release(sr.port)
+	}
+}
+
 // runProcess will run the process and return runtime, or handle a shutdown if one is triggered while the subprocess is running
-func (per *prometheusExecReceiver) runProcess(ctx context.Context) time.Duration {
+func (sr *subprocessReceiver) runProcess(ctx context.Context) time.Duration {
 	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	run := make(chan runResult, 1)
 
-	go per.handleProcessResult(childCtx, run)
+	go sr.handleProcessResult(childCtx, run)
 
 	select {
 	case result := <-run:
 		// Log the error from the subprocess without returning it since we want to restart the process if it exited
 		if result.subprocessErr != nil {
-			per.params.Logger.Info("Subprocess error", zap.String("error", result.subprocessErr.Error()))
+			sr.params.Logger.Info("Subprocess error", zap.String("subprocess", sr.target.Name), zap.String("error", result.subprocessErr.Error()))
 		}
-		cancel()
 		return result.elapsed
 
-	case <-per.shutdownCh:
+	case <-sr.shutdownCh:
+		// Wait for the subprocess to be stopped
 		cancel()
+		result := <-run
+		if result.subprocessErr != nil {
+			sr.params.Logger.Info("Subprocess error", zap.String("subprocess", sr.target.Name), zap.String("error", result.subprocessErr.Error()))
+		}
 		return 0
 	}
 }
 
 // handleProcessResult calls the process manager's run function and pipes the return value into the channel
-func (per *prometheusExecReceiver) handleProcessResult(childCtx context.Context, run chan<- runResult) {
-	elapsed, subprocessErr := per.subprocessConfig.Run(childCtx, per.params.Logger)
+func (sr *subprocessReceiver) handleProcessResult(childCtx context.Context, run chan<- runResult) {
+	elapsed, subprocessErr := sr.subprocessConfig.Run(childCtx, sr.params.Logger)
 	run <- runResult{elapsed, subprocessErr}
 }
 
-// computeDelayAndSleep will compute how long the process should delay before restarting and handle a shutdown while this goroutine waits
-func (per *prometheusExecReceiver) computeDelayAndSleep(elapsed time.Duration, crashCount int) {
+// computeDelay will compute how long the process should delay before restarting
+func (sr *subprocessReceiver) computeDelay(elapsed time.Duration, crashCount int) time.Duration {
 	sleepTime := getDelay(elapsed, healthyProcessTime, crashCount, healthyCrashCount)
-	per.params.Logger.Info("Subprocess start delay", zap.String("time until process restarts", sleepTime.String()))
+	sr.params.Logger.Info("Subprocess start delay", zap.String("subprocess", sr.target.Name), zap.String("time until process restarts", sleepTime.String()))
+	return sleepTime
+}
 
+// sleep waits for the given delay, or until a shutdown is triggered
+func (sr *subprocessReceiver) sleep(delay time.Duration) {
 	select {
-	case <-time.After(sleepTime):
+	case <-time.After(delay):
 		return
 
-	case <-per.shutdownCh:
+	case <-sr.shutdownCh:
 		return
 	}
 }
 
 // computeCrashCount will compute crashCount according to runtime
-func (per *prometheusExecReceiver) computeCrashCount(elapsed time.Duration, crashCount int) int {
+func (sr *subprocessReceiver) computeCrashCount(elapsed time.Duration, crashCount int) int {
 	if elapsed > healthyProcessTime {
 		return 1
 	}
@@ -281,20 +408,64 @@ func (per *prometheusExecReceiver) computeCrashCount(elapsed time.Duration, cras
 }
 
 // fillPortPlaceholders will check if any of the strings in the process data have the {{port}} placeholder, and replace it if necessary
-func (per *prometheusExecReceiver) fillPortPlaceholders(newPort int) *subprocessmanager.SubprocessConfig {
+func (sr *subprocessReceiver) fillPortPlaceholders(newPort int) *subprocessmanager.SubprocessConfig {
 	port := strconv.Itoa(newPort)
 
-	newConfig := *per.subprocessConfig
+	newConfig := *sr.subprocessConfig
 
-	newConfig.Command = strings.ReplaceAll(per.config.SubprocessConfig.Command, portTemplate, port)
+	newConfig.Command = strings.ReplaceAll(sr.target.SubprocessConfig.Command, portTemplate, port)
 
-	for i, env := range per.config.SubprocessConfig.Env {
+	newConfig.Env = make([]subprocessmanager.EnvConfig, len(sr.target.SubprocessConfig.Env))
+	for i, env := range sr.target.SubprocessConfig.Env {
+		newConfig.Env[i].Name = env.Name
 		newConfig.Env[i].Value = strings.ReplaceAll(env.Value, portTemplate, port)
 	}
 
 	return &newConfig
 }
 
+// portAllocator hands out random free ports, making sure the subprocesses of a receiver don't share one
+type portAllocator struct {
+	sync.Mutex
+	inUse map[int]struct{}
+}
+
+func newPortAllocator() *portAllocator {
+	return &portAllocator{inUse: make(map[int]struct{})}
+}
+
+// reserve marks the port as used, it returns false if it already was
+func (pa *portAllocator) reserve(port int) bool {
+	pa.Lock()
+	defer pa.Unlock()
+	if _, ok := pa.inUse[port]; ok {
+		return false
+	}
+	pa.inUse[port] = struct{}{}
+	return true
+}
+
+// allocate returns a free port that is not used by another subprocess
+func (pa *portAllocator) allocate() (int, error) {
+	for i := 0; i < maxPortAttempts; i++ {
+		port, err := generateRandomPort()
+		if err != nil {
+			return 0, err
+		}
+		if pa.reserve(port) {
+			return port, nil
+		}
+	}
+	return 0, errors.New("could not find a free port")
+}
+
+// release makes the port available again
+func (pa *portAllocator) release(port int) {
+	pa.Lock()
+	defer pa.Unlock()
+	delete(pa.inUse, port)
+}
+
 // generateRandomPort will generate a random available port
 func generateRandomPort() (int, error) {
 	listener, err := net.Listen("tcp", "localhost:0")
@@ -316,8 +487,20 @@ func getDelay(elapsed time.Duration, healthyProcessDuration time.Duration, crash
 	return initialDelay * time.Duration(math.Pow(delayMultiplier, float64(crashCount-healthyCrashCount)+rand.Float64()))
 }
 
-// Shutdown stops the underlying Prometheus receiver.
+// Shutdown stops the subprocesses, giving them a chance to exit gracefully, and their Prometheus receivers.
 func (per *prometheusExecReceiver) Shutdown(ctx context.Context) error {
 	close(per.shutdownCh)
-	return nil
+
+	done := make(chan struct{})
+	go func() {
+		per.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -120,6 +120,114 @@ func assertTwoUniqueValuesScraped(t *testing.T, metricsSlice []pdata.Metrics) {
 	assert.Fail(t, "All %v scraped values were non-unique", len(metricsSlice))
 }
 
+// TestMultipleSubprocesses loads a config with a list of subprocesses and checks a receiver is set up for each of them
+func TestMultipleSubprocesses(t *testing.T) {
+	receiverConfig := loadConfigAssertNoError(t, "prometheus_exec/multiple")
+
+	per, err := newPromExecReceiver(component.ReceiverCreateParams{Logger: zap.NewNop()}, receiverConfig.(*Config), nil)
+	require.NoError(t, err)
+	require.Len(t, per.subprocesses, 2)
+
+	mysql := per.subprocesses[0]
+	assert.Equal(t, "mysql", mysql.target.Name)
+	assert.Equal(t, 0, mysql.port)
+	mysqlScrapeConfig := mysql.promReceiverConfig.PrometheusConfig.ScrapeConfigs[0]
+	assert.Equal(t, "mysql", mysqlScrapeConfig.JobName)
+	assert.Equal(t, model.Duration(30*time.Second), mysqlScrapeConfig.ScrapeInterval)
+	assert.Equal(t, "/metrics", mysqlScrapeConfig.MetricsPath)
+	assert.Equal(t,
+		model.LabelSet{"database": "mysql"},
+		(*mysqlScrapeConfig.ServiceDiscoveryConfigs[0].(*discovery.StaticConfig))[0].Labels)
+
+	postgres := per.subprocesses[1]
+	assert.Equal(t, "postgres", postgres.target.Name)
+	assert.Equal(t, 9187, postgres.port)
+	assert.Equal(t, 2*time.Second, postgres.subprocessConfig.ShutdownTimeout)
+	postgresScrapeConfig := postgres.promReceiverConfig.PrometheusConfig.ScrapeConfigs[0]
+	assert.Equal(t, "postgres", postgresScrapeConfig.JobName)
+	assert.Equal(t, model.Duration(15*time.Second), postgresScrapeConfig.ScrapeInterval)
+	assert.Equal(t, "/probe", postgresScrapeConfig.MetricsPath)
+	assert.Nil(t, (*postgresScrapeConfig.ServiceDiscoveryConfigs[0].(*discovery.StaticConfig))[0].Labels)
+
+	// All the subprocesses share the ports in use
+	assert.Same(t, mysql.ports, postgres.ports)
+	assert.False(t, mysql.ports.reserve(9187))
+}
+
+func TestInvalidSubprocesses(t *testing.T) {
+	invalidTests := []struct {
+		name   string
+		config *Config
+	}{
+		{
+			name: "missing command",
+			config: &Config{
+				Subprocesses: []SubprocessTarget{
+					{Name: "a"},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			config: &Config{
+				Subprocesses: []SubprocessTarget{
+					{SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "a"}},
+				},
+			},
+		},
+		{
+			name: "duplicate name",
+			config: &Config{
+				Subprocesses: []SubprocessTarget{
+					{Name: "a", SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "a"}},
+					{Name: "a", SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "b"}},
+				},
+			},
+		},
+		{
+			name: "duplicate name with the top level subprocess",
+			config: &Config{
+				ReceiverSettings: configmodels.ReceiverSettings{NameVal: "prometheus_exec/a"},
+				SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "a"},
+				Subprocesses: []SubprocessTarget{
+					{Name: "a", SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "b"}},
+				},
+			},
+		},
+		{
+			name: "duplicate port",
+			config: &Config{
+				Subprocesses: []SubprocessTarget{
+					{Name: "a", Port: 9104, SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "a"}},
+					{Name: "b", Port: 9104, SubprocessConfig: subprocessmanager.SubprocessConfig{Command: "b"}},
+				},
+			},
+		},
+	}
+
+	for _, test := range invalidTests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newPromExecReceiver(component.ReceiverCreateParams{Logger: zap.NewNop()}, test.config, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPortAllocator(t *testing.T) {
+	pa := newPortAllocator()
+
+	assert.True(t, pa.reserve(9104))
+	assert.False(t, pa.reserve(9104))
+
+	port, err := pa.allocate()
+	require.NoError(t, err)
+	assert.NotEqual(t, 9104, port)
+	assert.False(t, pa.reserve(port))
+
+	pa.release(port)
+	assert.True(t, pa.reserve(port))
+}
+
 func TestConfigBuilderFunctions(t *testing.T) {
 	configTests := []struct {
 		name                 string
@@ -287,14 +395,16 @@ func TestConfigBuilderFunctions(t *testing.T) {
 	for _, test := range configTests {
 		t.Run(test.name, func(t *testing.T) {
 			test.config.SetName(test.customName)
-			got := getPromReceiverConfig(test.config)
+			target := getTopLevelTarget(test.config)
+			got := getPromReceiverConfig(test.config.Name(), &target)
 			assert.Equal(t, test.wantReceiverConfig, got)
 		})
 	}
 
 	for _, test := range configTests {
 		t.Run(test.name, func(t *testing.T) {
-			got := getSubprocessConfig(test.config)
+			target := getTopLevelTarget(test.config)
+			got := getSubprocessConfig(&target)
 			assert.Equal(t, test.wantSubprocessConfig, got)
 		})
 	}
@@ -359,15 +469,15 @@ func TestExtractName(t *testing.T) {
 func TestFillPortPlaceholders(t *testing.T) {
 	fillPortPlaceholdersTests := []struct {
 		name    string
-		wrapper *prometheusExecReceiver
+		wrapper *subprocessReceiver
 		newPort int
 		want    *subprocessmanager.SubprocessConfig
 	}{
 		{
 			name: "port is defined by user",
-			wrapper: &prometheusExecReceiver{
+			wrapper: &subprocessReceiver{
 				port: 10500,
-				config: &Config{
+				target: &SubprocessTarget{
 					SubprocessConfig: subprocessmanager.SubprocessConfig{
 						Command: "apache_exporter --port:{{port}}",
 						Env: []subprocessmanager.EnvConfig{
@@ -410,8 +520,8 @@ func TestFillPortPlaceholders(t *testing.T) {
 		},
 		{
 			name: "no string templating",
-			wrapper: &prometheusExecReceiver{
-				config: &Config{
+			wrapper: &subprocessReceiver{
+				target: &SubprocessTarget{
 					SubprocessConfig: subprocessmanager.SubprocessConfig{
 						Command: "apache_exporter",
 						Env: []subprocessmanager.EnvConfig{
@@ -454,8 +564,8 @@ func TestFillPortPlaceholders(t *testing.T) {
 		},
 		{
 			name: "no port defined",
-			wrapper: &prometheusExecReceiver{
-				config: &Config{
+			wrapper: &subprocessReceiver{
+				target: &SubprocessTarget{
 					SubprocessConfig: subprocessmanager.SubprocessConfig{
 						Command: "apache_exporter --port={{port}}",
 						Env: []subprocessmanager.EnvConfig{
@@ -590,11 +700,11 @@ func TestGetDelayAndComputeCrashCount(t *testing.T) {
 	})
 
 	// computeCrashCount() test
-	per := &prometheusExecReceiver{}
+	sr := &subprocessReceiver{}
 
 	for _, test := range getDelayAndComputeCrashCountTests {
 		t.Run(test.name, func(t *testing.T) {
-			got := per.computeCrashCount(test.elapsed, test.crashCount)
+			got := sr.computeCrashCount(test.elapsed, test.crashCount)
 			assert.Equal(t, test.wantCrashCount, got)
 		})
 	}
@@ -14,12 +14,17 @@
 
 package subprocessmanager
 
+import "time"
+
 // SubprocessConfig is the config definition for the subprocess manager
 type SubprocessConfig struct {
 	// Command is the command to be run (binary + flags, separated by commas)
 	Command string `mapstructure:"exec"`
 	// Env is a list of env variables to pass to a specific command
 	Env []EnvConfig `mapstructure:"env"`
+	// ShutdownTimeout is how long the subprocess is given to exit after being
+	// sent SIGTERM before it is killed. Defaults to 5 seconds.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
 // EnvConfig is the config definition of each key-value pair for environment variables
@@ -22,12 +22,16 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/kballard/go-shellquote"
 	"go.uber.org/zap"
 )
 
+// defaultShutdownTimeout is the time given to subprocesses to exit after SIGTERM
+const defaultShutdownTimeout = 5 * time.Second
+
 // Run will start the process and keep track of running time
 func (proc *SubprocessConfig) Run(ctx context.Context, logger *zap.Logger) (time.Duration, error) {
 	// Parse the command line string into arguments
@@ -77,17 +81,45 @@ func (proc *SubprocessConfig) Run(ctx context.Context, logger *zap.Logger) (time
 		return elapsed, nil
 
 	case <-ctx.Done():
+		err := proc.stop(childProcess.Process, processErrCh)
 		elapsed := time.Since(start)
-
-		err := childProcess.Process.Kill()
 		if err != nil {
-			return elapsed, fmt.Errorf("couldn't kill subprocess: %w", errProcess)
+			return elapsed, fmt.Errorf("couldn't stop subprocess: %w", err)
 		}
 		return elapsed, nil
 	}
 }
 
-// Log every line of the subprocesse's output using zap, until pipe is closed (EOF)
+// stop asks the subprocess to exit with SIGTERM and kills it if it is still
+// running after the shutdown timeout, or if it can't be sent the signal (e.g.
+// on Windows).
+func (proc *SubprocessConfig) stop(process *os.Process, processErrCh <-chan error) error {
+	timeout := proc.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	if err := process.Signal(syscall.SIGTERM); err == nil {
+		select {
+		case <-processErrCh:
+			return nil
+		case <-time.After(timeout):
+		}
+	}
+
+	if err := process.Kill(); err != nil {
+		select {
+		case <-processErrCh:
+			// The subprocess exited before it could be killed.
+			return nil
+		default:
+			return err
+		}
+	}
+	<-processErrCh
+	return nil
+}
+
 func (proc *SubprocessConfig) pipeSubprocessOutput(reader *bufio.Reader, logger *zap.Logger, isStdout bool) {
 	for {
 		line, err := reader.ReadString('\n')
@@ -148,3 +148,50 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRunShutdown(t *testing.T) {
+	var shutdownTests = []struct {
+		name    string
+		process *SubprocessConfig
+		maxWait time.Duration
+	}{
+		{
+			name: "process exits on SIGTERM",
+			process: &SubprocessConfig{
+				Command:         "sleep 60",
+				ShutdownTimeout: 10 * time.Second,
+			},
+			maxWait: 5 * time.Second,
+		},
+		{
+			name: "process ignoring SIGTERM is killed",
+			process: &SubprocessConfig{
+				Command:         `sh -c 'trap "" TERM; while true; do sleep 0.1; done'`,
+				ShutdownTimeout: 500 * time.Millisecond,
+			},
+			maxWait: 5 * time.Second,
+		},
+	}
+
+	for _, test := range shutdownTests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(200*time.Millisecond, cancel)
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := test.process.Run(ctx, zap.NewNop())
+				done <- err
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("Run() error = %v", err)
+				}
+			case <-time.After(test.maxWait):
+				t.Errorf("Run() did not return %v after the shutdown", test.maxWait)
+			}
+		})
+	}
+}
@@ -15,17 +15,34 @@
 package stanzareceiver
 
 import (
+	"time"
+
 	"github.com/observiq/stanza/pipeline"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"gopkg.in/yaml.v2"
 )
 
 // Config defines configuration for the stanza receiver
 type Config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
-	OffsetsFile                   string         `mapstructure:"offsets_file"`
-	PluginDir                     string         `mapstructure:"plugin_dir"`
-	Operators                     OperatorConfig `mapstructure:"operators"`
+	OffsetsFile                   string          `mapstructure:"offsets_file"`
+	PluginDir                     string          `mapstructure:"plugin_dir"`
+	Operators                     OperatorConfig  `mapstructure:"operators"`
+	Converter                     ConverterConfig `mapstructure:"converter"`
+	// RetrySettings configures how sending logs to the next consumer is retried.
+	// While retrying, no more entries are read from the stanza pipeline.
+	RetrySettings exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+}
+
+// ConverterConfig controls how the stanza entries are batched into logs.
+type ConverterConfig struct {
+	// MaxFlushCount is the maximum number of entries sent to the next consumer at once.
+	MaxFlushCount uint `mapstructure:"max_flush_count"`
+	// MaxFlushBytes is the approximate maximum size in bytes of the entries sent to the next consumer at once.
+	MaxFlushBytes uint `mapstructure:"max_flush_bytes"`
+	// FlushInterval is the maximum time an entry is buffered before being sent.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
 }
 
 type OperatorConfig []map[string]interface{}
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -57,5 +58,11 @@ func TestLoadConfig(t *testing.T) {
     layout: '%Y-%m-%d'
   severity:
     parse_from: sev`),
+			Converter: ConverterConfig{
+				MaxFlushCount: defaultMaxFlushCount,
+				MaxFlushBytes: 2048,
+				FlushInterval: defaultFlushInterval,
+			},
+			RetrySettings: exporterhelper.DefaultRetrySettings(),
 		})
 }
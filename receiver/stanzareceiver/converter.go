@@ -16,53 +16,93 @@ package stanzareceiver
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/observiq/stanza/entry"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-func convert(obsLog *entry.Entry) pdata.Logs {
+// convert converts the entries into logs, entries sharing the same resource
+// are grouped into a single ResourceLogs.
+func convert(entries ...*entry.Entry) pdata.Logs {
 	out := pdata.NewLogs()
 	logs := out.ResourceLogs()
-	logs.Resize(1)
-	rls := logs.At(0)
-
-	resource := rls.Resource()
-	if len(obsLog.Resource) > 0 {
-		resourceAtts := resource.Attributes()
-		for k, v := range obsLog.Resource {
-			resourceAtts.InsertString(k, v)
-		}
-	}
 
-	rls.InstrumentationLibraryLogs().Resize(1)
-	ills := rls.InstrumentationLibraryLogs().At(0)
+	logsByResource := make(map[string]pdata.LogSlice)
+	for _, obsLog := range entries {
+		key := resourceKey(obsLog.Resource)
+		lrs, ok := logsByResource[key]
+		if !ok {
+			rls := pdata.NewResourceLogs()
+			if len(obsLog.Resource) > 0 {
+				resourceAtts := rls.Resource().Attributes()
+				for k, v := range obsLog.Resource {
+					resourceAtts.InsertString(k, v)
+				}
+			}
 
-	il := ills.InstrumentationLibrary()
-	il.SetName(typeStr)
-	il.SetVersion(verStr)
+			rls.InstrumentationLibraryLogs().Resize(1)
+			ills := rls.InstrumentationLibraryLogs().At(0)
 
-	lr := pdata.NewLogRecord()
-	lr.SetTimestamp(pdata.TimestampUnixNano(obsLog.Timestamp.UnixNano()))
+			il := ills.InstrumentationLibrary()
+			il.SetName(typeStr)
+			il.SetVersion(verStr)
+
+			logs.Append(rls)
+			lrs = ills.Logs()
+			logsByResource[key] = lrs
+		}
 
-	sevText, sevNum := convertSeverity(obsLog.Severity)
-	lr.SetSeverityText(sevText)
-	lr.SetSeverityNumber(sevNum)
+		lr := pdata.NewLogRecord()
+		lr.SetTimestamp(pdata.TimestampUnixNano(obsLog.Timestamp.UnixNano()))
 
-	if len(obsLog.Labels) > 0 {
-		attributes := lr.Attributes()
-		for k, v := range obsLog.Labels {
-			attributes.InsertString(k, v)
+		sevText, sevNum := convertSeverity(obsLog.Severity)
+		lr.SetSeverityText(sevText)
+		lr.SetSeverityNumber(sevNum)
+
+		if len(obsLog.Labels) > 0 {
+			attributes := lr.Attributes()
+			for k, v := range obsLog.Labels {
+				attributes.InsertString(k, v)
+			}
 		}
-	}
 
-	insertToAttributeVal(obsLog.Record, lr.Body())
+		insertToAttributeVal(obsLog.Record, lr.Body())
 
-	ills.Logs().Append(lr)
+		lrs.Append(lr)
+	}
 
 	return out
 }
 
+// resourceKey returns a key identifying the resource, regardless of the
+// iteration order of the map.
+func resourceKey(resource map[string]string) string {
+	if len(resource) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(resource))
+	for k := range resource {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		// Prefix with the lengths so that keys and values can hold any character.
+		b.WriteString(strconv.Itoa(len(k)))
+		b.WriteByte(':')
+		b.WriteString(k)
+		b.WriteString(strconv.Itoa(len(resource[k])))
+		b.WriteByte(':')
+		b.WriteString(resource[k])
+	}
+	return b.String()
+}
+
 func insertToAttributeVal(value interface{}, dest pdata.AttributeValue) {
 	switch t := value.(type) {
 	case bool:
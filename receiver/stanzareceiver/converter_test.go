@@ -309,3 +309,53 @@ func TestConvertSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertGroupsByResource(t *testing.T) {
+	e1 := entry.New()
+	e1.AddResourceKey("host", "a")
+	e1.AddResourceKey("type", "global")
+	e1.Record = "one"
+
+	e2 := entry.New()
+	e2.AddResourceKey("host", "b")
+	e2.Record = "two"
+
+	e3 := entry.New()
+	e3.AddResourceKey("type", "global")
+	e3.AddResourceKey("host", "a")
+	e3.Record = "three"
+
+	e4 := entry.New()
+	e4.Record = "four"
+
+	resourceLogs := convert(e1, e2, e3, e4).ResourceLogs()
+	require.Equal(t, 3, resourceLogs.Len(), "expected 3 resources")
+
+	bodies := func(i int) []string {
+		lrs := resourceLogs.At(i).InstrumentationLibraryLogs().At(0).Logs()
+		out := make([]string, 0, lrs.Len())
+		for j := 0; j < lrs.Len(); j++ {
+			out = append(out, lrs.At(j).Body().StringVal())
+		}
+		return out
+	}
+
+	require.Equal(t, 2, resourceLogs.At(0).Resource().Attributes().Len())
+	require.Equal(t, []string{"one", "three"}, bodies(0))
+	require.Equal(t, 1, resourceLogs.At(1).Resource().Attributes().Len())
+	require.Equal(t, []string{"two"}, bodies(1))
+	require.Equal(t, 0, resourceLogs.At(2).Resource().Attributes().Len())
+	require.Equal(t, []string{"four"}, bodies(2))
+}
+
+func BenchmarkConvertBatch(b *testing.B) {
+	entries := make([]*entry.Entry, 100)
+	for i := range entries {
+		entries[i] = complexEntry()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		convert(entries...)
+	}
+}
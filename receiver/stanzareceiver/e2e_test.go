@@ -56,6 +56,25 @@ func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
 	return func() bool { return sink.LogRecordsCount() == expected }
 }
 
+// logRecords flattens the log records of logs, as entries are batched
+// differently depending on when they are read.
+func logRecords(logs ...pdata.Logs) []pdata.LogRecord {
+	var records []pdata.LogRecord
+	for _, ld := range logs {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				lrs := ills.At(j).Logs()
+				for k := 0; k < lrs.Len(); k++ {
+					records = append(records, lrs.At(k))
+				}
+			}
+		}
+	}
+	return records
+}
+
 func TestReadStaticFile(t *testing.T) {
 	t.Parallel()
 
@@ -79,7 +98,7 @@ func TestReadStaticFile(t *testing.T) {
 	e3.Set(entry.NewRecordField("msg"), "Some details...")
 	e3.AddLabel("file_name", "simple.log")
 
-	expectedLogs := []pdata.Logs{convert(e1), convert(e2), convert(e3)}
+	expectedLogs := convert(e1, e2, e3)
 
 	f := NewFactory()
 	sink := new(consumertest.LogsSink)
@@ -102,7 +121,7 @@ func TestReadStaticFile(t *testing.T) {
 
 	require.NoError(t, rcvr.Start(context.Background(), &testHost{t: t}))
 	require.Eventually(t, expectNLogs(sink, 3), time.Second, time.Millisecond)
-	require.Equal(t, expectedLogs, sink.AllLogs())
+	require.Equal(t, logRecords(expectedLogs), logRecords(sink.AllLogs()...))
 	require.NoError(t, rcvr.Shutdown(context.Background()))
 }
 
@@ -159,7 +178,7 @@ func (rt *rotationTest) Run(t *testing.T) {
 
 	// Build input lines and expected outputs
 	lines := make([]string, numLogs)
-	expectedLogs := make([]pdata.Logs, numLogs)
+	expectedLogs := make([]*entry.Entry, numLogs)
 	expectedTimestamp, _ := time.ParseInLocation("2006-01-02", "2020-08-25", time.Local)
 	for i := 0; i < numLogs; i++ {
 		msg := fmt.Sprintf("This is a simple log line with the number %3d", i)
@@ -168,7 +187,7 @@ func (rt *rotationTest) Run(t *testing.T) {
 		e := entry.New()
 		e.Timestamp = expectedTimestamp
 		e.Set(entry.NewRecordField("msg"), msg)
-		expectedLogs[i] = convert(e)
+		expectedLogs[i] = e
 	}
 
 	cfg := f.CreateDefaultConfig().(*Config)
@@ -194,7 +213,7 @@ func (rt *rotationTest) Run(t *testing.T) {
 	}
 
 	require.Eventually(t, expectNLogs(sink, numLogs), 2*time.Second, time.Millisecond)
-	require.ElementsMatch(t, expectedLogs, sink.AllLogs())
+	require.ElementsMatch(t, logRecords(convert(expectedLogs...)), logRecords(sink.AllLogs()...))
 	require.NoError(t, rcvr.Shutdown(context.Background()))
 }
 
@@ -16,23 +16,73 @@ package stanzareceiver
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/observiq/stanza/entry"
 	"github.com/observiq/stanza/operator/helper"
 	"go.uber.org/zap"
 )
 
-// LogEmitter is a stanza operator that emits log entries to a channel
+const (
+	defaultMaxFlushCount = 100
+	defaultMaxFlushBytes = 1 << 20
+	defaultFlushInterval = 100 * time.Millisecond
+
+	// stopFlushTimeout bounds how long Stop waits to hand over the entries
+	// still buffered.
+	stopFlushTimeout = time.Second
+)
+
+// LogEmitter is a stanza operator that buffers the entries it receives and
+// emits them in batches, once the batch holds max flush count entries or max
+// flush bytes of data, or the flush interval elapsed.
+// Emitting blocks until the batch is read, which applies backpressure to the
+// stanza operators.
 type LogEmitter struct {
 	helper.OutputOperator
-	logChan  chan *entry.Entry
+	logChan  chan []*entry.Entry
 	stopOnce sync.Once
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	batchMux      sync.Mutex
+	batch         []*entry.Entry
+	batchBytes    uint
+	maxFlushCount uint
+	maxFlushBytes uint
+	flushInterval time.Duration
+}
+
+// LogEmitterOption configures a LogEmitter.
+type LogEmitterOption func(*LogEmitter)
+
+// LogEmitterWithMaxFlushCount sets the maximum number of entries in a batch.
+func LogEmitterWithMaxFlushCount(maxFlushCount uint) LogEmitterOption {
+	return func(e *LogEmitter) {
+		e.maxFlushCount = maxFlushCount
+	}
+}
+
+// LogEmitterWithMaxFlushBytes sets the approximate maximum size in bytes of
+// the entries in a batch.
+func LogEmitterWithMaxFlushBytes(maxFlushBytes uint) LogEmitterOption {
+	return func(e *LogEmitter) {
+		e.maxFlushBytes = maxFlushBytes
+	}
+}
+
+// LogEmitterWithFlushInterval sets the maximum time an entry is buffered
+// before being emitted.
+func LogEmitterWithFlushInterval(flushInterval time.Duration) LogEmitterOption {
+	return func(e *LogEmitter) {
+		e.flushInterval = flushInterval
+	}
 }
 
-// NewLogEmitter creates a new receiver output
-func NewLogEmitter(logger *zap.SugaredLogger) *LogEmitter {
-	return &LogEmitter{
+func NewLogEmitter(logger *zap.SugaredLogger, opts ...LogEmitterOption) *LogEmitter {
+	e := &LogEmitter{
 		OutputOperator: helper.OutputOperator{
 			BasicOperator: helper.BasicOperator{
 				OperatorID:    "log_emitter",
@@ -40,23 +90,180 @@ func NewLogEmitter(logger *zap.SugaredLogger) *LogEmitter {
 				SugaredLogger: logger,
 			},
 		},
-		logChan: make(chan *entry.Entry),
+		logChan:       make(chan []*entry.Entry),
+		maxFlushCount: defaultMaxFlushCount,
+		maxFlushBytes: defaultMaxFlushBytes,
+		flushInterval: defaultFlushInterval,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.maxFlushCount == 0 {
+		e.maxFlushCount = defaultMaxFlushCount
+	}
+	if e.maxFlushBytes == 0 {
+		e.maxFlushBytes = defaultMaxFlushBytes
+	}
+	if e.flushInterval <= 0 {
+		e.flushInterval = defaultFlushInterval
+	}
+	e.batch = make([]*entry.Entry, 0, e.maxFlushCount)
+	return e
+}
+
+// Start starts the goroutine flushing the batch on the flush interval.
+func (e *LogEmitter) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go e.flusher(ctx)
+	return nil
 }
 
-// Process will emit an entry to the output channel
 func (e *LogEmitter) Process(ctx context.Context, ent *entry.Entry) error {
-	select {
-	case e.logChan <- ent:
-	case <-ctx.Done():
+	if batch := e.appendEntry(ent); batch != nil {
+		if !e.flush(ctx, batch) {
+			// keep the entries for the next flush rather than losing them
+			e.requeue(batch)
+			return ctx.Err()
+		}
 	}
 	return nil
 }
 
-// Stop will close the log channel
 func (e *LogEmitter) Stop() error {
 	e.stopOnce.Do(func() {
+		if e.cancel != nil {
+			e.cancel()
+			e.wg.Wait()
+		}
+
+		if batch := e.takeBatch(); len(batch) > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), stopFlushTimeout)
+			if !e.flush(ctx, batch) {
+				e.Errorw("Dropping buffered entries on stop", "count", len(batch))
+			}
+			cancel()
+		}
 		close(e.logChan)
 	})
 	return nil
 }
+
+// appendEntry adds ent to the batch and returns the batch once it is full.
+func (e *LogEmitter) appendEntry(ent *entry.Entry) []*entry.Entry {
+	e.batchMux.Lock()
+	defer e.batchMux.Unlock()
+
+	e.batch = append(e.batch, ent)
+	e.batchBytes += entrySize(ent)
+	if uint(len(e.batch)) < e.maxFlushCount && e.batchBytes < e.maxFlushBytes {
+		return nil
+	}
+	return e.swapBatch()
+}
+
+// takeBatch returns the buffered entries and starts a new batch.
+func (e *LogEmitter) takeBatch() []*entry.Entry {
+	e.batchMux.Lock()
+	defer e.batchMux.Unlock()
+
+	if len(e.batch) == 0 {
+		return nil
+	}
+	return e.swapBatch()
+}
+
+// requeue puts a batch that could not be flushed back in front of the
+// buffered entries.
+func (e *LogEmitter) requeue(batch []*entry.Entry) {
+	e.batchMux.Lock()
+	defer e.batchMux.Unlock()
+
+	for _, ent := range batch {
+		e.batchBytes += entrySize(ent)
+	}
+	e.batch = append(batch, e.batch...)
+}
+
+// swapBatch must be called with batchMux held.
+func (e *LogEmitter) swapBatch() []*entry.Entry {
+	batch := e.batch
+	e.batch = make([]*entry.Entry, 0, e.maxFlushCount)
+	e.batchBytes = 0
+	return batch
+}
+
+// flush blocks until the batch is read or ctx is done, it reports whether the
+// batch was read.
+func (e *LogEmitter) flush(ctx context.Context, batch []*entry.Entry) bool {
+	select {
+	case e.logChan <- batch:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (e *LogEmitter) flusher(ctx context.Context) {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if batch := e.takeBatch(); len(batch) > 0 && !e.flush(ctx, batch) {
+				// stopping, Stop flushes the requeued entries one last time
+				e.requeue(batch)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// entrySize approximates the size in bytes of an entry from its record,
+// labels and resource.
+func entrySize(ent *entry.Entry) uint {
+	size := recordSize(ent.Record)
+	for k, v := range ent.Labels {
+		size += uint(len(k) + len(v))
+	}
+	for k, v := range ent.Resource {
+		size += uint(len(k) + len(v))
+	}
+	return size
+}
+
+func recordSize(record interface{}) uint {
+	switch r := record.(type) {
+	case nil:
+		return 0
+	case string:
+		return uint(len(r))
+	case []byte:
+		return uint(len(r))
+	case map[string]interface{}:
+		var size uint
+		for k, v := range r {
+			size += uint(len(k)) + recordSize(v)
+		}
+		return size
+	case map[string]string:
+		var size uint
+		for k, v := range r {
+			size += uint(len(k) + len(v))
+		}
+		return size
+	case []interface{}:
+		var size uint
+		for _, v := range r {
+			size += recordSize(v)
+		}
+		return size
+	default:
+		return uint(len(fmt.Sprint(r)))
+	}
+}
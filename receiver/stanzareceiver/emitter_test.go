@@ -27,6 +27,7 @@ import (
 func TestLogEmitter(t *testing.T) {
 
 	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar())
+	require.NoError(t, emitter.Start())
 	defer emitter.Stop()
 
 	in := entry.New()
@@ -37,8 +38,114 @@ func TestLogEmitter(t *testing.T) {
 
 	select {
 	case out := <-emitter.logChan:
-		require.Equal(t, in, out)
+		require.Equal(t, []*entry.Entry{in}, out)
 	case <-time.After(time.Second):
 		require.FailNow(t, "Timed out waiting for output")
 	}
 }
+
+func TestLogEmitterMaxFlushCount(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar(),
+		LogEmitterWithMaxFlushCount(2),
+		LogEmitterWithFlushInterval(time.Hour))
+	require.NoError(t, emitter.Start())
+	defer emitter.Stop()
+
+	in := []*entry.Entry{entry.New(), entry.New(), entry.New()}
+	go func() {
+		for _, e := range in {
+			require.NoError(t, emitter.Process(context.Background(), e))
+		}
+	}()
+
+	select {
+	case out := <-emitter.logChan:
+		require.Equal(t, in[:2], out)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for output")
+	}
+
+	select {
+	case out := <-emitter.logChan:
+		require.FailNow(t, "Unexpected output before the flush interval", "%v", out)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestLogEmitterMaxFlushBytes(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar(),
+		LogEmitterWithMaxFlushBytes(10),
+		LogEmitterWithFlushInterval(time.Hour))
+	require.NoError(t, emitter.Start())
+	defer emitter.Stop()
+
+	in := []*entry.Entry{entry.New(), entry.New(), entry.New()}
+	in[0].Record = "12345"
+	in[1].Record = map[string]interface{}{"key": "12"}
+	in[2].Record = "1"
+	go func() {
+		for _, e := range in {
+			require.NoError(t, emitter.Process(context.Background(), e))
+		}
+	}()
+
+	select {
+	case out := <-emitter.logChan:
+		require.Equal(t, in[:2], out)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for output")
+	}
+
+	select {
+	case out := <-emitter.logChan:
+		require.FailNow(t, "Unexpected output before the flush interval", "%v", out)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestLogEmitterProcessRequeuesOnCancel(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar(),
+		LogEmitterWithMaxFlushCount(1),
+		LogEmitterWithFlushInterval(time.Hour))
+	require.NoError(t, emitter.Start())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	in := entry.New()
+	require.Equal(t, context.Canceled, emitter.Process(ctx, in))
+
+	go func() {
+		require.NoError(t, emitter.Stop())
+	}()
+
+	select {
+	case out := <-emitter.logChan:
+		require.Equal(t, []*entry.Entry{in}, out)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for output")
+	}
+}
+
+func TestLogEmitterStopFlushesBuffer(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar(),
+		LogEmitterWithFlushInterval(time.Hour))
+	require.NoError(t, emitter.Start())
+
+	in := entry.New()
+	require.NoError(t, emitter.Process(context.Background(), in))
+
+	go func() {
+		require.NoError(t, emitter.Stop())
+	}()
+
+	select {
+	case out := <-emitter.logChan:
+		require.Equal(t, []*entry.Entry{in}, out)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for output")
+	}
+
+	_, ok := <-emitter.logChan
+	require.False(t, ok, "channel should be closed once stopped")
+}
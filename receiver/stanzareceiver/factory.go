@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
@@ -43,6 +44,12 @@ func createDefaultConfig() configmodels.Receiver {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
+		Converter: ConverterConfig{
+			MaxFlushCount: defaultMaxFlushCount,
+			MaxFlushBytes: defaultMaxFlushBytes,
+			FlushInterval: defaultFlushInterval,
+		},
+		RetrySettings: exporterhelper.DefaultRetrySettings(),
 	}
 }
 
@@ -56,7 +63,12 @@ func createLogsReceiver(
 
 	obsConfig := cfg.(*Config)
 
-	emitter := NewLogEmitter(params.Logger.Sugar())
+	emitter := NewLogEmitter(
+		params.Logger.Sugar(),
+		LogEmitterWithMaxFlushCount(obsConfig.Converter.MaxFlushCount),
+		LogEmitterWithMaxFlushBytes(obsConfig.Converter.MaxFlushBytes),
+		LogEmitterWithFlushInterval(obsConfig.Converter.FlushInterval),
+	)
 
 	pipeline, err := obsConfig.Operators.IntoPipelineConfig()
 	if err != nil {
@@ -77,6 +89,7 @@ func createLogsReceiver(
 		agent:    logAgent,
 		emitter:  emitter,
 		consumer: nextConsumer,
+		retry:    obsConfig.RetrySettings,
 		logger:   params.Logger,
 	}, nil
 }
@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/stanza
 go 1.14
 
 require (
+	github.com/cenkalti/backoff v2.2.1+incompatible
 	github.com/observiq/nanojack v0.0.0-20201106172433-343928847ebc
 	github.com/observiq/stanza v0.13.11
 	github.com/stretchr/testify v1.7.0
@@ -21,6 +21,7 @@ import (
 	"github.com/observiq/stanza/entry"
 	"github.com/observiq/stanza/operator"
 	"github.com/observiq/stanza/operator/helper"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -85,3 +86,24 @@ func (m *mockLogsRejecter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error
 	m.rejected++
 	return fmt.Errorf("no")
 }
+
+// mockLogsFlaky fails the first failures calls, with a permanent error if
+// permanent is set.
+type mockLogsFlaky struct {
+	failures  int
+	permanent bool
+	calls     int
+	received  int
+}
+
+func (m *mockLogsFlaky) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	m.calls++
+	if m.calls <= m.failures {
+		if m.permanent {
+			return consumererror.Permanent(fmt.Errorf("no"))
+		}
+		return fmt.Errorf("not now")
+	}
+	m.received += ld.LogRecordCount()
+	return nil
+}
@@ -18,11 +18,16 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff"
 	stanza "github.com/observiq/stanza/agent"
+	"github.com/observiq/stanza/entry"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
 )
 
@@ -36,6 +41,7 @@ type stanzareceiver struct {
 	agent    *stanza.LogAgent
 	emitter  *LogEmitter
 	consumer consumer.LogsConsumer
+	retry    exporterhelper.RetrySettings
 	logger   *zap.Logger
 }
 
@@ -65,13 +71,11 @@ func (r *stanzareceiver) Start(ctx context.Context, host component.Host) error {
 				select {
 				case <-rctx.Done():
 					return
-				case obsLog, ok := <-r.emitter.logChan:
+				case entries, ok := <-r.emitter.logChan:
 					if !ok {
-						continue
-					}
-					if consumeErr := r.consumer.ConsumeLogs(ctx, convert(obsLog)); consumeErr != nil {
-						r.logger.Error("ConsumeLogs() error", zap.String("error", consumeErr.Error()))
+						return
 					}
+					r.consume(rctx, entries)
 				}
 			}
 		}()
@@ -94,3 +98,51 @@ func (r *stanzareceiver) Shutdown(context.Context) error {
 	})
 	return err
 }
+
+// consume sends the entries to the next consumer, retrying with an exponential
+// backoff on retryable errors. The emitter is not read while retrying, which
+// blocks the stanza operators instead of dropping entries.
+func (r *stanzareceiver) consume(ctx context.Context, entries []*entry.Entry) {
+	logs := convert(entries...)
+
+	expBackoff := backoff.ExponentialBackOff{
+		InitialInterval:     r.retry.InitialInterval,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		MaxInterval:         r.retry.MaxInterval,
+		MaxElapsedTime:      r.retry.MaxElapsedTime,
+		Clock:               backoff.SystemClock,
+	}
+	expBackoff.Reset()
+
+	for {
+		err := r.consumer.ConsumeLogs(ctx, logs)
+		if err == nil {
+			return
+		}
+
+		if !r.retry.Enabled || consumererror.IsPermanent(err) {
+			r.logger.Error("ConsumeLogs() error, dropping logs",
+				zap.Error(err), zap.Int("dropped_items", len(entries)))
+			return
+		}
+
+		delay := expBackoff.NextBackOff()
+		if delay == backoff.Stop {
+			r.logger.Error("ConsumeLogs() error, no more retries left, dropping logs",
+				zap.Error(err), zap.Int("dropped_items", len(entries)))
+			return
+		}
+
+		r.logger.Warn("ConsumeLogs() error, will retry",
+			zap.Error(err), zap.Duration("interval", delay))
+
+		select {
+		case <-ctx.Done():
+			r.logger.Error("Receiver is shutting down, dropping logs",
+				zap.Error(err), zap.Int("dropped_items", len(entries)))
+			return
+		case <-time.After(delay):
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/observiq/stanza/entry"
 	"github.com/observiq/stanza/pipeline"
@@ -28,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"gopkg.in/yaml.v2"
@@ -44,7 +46,7 @@ func TestStart(t *testing.T) {
 	require.NoError(t, err, "receiver start failed")
 
 	obsReceiver := receiver.(*stanzareceiver)
-	obsReceiver.emitter.logChan <- entry.New()
+	obsReceiver.emitter.logChan <- []*entry.Entry{entry.New()}
 	receiver.Shutdown(context.Background())
 	require.Equal(t, 1, mockConsumer.received, "one log entry expected")
 }
@@ -76,11 +78,79 @@ func TestHandleConsumeError(t *testing.T) {
 	require.NoError(t, err, "receiver start failed")
 
 	obsReceiver := receiver.(*stanzareceiver)
-	obsReceiver.emitter.logChan <- entry.New()
+	obsReceiver.emitter.logChan <- []*entry.Entry{entry.New()}
 	receiver.Shutdown(context.Background())
 	require.Equal(t, 1, mockConsumer.rejected, "one log entry expected")
 }
 
+func TestConsumeRetry(t *testing.T) {
+	mockConsumer := mockLogsFlaky{failures: 2}
+	receiver := &stanzareceiver{
+		consumer: &mockConsumer,
+		retry: exporterhelper.RetrySettings{
+			Enabled:         true,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  time.Minute,
+		},
+		logger: zaptest.NewLogger(t),
+	}
+
+	receiver.consume(context.Background(), []*entry.Entry{entry.New(), entry.New()})
+	require.Equal(t, 3, mockConsumer.calls)
+	require.Equal(t, 2, mockConsumer.received)
+}
+
+func TestConsumePermanentError(t *testing.T) {
+	mockConsumer := mockLogsFlaky{failures: 1, permanent: true}
+	receiver := &stanzareceiver{
+		consumer: &mockConsumer,
+		retry:    exporterhelper.DefaultRetrySettings(),
+		logger:   zaptest.NewLogger(t),
+	}
+
+	receiver.consume(context.Background(), []*entry.Entry{entry.New()})
+	require.Equal(t, 1, mockConsumer.calls)
+	require.Equal(t, 0, mockConsumer.received)
+}
+
+func TestConsumeRetryDisabled(t *testing.T) {
+	mockConsumer := mockLogsFlaky{failures: 1}
+	receiver := &stanzareceiver{
+		consumer: &mockConsumer,
+		retry:    exporterhelper.RetrySettings{Enabled: false},
+		logger:   zaptest.NewLogger(t),
+	}
+
+	receiver.consume(context.Background(), []*entry.Entry{entry.New()})
+	require.Equal(t, 1, mockConsumer.calls)
+	require.Equal(t, 0, mockConsumer.received)
+}
+
+func TestConsumeStopsRetryingOnShutdown(t *testing.T) {
+	mockConsumer := mockLogsFlaky{failures: 100}
+	receiver := &stanzareceiver{
+		consumer: &mockConsumer,
+		retry:    exporterhelper.DefaultRetrySettings(),
+		logger:   zaptest.NewLogger(t),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		receiver.consume(ctx, []*entry.Entry{entry.New()})
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "consume should return once the context is done")
+	}
+	require.Equal(t, 0, mockConsumer.received)
+}
+
 func BenchmarkReadLine(b *testing.B) {
 
 	tempDir, err := ioutil.TempDir("", "")
@@ -102,6 +172,7 @@ func BenchmarkReadLine(b *testing.B) {
 	require.NoError(b, yaml.Unmarshal([]byte(pipelineYaml), &pipelineCfg))
 
 	emitter := NewLogEmitter(zap.NewNop().Sugar())
+	require.NoError(b, emitter.Start())
 	defer emitter.Stop()
 
 	buildContext := testutil.NewBuildContext(b)
@@ -118,8 +189,10 @@ func BenchmarkReadLine(b *testing.B) {
 	// // Run the actual benchmark
 	b.ResetTimer()
 	require.NoError(b, pl.Start())
-	for i := 0; i < b.N; i++ {
-		convert(<-emitter.logChan)
+	for i := 0; i < b.N; {
+		entries := <-emitter.logChan
+		convert(entries...)
+		i += len(entries)
 	}
 }
 
@@ -160,6 +233,7 @@ func BenchmarkParseAndMap(b *testing.B) {
 	require.NoError(b, yaml.Unmarshal([]byte(pipelineYaml), &pipelineCfg))
 
 	emitter := NewLogEmitter(zap.NewNop().Sugar())
+	require.NoError(b, emitter.Start())
 	defer emitter.Stop()
 
 	buildContext := testutil.NewBuildContext(b)
@@ -176,7 +250,9 @@ func BenchmarkParseAndMap(b *testing.B) {
 	// // Run the actual benchmark
 	b.ResetTimer()
 	require.NoError(b, pl.Start())
-	for i := 0; i < b.N; i++ {
-		convert(<-emitter.logChan)
+	for i := 0; i < b.N; {
+		entries := <-emitter.logChan
+		convert(entries...)
+		i += len(entries)
 	}
 }
@@ -32,4 +32,9 @@ type Config struct {
 	// ExtractCollectdTags instructs the Wavefront receiver to attempt to extract
 	// tags in the CollectD format from the metric name. The default is false.
 	ExtractCollectdTags bool `mapstructure:"extract_collectd_tags"`
+
+	// TracingEndpoint is the address and port that receives Wavefront spans
+	// when the receiver is part of a traces pipeline. The default is
+	// "localhost:30000".
+	TracingEndpoint string `mapstructure:"tracing_endpoint"`
 }
@@ -57,6 +57,7 @@ func TestLoadConfig(t *testing.T) {
 			},
 			TCPIdleTimeout:      5 * time.Second,
 			ExtractCollectdTags: true,
+			TracingEndpoint:     "localhost:30001",
 		},
 		r1)
 }
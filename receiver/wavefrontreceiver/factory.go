@@ -40,7 +40,8 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -52,7 +53,8 @@ func createDefaultConfig() configmodels.Receiver {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: "localhost:2003",
 		},
-		TCPIdleTimeout: transport.TCPIdleTimeoutDefault,
+		TCPIdleTimeout:  transport.TCPIdleTimeoutDefault,
+		TracingEndpoint: "localhost:30000",
 	}
 }
 
@@ -87,3 +89,14 @@ func createMetricsReceiver(
 	}
 	return carbonreceiver.New(params.Logger, carbonCfg, consumer)
 }
+
+func createTracesReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.TracesConsumer,
+) (component.TracesReceiver, error) {
+	// Spans are received on their own endpoint, like the Wavefront proxy does,
+	// since the Carbon transport used for metrics only handles metrics.
+	return newTracesReceiver(params.Logger, cfg.(*Config), consumer)
+}
@@ -41,3 +41,16 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tReceiver, err := createTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tReceiver, "receiver creation failed")
+
+	cfg.TracingEndpoint = ""
+	_, err = createTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.Error(t, err)
+}
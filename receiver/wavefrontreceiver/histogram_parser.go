@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// histogramIntervals maps the prefix of a Wavefront histogram line to the
+// aggregation interval of the distribution.
+var histogramIntervals = map[string]time.Duration{
+	"!M": time.Minute,
+	"!H": time.Hour,
+	"!D": 24 * time.Hour,
+}
+
+// centroid is a Wavefront histogram centroid: the number of values aggregated
+// around a mean.
+type centroid struct {
+	count int64
+	mean  float64
+}
+
+// parseHistogram parses a Wavefront histogram distribution, see
+// https://docs.wavefront.com/wavefront_data_format.html#histogram-data-format-syntax.
+// Each line is in the following format:
+//
+// 	"{!M | !H | !D} [<timestamp>] #<count> <mean> [... #<count> <mean>] <metricName> source=<source> [pointTags]"
+//
+// The distribution is converted to a cumulative distribution that starts at
+// the beginning of the interval containing the timestamp. The centroids are
+// sorted by mean and each becomes a bucket whose bounds are the midpoints
+// between it and its neighbours.
+func (wp *WavefrontParser) parseHistogram(line string) (*metricspb.Metric, error) {
+	parts := strings.SplitN(line, " ", 2)
+	interval, ok := histogramIntervals[parts[0]]
+	if !ok || len(parts) < 2 {
+		return nil, fmt.Errorf("invalid wavefront histogram [%s]", line)
+	}
+	rest := parts[1]
+
+	next := func() string {
+		parts := strings.SplitN(rest, " ", 2)
+		rest = ""
+		if len(parts) == 2 {
+			rest = parts[1]
+		}
+		return parts[0]
+	}
+
+	token := next()
+	var ts time.Time
+	if !strings.HasPrefix(token, "#") {
+		unixTime, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp for wavefront histogram [%s]", line)
+		}
+		ts = time.Unix(unixTime, 0)
+		token = next()
+	} else {
+		ts = time.Now()
+	}
+
+	var centroids []centroid
+	for strings.HasPrefix(token, "#") {
+		count, err := strconv.ParseInt(token[1:], 10, 64)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid centroid count for wavefront histogram [%s]", line)
+		}
+		mean, err := strconv.ParseFloat(next(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid centroid mean for wavefront histogram [%s]: %v", line, err)
+		}
+		centroids = append(centroids, centroid{count: count, mean: mean})
+		token = next()
+	}
+	if len(centroids) == 0 {
+		return nil, fmt.Errorf("no centroids for wavefront histogram [%s]", line)
+	}
+
+	metricName := unDoubleQuote(token)
+	if metricName == "" {
+		return nil, fmt.Errorf("empty name for wavefront histogram [%s]", line)
+	}
+
+	metricName, labelKeys, labelValues, err := wp.buildNameAndLabels(metricName, rest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wavefront histogram [%s]: %v", line, err)
+	}
+
+	start := ts.Truncate(interval)
+	metric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      metricName,
+			Type:      metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+			LabelKeys: labelKeys,
+		},
+		Timeseries: []*metricspb.TimeSeries{
+			{
+				StartTimestamp: &timestamppb.Timestamp{Seconds: start.Unix()},
+				LabelValues:    labelValues,
+				Points: []*metricspb.Point{
+					{
+						Timestamp: &timestamppb.Timestamp{Seconds: start.Add(interval).Unix()},
+						Value: &metricspb.Point_DistributionValue{
+							DistributionValue: buildDistribution(centroids),
+						},
+					},
+				},
+			},
+		},
+	}
+	return metric, nil
+}
+
+func buildDistribution(centroids []centroid) *metricspb.DistributionValue {
+	sort.SliceStable(centroids, func(i, j int) bool {
+		return centroids[i].mean < centroids[j].mean
+	})
+
+	dist := &metricspb.DistributionValue{
+		Buckets: make([]*metricspb.DistributionValue_Bucket, 0, len(centroids)),
+	}
+	bounds := make([]float64, 0, len(centroids)-1)
+	for i, c := range centroids {
+		if i > 0 && c.mean == centroids[i-1].mean {
+			// Centroids with the same mean share their bucket.
+			dist.Buckets[len(dist.Buckets)-1].Count += c.count
+		} else {
+			if i > 0 {
+				bounds = append(bounds, (centroids[i-1].mean+c.mean)/2)
+			}
+			dist.Buckets = append(dist.Buckets, &metricspb.DistributionValue_Bucket{Count: c.count})
+		}
+		dist.Count += c.count
+		dist.Sum += float64(c.count) * c.mean
+	}
+
+	dist.BucketOptions = &metricspb.DistributionValue_BucketOptions{
+		Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+			Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+				Bounds: bounds,
+			},
+		},
+	}
+	return dist
+}
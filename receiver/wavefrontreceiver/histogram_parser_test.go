@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_wavefrontParser_ParseHistogram(t *testing.T) {
+	tests := []struct {
+		line                string
+		extractCollectDTags bool
+		want                *metricspb.Metric
+		wantErr             bool
+	}{
+		{
+			line: "!M 1533529977 #20 30.0 #10 5.1 request.latency source=appServer1 region=us-west",
+			want: buildHistogramMetric(
+				"request.latency",
+				[]string{"source", "region"},
+				[]string{"appServer1", "us-west"},
+				1533529920,
+				1533529980,
+				&metricspb.DistributionValue{
+					Count:         30,
+					Sum:           651,
+					BucketOptions: explicitBounds(17.55),
+					Buckets:       buckets(10, 20),
+				},
+			),
+		},
+		{
+			line: "!H 1533529977 #1 1 #2 2 #3 1 \"quoted.name\" source=s",
+			want: buildHistogramMetric(
+				"quoted.name",
+				[]string{"source"},
+				[]string{"s"},
+				1533528000,
+				1533531600,
+				&metricspb.DistributionValue{
+					Count:         6,
+					Sum:           8,
+					BucketOptions: explicitBounds(1.5),
+					Buckets:       buckets(4, 2),
+				},
+			),
+		},
+		{
+			line: "!D 1533529977 #5 0.5 day.metric",
+			want: buildHistogramMetric(
+				"day.metric",
+				nil,
+				nil,
+				1533513600,
+				1533600000,
+				&metricspb.DistributionValue{
+					Count:         5,
+					Sum:           2.5,
+					BucketOptions: explicitBounds(),
+					Buckets:       buckets(5),
+				},
+			),
+		},
+		{
+			line:                "!M 1533529977 #1 1 collectd.[a=b]metric source=s",
+			extractCollectDTags: true,
+			want: buildHistogramMetric(
+				"collectd.metric",
+				[]string{"source", "a"},
+				[]string{"s", "b"},
+				1533529920,
+				1533529980,
+				&metricspb.DistributionValue{
+					Count:         1,
+					Sum:           1,
+					BucketOptions: explicitBounds(),
+					Buckets:       buckets(1),
+				},
+			),
+		},
+		{
+			line:    "!W 1533529977 #1 1 invalid.interval",
+			wantErr: true,
+		},
+		{
+			line:    "!M 1533529977 no.centroids source=s",
+			wantErr: true,
+		},
+		{
+			line:    "!M 1533529977 #x 1 invalid.count",
+			wantErr: true,
+		},
+		{
+			line:    "!M 1533529977 #1 x invalid.mean",
+			wantErr: true,
+		},
+		{
+			line:    "!M 1533529977 #1 1",
+			wantErr: true,
+		},
+		{
+			line:    "!M 1533529977 #1 1 invalid.tags source",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			p := WavefrontParser{ExtractCollectdTags: tt.extractCollectDTags}
+			got, err := p.Parse(tt.line)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_wavefrontParser_ParseHistogramWithoutTimestamp(t *testing.T) {
+	p := WavefrontParser{}
+	got, err := p.Parse("!M #1 1 no.timestamp")
+	require.NoError(t, err)
+
+	start := got.Timeseries[0].StartTimestamp.AsTime()
+	end := got.Timeseries[0].Points[0].Timestamp.AsTime()
+	assert.Equal(t, time.Minute, end.Sub(start))
+	assert.WithinDuration(t, time.Now().Truncate(time.Minute), start, time.Minute)
+}
+
+func buildHistogramMetric(
+	name string,
+	keys []string,
+	values []string,
+	start int64,
+	end int64,
+	dist *metricspb.DistributionValue,
+) *metricspb.Metric {
+	metric := buildMetric(
+		metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+		name,
+		keys,
+		values,
+		&metricspb.Point{
+			Timestamp: &timestamppb.Timestamp{Seconds: end},
+			Value:     &metricspb.Point_DistributionValue{DistributionValue: dist},
+		},
+	)
+	metric.Timeseries[0].StartTimestamp = &timestamppb.Timestamp{Seconds: start}
+	return metric
+}
+
+func explicitBounds(bounds ...float64) *metricspb.DistributionValue_BucketOptions {
+	if bounds == nil {
+		bounds = []float64{}
+	}
+	return &metricspb.DistributionValue_BucketOptions{
+		Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+			Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+				Bounds: bounds,
+			},
+		},
+	}
+}
+
+func buckets(counts ...int64) []*metricspb.DistributionValue_Bucket {
+	bs := make([]*metricspb.DistributionValue_Bucket, 0, len(counts))
+	for _, c := range counts {
+		bs = append(bs, &metricspb.DistributionValue_Bucket{Count: c})
+	}
+	return bs
+}
@@ -152,3 +152,38 @@ func Test_wavefrontreceiver_EndToEnd(t *testing.T) {
 		sink.Reset()
 	}
 }
+
+func Test_wavefrontreceiver_Traces(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.TCPIdleTimeout = time.Second
+	addr := testutil.GetAvailableLocalAddress(t)
+	rCfg.TracingEndpoint = addr
+
+	sink := new(consumertest.TracesSink)
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	rcvr, err := createTracesReceiver(context.Background(), params, rCfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer rcvr.Shutdown(context.Background())
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	msg := "op0 source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=0313bafe-9457-11e8-9eb6-529269fb1459 1552949776000 343\n" +
+		"invalid span line\n" +
+		"op1 source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=2f64e538-9457-11e8-9eb6-529269fb1459 1552949776100 10"
+	_, err = fmt.Fprint(conn, msg)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	testutil.WaitFor(t, func() bool {
+		return sink.SpansCount() == 2
+	})
+
+	var names []string
+	for _, td := range sink.AllTraces() {
+		names = append(names, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+	}
+	assert.Equal(t, []string{"op0", "op1"}, names)
+}
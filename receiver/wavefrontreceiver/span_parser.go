@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// Tags of Wavefront spans with a special meaning, see
+// https://docs.wavefront.com/trace_data_details.html#span-tags.
+const (
+	tagTraceID     = "traceId"
+	tagSpanID      = "spanId"
+	tagParent      = "parent"
+	tagFollowsFrom = "followsFrom"
+	tagSource      = "source"
+	tagService     = "service"
+	tagSpanKind    = "span.kind"
+	tagError       = "error"
+)
+
+// resourceTags are the tags of Wavefront spans that describe the application
+// instead of the span, they are set as resource attributes.
+var resourceTags = map[string]string{
+	tagSource:     conventions.AttributeHostName,
+	tagService:    conventions.AttributeServiceName,
+	"application": "application",
+	"cluster":     "cluster",
+	"shard":       "shard",
+}
+
+var spanKinds = map[string]pdata.SpanKind{
+	"client":   pdata.SpanKindCLIENT,
+	"server":   pdata.SpanKindSERVER,
+	"producer": pdata.SpanKindPRODUCER,
+	"consumer": pdata.SpanKindCONSUMER,
+	"internal": pdata.SpanKindINTERNAL,
+}
+
+// parseSpan parses a Wavefront span, see
+// https://docs.wavefront.com/trace_data_details.html#wavefront-span-format,
+// into the collector trace format. Each line is in the following format:
+//
+// 	"<operationName> source=<source> <spanTags> <start_milliseconds> <duration_milliseconds>"
+//
+// The "traceId", "spanId", "parent" and "followsFrom" tags hold UUIDs: the
+// trace ID is the whole UUID while span IDs are folded to 8 bytes. Parents
+// after the first one and "followsFrom" spans are added as links.
+func parseSpan(line string) (pdata.Traces, error) {
+	td := pdata.NewTraces()
+
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) < 2 {
+		return td, fmt.Errorf("invalid wavefront span [%s]", line)
+	}
+	name := unDoubleQuote(parts[0])
+	if name == "" {
+		return td, fmt.Errorf("empty name for wavefront span [%s]", line)
+	}
+	rest := parts[1]
+
+	// The start and duration are the last two fields of the line.
+	idx := strings.LastIndexByte(rest, ' ')
+	if idx == -1 {
+		return td, fmt.Errorf("invalid wavefront span [%s]", line)
+	}
+	duration, err := strconv.ParseInt(rest[idx+1:], 10, 64)
+	if err != nil || duration < 0 {
+		return td, fmt.Errorf("invalid duration for wavefront span [%s]", line)
+	}
+	rest = rest[:idx]
+	idx = strings.LastIndexByte(rest, ' ')
+	if idx == -1 {
+		return td, fmt.Errorf("invalid wavefront span [%s]", line)
+	}
+	start, err := strconv.ParseInt(rest[idx+1:], 10, 64)
+	if err != nil {
+		return td, fmt.Errorf("invalid start for wavefront span [%s]", line)
+	}
+
+	keys, values, err := buildLabels(strings.TrimSpace(rest[:idx]))
+	if err != nil {
+		return td, fmt.Errorf("invalid wavefront span [%s]: %v", line, err)
+	}
+
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.Spans().Resize(1)
+	span := ils.Spans().At(0)
+
+	span.SetName(name)
+	startTime := time.Unix(0, start*int64(time.Millisecond))
+	span.SetStartTime(pdata.TimestampUnixNano(startTime.UnixNano()))
+	span.SetEndTime(pdata.TimestampUnixNano(startTime.Add(time.Duration(duration) * time.Millisecond).UnixNano()))
+
+	var hasTraceID, hasSpanID, hasParent bool
+	for i, key := range keys {
+		value := values[i].Value
+		switch key.Key {
+		case tagTraceID:
+			id, err := parseUUID(value)
+			if err != nil {
+				return td, fmt.Errorf("invalid traceId for wavefront span [%s]: %v", line, err)
+			}
+			span.SetTraceID(pdata.NewTraceID(id))
+			hasTraceID = true
+		case tagSpanID:
+			id, err := parseUUID(value)
+			if err != nil {
+				return td, fmt.Errorf("invalid spanId for wavefront span [%s]: %v", line, err)
+			}
+			span.SetSpanID(spanIDFromUUID(id))
+			hasSpanID = true
+		case tagParent, tagFollowsFrom:
+			id, err := parseUUID(value)
+			if err != nil {
+				return td, fmt.Errorf("invalid %s for wavefront span [%s]: %v", key.Key, line, err)
+			}
+			if key.Key == tagParent && !hasParent {
+				span.SetParentSpanID(spanIDFromUUID(id))
+				hasParent = true
+				continue
+			}
+			span.Links().Resize(span.Links().Len() + 1)
+			span.Links().At(span.Links().Len() - 1).SetSpanID(spanIDFromUUID(id))
+		case tagSpanKind:
+			if kind, ok := spanKinds[strings.ToLower(value)]; ok {
+				span.SetKind(kind)
+			} else {
+				span.Attributes().UpsertString(key.Key, value)
+			}
+		case tagError:
+			if strings.EqualFold(value, "true") {
+				span.Status().SetCode(pdata.StatusCodeError)
+			}
+			span.Attributes().UpsertString(key.Key, value)
+		default:
+			if attr, ok := resourceTags[key.Key]; ok {
+				rs.Resource().Attributes().UpsertString(attr, value)
+			} else {
+				span.Attributes().UpsertString(key.Key, value)
+			}
+		}
+	}
+
+	if !hasTraceID || !hasSpanID {
+		return td, fmt.Errorf("wavefront span without traceId or spanId [%s]", line)
+	}
+
+	// Links share the trace of the span.
+	for i := 0; i < span.Links().Len(); i++ {
+		span.Links().At(i).SetTraceID(span.TraceID())
+	}
+
+	return td, nil
+}
+
+// parseUUID parses a UUID, with or without dashes, into its bytes.
+func parseUUID(s string) ([16]byte, error) {
+	var id [16]byte
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		return id, err
+	}
+	if len(b) != len(id) {
+		return id, fmt.Errorf("expected %d bytes, got %d", len(id), len(b))
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// spanIDFromUUID folds the UUID into a span ID by XORing its two halves. This
+// keeps the 64 bits IDs that are stored zero-padded in UUIDs, e.g. by the
+// Wavefront proxy for Zipkin and Jaeger spans, and the varying time fields of
+// time-based UUIDs, whose last 8 bytes are the same for all spans of a host.
+func spanIDFromUUID(id [16]byte) pdata.SpanID {
+	var spanID [8]byte
+	for i := range spanID {
+		spanID[i] = id[i] ^ id[i+8]
+	}
+	return pdata.NewSpanID(spanID)
+}
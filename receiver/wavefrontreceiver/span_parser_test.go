@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func Test_parseSpan(t *testing.T) {
+	line := `getAllUsers source=localhost traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 ` +
+		`spanId=0313bafe-9457-11e8-9eb6-529269fb1459 parent=2f64e538-9457-11e8-9eb6-529269fb1459 ` +
+		`followsFrom=5f64e538-9457-11e8-9eb6-529269fb1459 application=Wavefront service=auth ` +
+		`span.kind=server error=true http.method="GET" 1552949776000 343`
+
+	td, err := parseSpan(line)
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, map[string]string{
+		"host.name":    "localhost",
+		"service.name": "auth",
+		"application":  "Wavefront",
+	}, attributesToMap(rs.Resource().Attributes()))
+
+	span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "getAllUsers", span.Name())
+	assert.Equal(t,
+		pdata.NewTraceID([16]byte{0x7b, 0x3b, 0xf4, 0x70, 0x94, 0x56, 0x11, 0xe8, 0x9e, 0xb6, 0x52, 0x92, 0x69, 0xfb, 0x14, 0x59}),
+		span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0x9d, 0xa5, 0xe8, 0x6c, 0xfd, 0xac, 0x05, 0xb1}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0xb1, 0xd2, 0xb7, 0xaa, 0xfd, 0xac, 0x05, 0xb1}), span.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindSERVER, span.Kind())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, pdata.TimestampUnixNano(1552949776000000000), span.StartTime())
+	assert.Equal(t, pdata.TimestampUnixNano(1552949776343000000), span.EndTime())
+	assert.Equal(t, map[string]string{
+		"error":       "true",
+		"http.method": "GET",
+	}, attributesToMap(span.Attributes()))
+
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, span.TraceID(), span.Links().At(0).TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{0xc1, 0xd2, 0xb7, 0xaa, 0xfd, 0xac, 0x05, 0xb1}), span.Links().At(0).SpanID())
+}
+
+func Test_spanIDFromUUID(t *testing.T) {
+	// IDs of 64 bits are zero-padded in the UUID.
+	id, err := parseUUID("00000000-0000-0000-0102-030405060708")
+	require.NoError(t, err)
+	assert.Equal(t, pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), spanIDFromUUID(id))
+}
+
+func Test_parseSpanInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{
+			name: "missing_duration",
+			line: "op source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=0313bafe-9457-11e8-9eb6-529269fb1459 1552949776000",
+		},
+		{
+			name: "invalid_duration",
+			line: "op source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=0313bafe-9457-11e8-9eb6-529269fb1459 1552949776000 x",
+		},
+		{
+			name: "invalid_start",
+			line: "op source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=0313bafe-9457-11e8-9eb6-529269fb1459 x 343",
+		},
+		{
+			name: "missing_trace_id",
+			line: "op source=s spanId=0313bafe-9457-11e8-9eb6-529269fb1459 1552949776000 343",
+		},
+		{
+			name: "missing_span_id",
+			line: "op source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 1552949776000 343",
+		},
+		{
+			name: "invalid_trace_id",
+			line: "op source=s traceId=xyz spanId=0313bafe-9457-11e8-9eb6-529269fb1459 1552949776000 343",
+		},
+		{
+			name: "short_parent",
+			line: "op source=s traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 spanId=0313bafe-9457-11e8-9eb6-529269fb1459 parent=0313bafe 1552949776000 343",
+		},
+		{
+			name: "invalid_tags",
+			line: "op source traceId=7b3bf470-9456-11e8-9eb6-529269fb1459 1552949776000 343",
+		},
+		{
+			name: "empty_name",
+			line: `"" source=s 1552949776000 343`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSpan(tt.line)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v.StringVal()
+	})
+	return m
+}
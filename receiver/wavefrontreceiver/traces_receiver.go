@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	spansTransport = "tcp"
+	spansFormat    = "wavefront"
+)
+
+// tracesReceiver receives Wavefront spans, one per line, over TCP.
+type tracesReceiver struct {
+	sync.Mutex
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.TracesConsumer
+
+	ln       net.Listener
+	wg       sync.WaitGroup
+	connsMtx sync.Mutex
+	conns    map[net.Conn]struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+var _ component.TracesReceiver = (*tracesReceiver)(nil)
+
+func newTracesReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.TracesConsumer,
+) (*tracesReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if config.TracingEndpoint == "" {
+		return nil, errors.New("empty tracing_endpoint")
+	}
+
+	return &tracesReceiver{
+		logger:       logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		conns:        make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// Start starts listening for Wavefront spans.
+func (r *tracesReceiver) Start(_ context.Context, host component.Host) error {
+	r.Lock()
+	defer r.Unlock()
+
+	err := componenterror.ErrAlreadyStarted
+	r.startOnce.Do(func() {
+		r.ln, err = net.Listen("tcp", r.config.TracingEndpoint)
+		if err != nil {
+			return
+		}
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			if err := r.acceptConnections(); err != nil {
+				host.ReportFatalError(err)
+			}
+		}()
+	})
+	return err
+}
+
+// Shutdown stops listening and closes the open connections.
+func (r *tracesReceiver) Shutdown(context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+
+	err := componenterror.ErrAlreadyStopped
+	r.stopOnce.Do(func() {
+		err = nil
+		if r.ln == nil {
+			return
+		}
+		err = r.ln.Close()
+
+		r.connsMtx.Lock()
+		for conn := range r.conns {
+			conn.Close()
+		}
+		r.connsMtx.Unlock()
+
+		r.wg.Wait()
+	})
+	return err
+}
+
+func (r *tracesReceiver) acceptConnections() error {
+	for {
+		conn, err := r.ln.Accept()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				continue
+			}
+			if strings.Contains(err.Error(), "use of closed network connection") {
+				// The listener was closed by Shutdown.
+				return nil
+			}
+			return err
+		}
+
+		r.connsMtx.Lock()
+		r.conns[conn] = struct{}{}
+		r.connsMtx.Unlock()
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.handleConnection(conn)
+
+			r.connsMtx.Lock()
+			delete(r.conns, conn)
+			r.connsMtx.Unlock()
+		}()
+	}
+}
+
+func (r *tracesReceiver) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(r.config.TCPIdleTimeout)); err != nil {
+			r.logger.Debug("Failed to set the connection deadline", zap.Error(err))
+			return
+		}
+
+		// It is possible to have data in line and err to be io.EOF.
+		line, readErr := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line != "" {
+			if err := r.consumeSpan(line); err != nil {
+				// See the carbon receiver on why the connection is closed.
+				return
+			}
+		}
+
+		if readErr != nil {
+			r.logger.Debug("Closing Wavefront spans connection", zap.Error(readErr))
+			return
+		}
+	}
+}
+
+// consumeSpan parses and pushes the span on the line to the next consumer.
+// Only errors from the next consumer are returned, lines that can't be parsed
+// are logged and dropped.
+func (r *tracesReceiver) consumeSpan(line string) error {
+	ctx := obsreport.ReceiverContext(context.Background(), r.config.Name(), spansTransport)
+	ctx = obsreport.StartTraceDataReceiveOp(ctx, r.config.Name(), spansTransport)
+
+	td, err := parseSpan(line)
+	if err != nil {
+		r.logger.Debug("Wavefront translation error", zap.String("receiver", r.config.Name()), zap.Error(err))
+		obsreport.EndTraceDataReceiveOp(ctx, spansFormat, 1, err)
+		return nil
+	}
+
+	err = r.nextConsumer.ConsumeTraces(ctx, td)
+	obsreport.EndTraceDataReceiveOp(ctx, spansFormat, 1, err)
+	return err
+}
@@ -57,8 +57,14 @@ func (wp *WavefrontParser) BuildParser() (protocol.Parser, error) {
 //
 // 	"<metricName> <metricValue> [<timestamp>] source=<source> [pointTags]"
 //
-// Detailed description of each element is available on the link above.
+// Detailed description of each element is available on the link above. Lines
+// starting with "!" are parsed as Wavefront histogram distributions, see
+// parseHistogram.
 func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
+	if strings.HasPrefix(line, "!") {
+		return wp.parseHistogram(line)
+	}
+
 	parts := strings.SplitN(line, " ", 3)
 	if len(parts) < 3 {
 		return nil, fmt.Errorf("invalid wavefront metric [%s]", line)
@@ -106,20 +112,9 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 	}
 	point.Timestamp = &ts
 
-	var labelKeys []*metricspb.LabelKey
-	var labelValues []*metricspb.LabelValue
-	if tags != "" {
-		// to need for special treatment for source, treat it as a normal tag since
-		// tags are separated by space and are optionally double-quoted.
-		var err error
-		labelKeys, labelValues, err = buildLabels(tags)
-		if err != nil {
-			return nil, fmt.Errorf("invalid wavefront metric [%s]: %v", line, err)
-		}
-	}
-
-	if wp.ExtractCollectdTags {
-		metricName, labelKeys, labelValues = wp.injectCollectDLabels(metricName, labelKeys, labelValues)
+	metricName, labelKeys, labelValues, err := wp.buildNameAndLabels(metricName, tags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wavefront metric [%s]: %v", line, err)
 	}
 
 	metric := &metricspb.Metric{
@@ -138,6 +133,25 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 	return metric, nil
 }
 
+// buildNameAndLabels builds the labels from the tags of a Wavefront line and
+// extracts the CollectD tags from the metric name if configured to do so.
+func (wp *WavefrontParser) buildNameAndLabels(
+	metricName string,
+	tags string,
+) (string, []*metricspb.LabelKey, []*metricspb.LabelValue, error) {
+	// to need for special treatment for source, treat it as a normal tag since
+	// tags are separated by space and are optionally double-quoted.
+	labelKeys, labelValues, err := buildLabels(tags)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if wp.ExtractCollectdTags {
+		metricName, labelKeys, labelValues = wp.injectCollectDLabels(metricName, labelKeys, labelValues)
+	}
+	return metricName, labelKeys, labelValues, nil
+}
+
 func (wp *WavefrontParser) injectCollectDLabels(
 	metricName string,
 	labelKeys []*metricspb.LabelKey,
@@ -19,6 +19,8 @@ import (
 
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver/internal/metadata"
 )
 
 type Config struct {
@@ -27,4 +29,7 @@ type Config struct {
 
 	// Timeout within which requests should be completed.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Metrics allows to disable or rename the emitted metrics.
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
 }
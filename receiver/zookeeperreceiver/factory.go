@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver/internal/metadata"
 )
 
 const (
@@ -54,6 +56,7 @@ func createDefaultConfig() configmodels.Receiver {
 			Endpoint: ":2181",
 		},
 		Timeout: defaultTimeout,
+		Metrics: metadata.DefaultMetricsSettings(),
 	}
 }
 
@@ -351,6 +351,130 @@ var Metrics = &metricStruct{
 // manipulating those metrics. M is an alias for Metrics
 var M = Metrics
 
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	// Enabled determines whether the metric is emitted.
+	Enabled bool `mapstructure:"enabled"`
+	// Name, when set, replaces the name the metric is emitted with.
+	Name string `mapstructure:"name"`
+}
+
+// MetricsSettings provides settings for zookeeperreceiver metrics.
+type MetricsSettings struct {
+	ZookeeperApproximateDateSize   MetricSettings `mapstructure:"zookeeper.approximate_date_size"`
+	ZookeeperConnectionsAlive      MetricSettings `mapstructure:"zookeeper.connections_alive"`
+	ZookeeperEphemeralNodes        MetricSettings `mapstructure:"zookeeper.ephemeral_nodes"`
+	ZookeeperFollowers             MetricSettings `mapstructure:"zookeeper.followers"`
+	ZookeeperFsyncThresholdExceeds MetricSettings `mapstructure:"zookeeper.fsync_threshold_exceeds"`
+	ZookeeperLatencyAvg            MetricSettings `mapstructure:"zookeeper.latency.avg"`
+	ZookeeperLatencyMax            MetricSettings `mapstructure:"zookeeper.latency.max"`
+	ZookeeperLatencyMin            MetricSettings `mapstructure:"zookeeper.latency.min"`
+	ZookeeperMaxFileDescriptors    MetricSettings `mapstructure:"zookeeper.max_file_descriptors"`
+	ZookeeperOpenFileDescriptors   MetricSettings `mapstructure:"zookeeper.open_file_descriptors"`
+	ZookeeperOutstandingRequests   MetricSettings `mapstructure:"zookeeper.outstanding_requests"`
+	ZookeeperPacketsReceived       MetricSettings `mapstructure:"zookeeper.packets.received"`
+	ZookeeperPacketsSent           MetricSettings `mapstructure:"zookeeper.packets.sent"`
+	ZookeeperPendingSyncs          MetricSettings `mapstructure:"zookeeper.pending_syncs"`
+	ZookeeperSyncedFollowers       MetricSettings `mapstructure:"zookeeper.synced_followers"`
+	ZookeeperWatches               MetricSettings `mapstructure:"zookeeper.watches"`
+	ZookeeperZnodes                MetricSettings `mapstructure:"zookeeper.znodes"`
+}
+
+// DefaultMetricsSettings returns the default settings, emitting all the metrics under their own names.
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		ZookeeperApproximateDateSize:   MetricSettings{Enabled: true},
+		ZookeeperConnectionsAlive:      MetricSettings{Enabled: true},
+		ZookeeperEphemeralNodes:        MetricSettings{Enabled: true},
+		ZookeeperFollowers:             MetricSettings{Enabled: true},
+		ZookeeperFsyncThresholdExceeds: MetricSettings{Enabled: true},
+		ZookeeperLatencyAvg:            MetricSettings{Enabled: true},
+		ZookeeperLatencyMax:            MetricSettings{Enabled: true},
+		ZookeeperLatencyMin:            MetricSettings{Enabled: true},
+		ZookeeperMaxFileDescriptors:    MetricSettings{Enabled: true},
+		ZookeeperOpenFileDescriptors:   MetricSettings{Enabled: true},
+		ZookeeperOutstandingRequests:   MetricSettings{Enabled: true},
+		ZookeeperPacketsReceived:       MetricSettings{Enabled: true},
+		ZookeeperPacketsSent:           MetricSettings{Enabled: true},
+		ZookeeperPendingSyncs:          MetricSettings{Enabled: true},
+		ZookeeperSyncedFollowers:       MetricSettings{Enabled: true},
+		ZookeeperWatches:               MetricSettings{Enabled: true},
+		ZookeeperZnodes:                MetricSettings{Enabled: true},
+	}
+}
+
+func (ms *MetricsSettings) byName(n string) (MetricSettings, bool) {
+	switch n {
+	case "zookeeper.approximate_date_size":
+		return ms.ZookeeperApproximateDateSize, true
+	case "zookeeper.connections_alive":
+		return ms.ZookeeperConnectionsAlive, true
+	case "zookeeper.ephemeral_nodes":
+		return ms.ZookeeperEphemeralNodes, true
+	case "zookeeper.followers":
+		return ms.ZookeeperFollowers, true
+	case "zookeeper.fsync_threshold_exceeds":
+		return ms.ZookeeperFsyncThresholdExceeds, true
+	case "zookeeper.latency.avg":
+		return ms.ZookeeperLatencyAvg, true
+	case "zookeeper.latency.max":
+		return ms.ZookeeperLatencyMax, true
+	case "zookeeper.latency.min":
+		return ms.ZookeeperLatencyMin, true
+	case "zookeeper.max_file_descriptors":
+		return ms.ZookeeperMaxFileDescriptors, true
+	case "zookeeper.open_file_descriptors":
+		return ms.ZookeeperOpenFileDescriptors, true
+	case "zookeeper.outstanding_requests":
+		return ms.ZookeeperOutstandingRequests, true
+	case "zookeeper.packets.received":
+		return ms.ZookeeperPacketsReceived, true
+	case "zookeeper.packets.sent":
+		return ms.ZookeeperPacketsSent, true
+	case "zookeeper.pending_syncs":
+		return ms.ZookeeperPendingSyncs, true
+	case "zookeeper.synced_followers":
+		return ms.ZookeeperSyncedFollowers, true
+	case "zookeeper.watches":
+		return ms.ZookeeperWatches, true
+	case "zookeeper.znodes":
+		return ms.ZookeeperZnodes, true
+	}
+	return MetricSettings{}, false
+}
+
+// Enabled returns whether the metric with the given name is emitted. Unknown metrics are never emitted.
+func (ms *MetricsSettings) Enabled(n string) bool {
+	settings, ok := ms.byName(n)
+	return ok && settings.Enabled
+}
+
+// FactoriesByName returns the factories of the enabled metrics, keyed by the metric names
+// from metadata.yaml. The created metrics carry the configured name when one is set.
+func (ms *MetricsSettings) FactoriesByName() map[string]func() pdata.Metric {
+	factories := make(map[string]func() pdata.Metric)
+	for name, metric := range metricsByName {
+		settings, _ := ms.byName(name)
+		if !settings.Enabled {
+			continue
+		}
+		if settings.Name == "" {
+			factories[name] = metric.New
+			continue
+		}
+		factories[name] = renamed(metric.New, settings.Name)
+	}
+	return factories
+}
+
+func renamed(newFunc func() pdata.Metric, name string) func() pdata.Metric {
+	return func() pdata.Metric {
+		metric := newFunc()
+		metric.SetName(name)
+		return metric
+	}
+}
+
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
 	// ServerState (State of the Zookeeper server (leader, standalone or follower).)
@@ -124,11 +124,14 @@ func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pdata.Resou
 		Metrics:                    pdata.NewMetrics(),
 		Timestamp:                  time.Now(),
 		InstrumentationLibraryName: "otelcol/zookeeper",
-		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		MetricFactoriesByName:      z.config.Metrics.FactoriesByName(),
 		ResourceAttributes:         attributes,
 	}
 
 	for _, stat := range stats {
+		if !z.config.Metrics.Enabled(stat.metric.Name()) {
+			continue
+		}
 		// Currently the receiver only deals with one metric type.
 		switch stat.metric.DataType() {
 		case pdata.MetricDataTypeIntGauge:
@@ -67,6 +67,7 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 		expectedMetrics              []pdata.Metric
 		expectedResourceAttributes   map[string]string
 		mockedZKOutputSourceFilename string
+		metricsSettings              func(*metadata.MetricsSettings)
 		mockZKConnectionErr          bool
 		expectedLogs                 []logMsg
 		expectedNumResourceMetrics   int
@@ -105,6 +106,35 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			},
 			expectedNumResourceMetrics: 1,
 		},
+		{
+			name:                         "Disabled and renamed metrics",
+			mockedZKOutputSourceFilename: "mntr-3.4.14",
+			metricsSettings: func(ms *metadata.MetricsSettings) {
+				ms.ZookeeperWatches.Enabled = false
+				ms.ZookeeperFsyncThresholdExceeds.Enabled = false
+				ms.ZookeeperZnodes.Name = "zookeeper.znode.count"
+			},
+			expectedMetrics: func() []pdata.Metric {
+				out := make([]pdata.Metric, 0, len(commonMetrics))
+				for _, metric := range commonMetrics {
+					switch metric.Name() {
+					case metadata.M.ZookeeperWatches.Name():
+						continue
+					case metadata.M.ZookeeperZnodes.Name():
+						renamed := metadata.M.ZookeeperZnodes.New()
+						renamed.SetName("zookeeper.znode.count")
+						metric = renamed
+					}
+					out = append(out, metric)
+				}
+				return out
+			}(),
+			expectedResourceAttributes: map[string]string{
+				"server.state": "standalone",
+				"zk.version":   "3.4.14-4c25d480e66aadd371de8bd2fd8da255ac140bcf",
+			},
+			expectedNumResourceMetrics: 1,
+		},
 		{
 			name:                "Arbitrary connection error",
 			mockZKConnectionErr: true,
@@ -202,6 +232,10 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 					Endpoint: localAddr,
 				},
 				Timeout: defaultTimeout,
+				Metrics: metadata.DefaultMetricsSettings(),
+			}
+			if tt.metricsSettings != nil {
+				tt.metricsSettings(&cfg.Metrics)
 			}
 
 			core, observedLogs := observer.New(zap.DebugLevel)